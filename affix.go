@@ -0,0 +1,340 @@
+package rerand
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"regexp/syntax"
+)
+
+// ErrNoAffix is returned by GenerateWithPrefix and GenerateWithSuffix if no matching string has the prefix or the suffix.
+var ErrNoAffix = errors.New("rerand: no matching string has the affix")
+
+// GenerateWithPrefix generates a random string that matches the pattern and starts with prefix,
+// e.g. an ID in a known namespace.
+// The program is walked along the prefix, instead of rejecting the strings without it.
+// The choices are weighted by the probabilities that they lead to the prefix,
+// so the strings with the prefix are as likely as Generate generates them.
+// It returns ErrNoAffix if no matching string starts with prefix.
+// The string is not transformed by WithTransform, and WithDependency and WithGraphemeClusters are ignored.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateWithPrefix(prefix string) (string, error) {
+	return g.generateAffix(newPrefixAffix([]rune(prefix)))
+}
+
+// GenerateWithSuffix generates a random string that matches the pattern and ends with suffix.
+// It is the counterpart of GenerateWithPrefix.
+// It returns ErrNoAffix if no matching string ends with suffix.
+// The string is not transformed by WithTransform, and WithDependency and WithGraphemeClusters are ignored.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateWithSuffix(suffix string) (string, error) {
+	return g.generateAffix(newSuffixAffix([]rune(suffix)))
+}
+
+// affix is the automaton that accepts the strings with the prefix or the suffix.
+// The states are from 0 to len(runes), and len(runes) is the accepting state.
+type affix struct {
+	runes []rune
+
+	// next returns the state after reading c in the state q, or -1 if the string is rejected.
+	// c is -1 for the runes that are not in runes.
+	next func(q int, c rune) int
+}
+
+func newPrefixAffix(prefix []rune) *affix {
+	return &affix{
+		runes: prefix,
+		next: func(q int, c rune) int {
+			if q == len(prefix) {
+				return q
+			}
+			if prefix[q] == c {
+				return q + 1
+			}
+			return -1
+		},
+	}
+}
+
+func newSuffixAffix(suffix []rune) *affix {
+	// the failure function of the Knuth-Morris-Pratt algorithm
+	fail := make([]int, len(suffix)+1)
+	for i := 1; i < len(suffix); i++ {
+		k := fail[i]
+		for k > 0 && suffix[k] != suffix[i] {
+			k = fail[k]
+		}
+		if suffix[k] == suffix[i] {
+			k++
+		}
+		fail[i+1] = k
+	}
+	return &affix{
+		runes: suffix,
+		next: func(q int, c rune) int {
+			for {
+				if q < len(suffix) && suffix[q] == c {
+					return q + 1
+				}
+				if q == 0 {
+					return 0
+				}
+				q = fail[q]
+			}
+		},
+	}
+}
+
+// generateAffix generates a random string that the affix accepts.
+// The choices are made with the probabilities conditioned on the acceptance,
+// so the strings are distributed as the generator generates them, except for the ones without the affix.
+func (g *Generator) generateAffix(a *affix) (string, error) {
+	w := g.newAffixWalk(a)
+	if !w.ok[w.index(uint32(g.prog.Start), 0)] {
+		return "", ErrNoAffix
+	}
+	return g.filter(context.Background(), func() (string, error) {
+		var result []rune
+		limit := g.lengthLimit(0)
+		pc, q := uint32(g.prog.Start), 0
+		for {
+			in := &g.inst[pc]
+			switch in.Op {
+			case syntax.InstMatch:
+				return string(result), nil
+			case syntax.InstRune, syntax.InstRune1:
+				var c rune
+				if in.Op == syntax.InstRune1 {
+					c = in.Rune[0]
+				} else {
+					c = g.affixRune(w, in, q)
+				}
+				result = append(result, c)
+				if len(result) > limit {
+					result, err := g.tooLong(result, limit)
+					return string(result), err
+				}
+				q = a.next(q, a.index(c))
+				pc = in.Out
+			case syntax.InstAlt:
+				out, arg := w.index(in.Out, q), w.index(in.Arg, q)
+				p := in.altProb()
+				if w.choose(g, []int{out, arg}, []float64{p * w.prob[out], (1 - p) * w.prob[arg]}) == 0 {
+					pc = in.Out
+				} else {
+					pc = in.Arg
+				}
+			default:
+				pc = in.Out
+			}
+		}
+	})
+}
+
+// index returns c if it is in the runes of the affix, otherwise -1.
+func (a *affix) index(c rune) rune {
+	if containsRune(a.runes, c) {
+		return c
+	}
+	return -1
+}
+
+// affixWalk is the product of the program and the affix.
+// The state is the pair of the instruction pc and the state q of the affix, at the index pc*(len(a.runes)+1)+q.
+type affixWalk struct {
+	a      *affix
+	states int
+
+	// ok is whether the string generated from the state can be accepted.
+	ok []bool
+
+	// prob is the probability that the string generated from the state is accepted.
+	// It may underflow to zero even if ok is true.
+	prob []float64
+}
+
+// maxAffixIterations is the maximum number of the iterations that compute the probabilities of affixWalk.
+const maxAffixIterations = 10000
+
+func (g *Generator) newAffixWalk(a *affix) *affixWalk {
+	w := &affixWalk{
+		a:      a,
+		states: len(a.runes) + 1,
+	}
+	w.ok = make([]bool, len(g.inst)*w.states)
+	w.prob = make([]float64, len(g.inst)*w.states)
+
+	// ok is the least fixed point, and prob converges to it from below.
+	for iter, changed := 0, true; changed && iter < maxAffixIterations; iter++ {
+		changed = false
+		for pc := range g.inst {
+			in := &g.inst[pc]
+			for q := 0; q < w.states; q++ {
+				var ok bool
+				var prob float64
+				switch in.Op {
+				case syntax.InstMatch:
+					ok = q == len(a.runes)
+					if ok {
+						prob = 1
+					}
+				case syntax.InstRune, syntax.InstRune1:
+					for _, t := range w.transitions(in, q) {
+						ok = ok || w.ok[t.next]
+						prob += t.weight * w.prob[t.next]
+					}
+				case syntax.InstAlt:
+					out, arg := w.index(in.Out, q), w.index(in.Arg, q)
+					p := in.altProb()
+					ok = w.ok[out] || w.ok[arg]
+					prob = p*w.prob[out] + (1-p)*w.prob[arg]
+				case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+					out := w.index(in.Out, q)
+					ok = w.ok[out]
+					prob = w.prob[out]
+				}
+				i := w.index(uint32(pc), q)
+				if ok && !w.ok[i] {
+					w.ok[i] = true
+					changed = true
+				}
+				if prob > w.prob[i]*(1+1e-12) {
+					changed = true
+				}
+				w.prob[i] = prob
+			}
+		}
+	}
+	return w
+}
+
+func (w *affixWalk) index(pc uint32, q int) int {
+	return int(pc)*w.states + q
+}
+
+// affixTransition is a transition of InstRune in affixWalk.
+type affixTransition struct {
+	c      rune    // the rune of the affix, or -1 for the other runes
+	next   int     // the next state
+	weight float64 // the probability of the transition
+}
+
+// transitions returns the transitions of InstRune or InstRune1 in the state q.
+// The runes are assumed to be generated uniformly from the class.
+func (w *affixWalk) transitions(in *myinst, q int) []affixTransition {
+	a := w.a
+	if in.Op == syntax.InstRune1 {
+		c := a.index(in.Rune[0])
+		if n := a.next(q, c); n >= 0 {
+			return []affixTransition{{c: c, next: w.index(in.Out, n), weight: 1}}
+		}
+		return nil
+	}
+
+	var size float64
+	runes := in.runeGenerator.runes
+	if len(runes) == 1 {
+		size = 1
+	} else {
+		for i := 0; i < len(runes); i += 2 {
+			size += float64(runes[i+1]-runes[i]) + 1
+		}
+	}
+	var matched []rune
+	var ts []affixTransition
+	for _, c := range a.runes {
+		if !in.matchRune(c) || containsRune(matched, c) {
+			continue
+		}
+		matched = append(matched, c)
+		if n := a.next(q, c); n >= 0 {
+			ts = append(ts, affixTransition{c: c, next: w.index(in.Out, n), weight: 1 / size})
+		}
+	}
+	if other := size - float64(len(matched)); other > 0 {
+		if n := a.next(q, -1); n >= 0 {
+			ts = append(ts, affixTransition{c: -1, next: w.index(in.Out, n), weight: other / size})
+		}
+	}
+	return ts
+}
+
+// choose chooses one of the states with the probabilities proportional to the weights, and returns the index.
+// If all the weights underflow to zero, it chooses one of the acceptable states uniformly.
+func (w *affixWalk) choose(g *Generator, states []int, weights []float64) int {
+	var sum float64
+	for _, weight := range weights {
+		sum += weight
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if sum > 0 {
+		x := g.rand.Float64() * sum
+		for i, weight := range weights {
+			if weight > 0 && x < weight {
+				return i
+			}
+			x -= weight
+		}
+		for i := len(weights) - 1; i >= 0; i-- {
+			if weights[i] > 0 {
+				return i
+			}
+		}
+	}
+	var candidates []int
+	for i, s := range states {
+		if w.ok[s] {
+			candidates = append(candidates, i)
+		}
+	}
+	return candidates[g.rand.Intn(len(candidates))]
+}
+
+// altProb returns the probability that InstAlt takes Out.
+func (in *myinst) altProb() float64 {
+	if in.y > 0 {
+		return float64(in.x) / float64(in.y)
+	}
+	p, _ := new(big.Rat).SetFrac(in.bigX, in.bigY).Float64()
+	return p
+}
+
+// affixRune generates a rune by InstRune in the state q of the affix.
+func (g *Generator) affixRune(w *affixWalk, in *myinst, q int) rune {
+	ts := w.transitions(in, q)
+	states := make([]int, len(ts))
+	weights := make([]float64, len(ts))
+	for i, t := range ts {
+		states[i] = t.next
+		weights[i] = t.weight * w.prob[t.next]
+	}
+	if c := ts[w.choose(g, states, weights)].c; c >= 0 {
+		return c
+	}
+
+	// sample as usual, while the sampled rune is in the affix.
+	for attempt := 0; attempt < DefaultMaxAttempts; attempt++ {
+		c := in.generateRune(g.rand, &g.mu)
+		if in.matchRune(c) && !containsRune(w.a.runes, c) {
+			return c
+		}
+	}
+	return w.a.otherRune(in)
+}
+
+// otherRune returns a rune of the instruction that is not in the runes of the affix.
+func (a *affix) otherRune(in *myinst) rune {
+	runes := in.runeGenerator.runes
+	if len(runes) == 1 {
+		return runes[0]
+	}
+	for i := 0; i < len(runes); i += 2 {
+		for c := runes[i]; c <= runes[i+1]; c++ {
+			if !containsRune(a.runes, c) {
+				return c
+			}
+		}
+	}
+	return runes[0]
+}
@@ -0,0 +1,102 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestGenerateWithPrefix(t *testing.T) {
+	tests := []struct {
+		pattern string
+		prefix  string
+	}{
+		{`[a-z]{3}-\d{4}`, "ab"},
+		{`[a-z]{3}-\d{4}`, "abc-12"},
+		{`(user|admin|guest)_\d{3}`, "adm"},
+		{`(user|admin|guest)_\d{3}`, ""},
+		{`a*b`, "aaaa"},
+		{`[a-f]+`, "face"},
+		{`あ+い`, "ああ"},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(8)))
+		re := regexp.MustCompile(`^(?:` + tt.pattern + `)$`)
+		for i := 0; i < 100; i++ {
+			s, err := g.GenerateWithPrefix(tt.prefix)
+			if err != nil {
+				t.Fatalf("%s, %q: %v", tt.pattern, tt.prefix, err)
+			}
+			if !strings.HasPrefix(s, tt.prefix) || !re.MatchString(s) {
+				t.Fatalf("%s, %q: unexpected %q", tt.pattern, tt.prefix, s)
+			}
+		}
+	}
+}
+
+func TestGenerateWithSuffix(t *testing.T) {
+	tests := []struct {
+		pattern string
+		suffix  string
+	}{
+		{`[a-z]{3}-\d{4}`, "99"},
+		{`[a-z]{3}-\d{4}`, "z-0000"},
+		{`(user|admin|guest)_\d{3}`, "st_007"},
+		{`[ab]*`, "abab"},
+		{`[ab]*`, "aab"},
+		{`(ab)+`, "abab"},
+		{`.*\.go`, "_test.go"},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(8)))
+		re := regexp.MustCompile(`^(?:` + tt.pattern + `)$`)
+		for i := 0; i < 100; i++ {
+			s, err := g.GenerateWithSuffix(tt.suffix)
+			if err != nil {
+				t.Fatalf("%s, %q: %v", tt.pattern, tt.suffix, err)
+			}
+			if !strings.HasSuffix(s, tt.suffix) || !re.MatchString(s) {
+				t.Fatalf("%s, %q: unexpected %q", tt.pattern, tt.suffix, s)
+			}
+		}
+	}
+}
+
+func TestGenerateWithPrefixDistribution(t *testing.T) {
+	// the rest of the string is distributed as usual.
+	g := Must(New(`x[ab]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		s, err := g.GenerateWithPrefix("x")
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[s]++
+	}
+	if counts["xa"] < 400 || counts["xb"] < 400 {
+		t.Errorf("unexpected distribution %v", counts)
+	}
+}
+
+func TestGenerateWithAffixImpossible(t *testing.T) {
+	g := Must(New(`[a-z]{3}`, syntax.Perl, nil))
+	if _, err := g.GenerateWithPrefix("abcd"); !errors.Is(err, ErrNoAffix) {
+		t.Errorf("want ErrNoAffix, got %v", err)
+	}
+	if _, err := g.GenerateWithPrefix("0"); !errors.Is(err, ErrNoAffix) {
+		t.Errorf("want ErrNoAffix, got %v", err)
+	}
+	if _, err := g.GenerateWithSuffix("Z"); !errors.Is(err, ErrNoAffix) {
+		t.Errorf("want ErrNoAffix, got %v", err)
+	}
+}
+
+func TestGenerateWithAffixMaxLength(t *testing.T) {
+	g := Must(New(`a+`, syntax.Perl, nil, WithTargetLength(100), WithMaxLength(5, MaxLengthError)))
+	if _, err := g.GenerateWithPrefix("aaaaaaaa"); !errors.Is(err, ErrTooLong) {
+		t.Errorf("want ErrTooLong, got %v", err)
+	}
+}
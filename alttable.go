@@ -0,0 +1,104 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"sync"
+)
+
+// minAltTableBranches is the minimum number of the branches of an alternation chain that is replaced by altTable.
+// The shorter chains are walked as is, because they are as fast as the table.
+const minAltTableBranches = 8
+
+// altTable chooses one of the branches of a chain of InstAlt, e.g. a word list `alpha|bravo|charlie|...`,
+// in constant time by the alias method, instead of walking the chain.
+type altTable struct {
+	outs  []uint32 // the branches in the generation program
+	prob  []int64  // the probability of taking the column itself, in units of fixedPointOne
+	alias []int32  // the branch taken instead of the column
+}
+
+// altChain returns the branches of the chain of InstAlt beginning at pc, and their probabilities.
+// The chain is the tree of InstAlt directly connected to each other,
+// e.g. syntax.Compile compiles `a|b|c` into Alt(Alt(a, b), c).
+// skip skips the instructions that the generation program eliminates.
+func altChain(inst []myinst, pc uint32, skip func(uint32) uint32) ([]uint32, []float64) {
+	var outs []uint32
+	var probs []float64
+	visited := map[uint32]bool{}
+	var visit func(pc uint32, prob float64)
+	visit = func(pc uint32, prob float64) {
+		in := &inst[pc]
+		if in.Op != syntax.InstAlt || in.y == 0 || visited[pc] {
+			// a branch of the chain
+			outs = append(outs, pc)
+			probs = append(probs, prob)
+			return
+		}
+		visited[pc] = true
+		p := float64(in.x) / float64(in.y)
+		visit(skip(in.Out), prob*p)
+		visit(skip(in.Arg), prob*(1-p))
+	}
+	visit(pc, 1)
+	return outs, probs
+}
+
+// newAltTable builds the alias table of the branches with the probabilities by Vose's method.
+// outs are the branches in the generation program.
+func newAltTable(outs []uint32, probs []float64) *altTable {
+	n := len(outs)
+	t := &altTable{
+		outs:  outs,
+		prob:  make([]int64, n),
+		alias: make([]int32, n),
+	}
+	var sum float64
+	for _, p := range probs {
+		sum += p
+	}
+	scaled := make([]float64, n)
+	var small, large []int32
+	for i, p := range probs {
+		scaled[i] = p / sum * float64(n)
+		if scaled[i] < 1 {
+			small = append(small, int32(i))
+		} else {
+			large = append(large, int32(i))
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		t.prob[s] = int64(scaled[s] * fixedPointOne)
+		t.alias[s] = l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// the rest are one, except for the rounding errors.
+	for _, i := range large {
+		t.prob[i] = fixedPointOne
+		t.alias[i] = i
+	}
+	for _, i := range small {
+		t.prob[i] = fixedPointOne
+		t.alias[i] = i
+	}
+	return t
+}
+
+// choose returns the branch in the generation program.
+// mu guards r.
+func (t *altTable) choose(r *rand.Rand, mu sync.Locker) uint32 {
+	mu.Lock()
+	col := r.Int63n(int64(len(t.outs)))
+	a := r.Int63n(fixedPointOne)
+	mu.Unlock()
+	if a < t.prob[col] {
+		return t.outs[col]
+	}
+	return t.outs[t.alias[col]]
+}
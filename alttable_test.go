@@ -0,0 +1,81 @@
+package rerand
+
+import (
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+var natoAlphabet = []string{
+	"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliett", "kilo", "lima", "mike",
+	"november", "oscar", "papa", "quebec", "romeo", "sierra", "tango", "uniform", "victor", "whiskey", "xray", "yankee", "zulu",
+}
+
+func TestAltTable(t *testing.T) {
+	pattern := strings.Join(natoAlphabet, "|")
+	g := Must(New(pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+	var tables int
+	for _, in := range g.code {
+		if in.table != nil {
+			tables++
+		}
+	}
+	if tables == 0 {
+		t.Fatal("want the alias table, got none")
+	}
+
+	const n = 26000
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		counts[g.Generate()]++
+	}
+	for _, word := range natoAlphabet {
+		if c := counts[word]; c < 800 || c > 1200 {
+			t.Errorf("%s is generated %d times, want about 1000", word, c)
+		}
+	}
+	if len(counts) != len(natoAlphabet) {
+		t.Errorf("want %d words, got %d", len(natoAlphabet), len(counts))
+	}
+}
+
+func TestAltTableStreamV2(t *testing.T) {
+	pattern := strings.Join(natoAlphabet, "|")
+	g := Must(New(pattern, syntax.Perl, nil, WithStreamVersion(StreamV2)))
+	for _, in := range g.code {
+		if in.table != nil {
+			t.Fatal("want no alias table in StreamV2")
+		}
+	}
+}
+
+func TestNewAltTable(t *testing.T) {
+	probs := []float64{0.5, 0.25, 0.125, 0.0625, 0.0625, 0, 0, 0}
+	outs := make([]uint32, len(probs))
+	for i := range outs {
+		outs[i] = uint32(i)
+	}
+	table := newAltTable(outs, probs)
+
+	// the probability of each branch is the sum of its own column and the columns aliased to it.
+	got := make([]float64, len(probs))
+	for col, p := range table.prob {
+		q := float64(p) / fixedPointOne
+		got[col] += q / float64(len(probs))
+		got[table.alias[col]] += (1 - q) / float64(len(probs))
+	}
+	for i := range probs {
+		if math.Abs(got[i]-probs[i]) > 1e-12 {
+			t.Errorf("%d: want %f, got %f", i, probs[i], got[i])
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		if out := table.choose(r, nopLocker{}); probs[out] == 0 {
+			t.Fatalf("the branch %d of zero probability is chosen", out)
+		}
+	}
+}
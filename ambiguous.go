@@ -0,0 +1,76 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+	"slices"
+)
+
+// ambiguousRunes are the runes that are confusable with each other.
+var ambiguousRunes = []rune("01IOl|o")
+
+// WithoutAmbiguousRunes removes the visually confusable runes 0, O, o, 1, I, l and | from the character classes and `.`,
+// e.g. `[A-Za-z0-9]{10}` generates human-transcribable codes.
+// The literals in the pattern are kept as is.
+// New fails if a character class has only ambiguous runes.
+func WithoutAmbiguousRunes() Option {
+	return func(o *options) {
+		o.excludedRunes = append(o.excludedRunes, ambiguousRunes...)
+	}
+}
+
+// excludeRunes removes the runes from the character classes and `.` of the program.
+// ceiling is the maximum rune of `.`.
+func excludeRunes(prog *syntax.Prog, excluded []rune, ceiling rune) error {
+	if len(excluded) == 0 {
+		return nil
+	}
+	excluded = slices.Clone(excluded)
+	slices.Sort(excluded)
+	excluded = slices.Compact(excluded)
+	for i := range prog.Inst {
+		in := &prog.Inst[i]
+		switch in.Op {
+		case syntax.InstRuneAny:
+			in.Op = syntax.InstRune
+			in.Rune = []rune{0, ceiling}
+		case syntax.InstRuneAnyNotNL:
+			in.Op = syntax.InstRune
+			in.Rune = []rune{0, '\n' - 1, '\n' + 1, ceiling}
+		case syntax.InstRune:
+			if len(in.Rune) < 2 {
+				continue
+			}
+		default:
+			continue
+		}
+		runes := subtractRunes(in.Rune, excluded)
+		if len(runes) == 0 {
+			return errors.New("rerand: the character class has no rune to generate")
+		}
+		in.Rune = runes
+	}
+	return nil
+}
+
+// subtractRunes returns the ranges of runes without the excluded runes.
+// excluded must be sorted.
+func subtractRunes(runes []rune, excluded []rune) []rune {
+	var ret []rune
+	for i := 0; i+1 < len(runes); i += 2 {
+		lo, hi := runes[i], runes[i+1]
+		for _, r := range excluded {
+			if r < lo || r > hi {
+				continue
+			}
+			if lo < r {
+				ret = append(ret, lo, r-1)
+			}
+			lo = r + 1
+		}
+		if lo <= hi {
+			ret = append(ret, lo, hi)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,52 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithoutAmbiguousRunes(t *testing.T) {
+	patterns := []string{`[A-Za-z0-9]{10}`, `.{10}`, `\w{10}`, `[^a-z]{10}`}
+	for _, pattern := range patterns {
+		g := Must(New(pattern, syntax.Perl, nil, WithoutAmbiguousRunes()))
+		for i := 0; i < 1000; i++ {
+			s := g.Generate()
+			if strings.ContainsAny(s, string(ambiguousRunes)) {
+				t.Errorf("%s: %q has ambiguous runes", pattern, s)
+			}
+		}
+	}
+
+	// literals are kept
+	g := Must(New(`ID-0[0-9]`, syntax.Perl, nil, WithoutAmbiguousRunes()))
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !strings.HasPrefix(s, "ID-0") || strings.ContainsAny(s[4:], "01") {
+			t.Errorf("unexpected %q", s)
+		}
+	}
+}
+
+func TestWithoutAmbiguousRunesError(t *testing.T) {
+	if _, err := New(`[01]`, syntax.Perl, nil, WithoutAmbiguousRunes()); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestSubtractRunes(t *testing.T) {
+	tests := []struct {
+		runes, excluded, want []rune
+	}{
+		{[]rune{'0', '9'}, []rune("01"), []rune{'2', '9'}},
+		{[]rune{'0', '9'}, []rune("5"), []rune{'0', '4', '6', '9'}},
+		{[]rune{'0', '9'}, []rune("9"), []rune{'0', '8'}},
+		{[]rune{'0', '1', 'a', 'z'}, []rune("01"), []rune{'a', 'z'}},
+		{[]rune{'0', '1'}, []rune("01"), nil},
+	}
+	for _, tt := range tests {
+		if got := subtractRunes(tt.runes, tt.excluded); !slices.Equal(got, tt.want) {
+			t.Errorf("subtractRunes(%q, %q): want %q, got %q", tt.runes, tt.excluded, tt.want, got)
+		}
+	}
+}
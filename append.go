@@ -0,0 +1,61 @@
+package rerand
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// AppendRunes appends a random string to dst as runes, and returns the extended buffer.
+// It does not allocate if dst has enough capacity.
+// It panics if the generation fails, in the same way as Generate.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) AppendRunes(dst []rune) []rune {
+	if g.opts.hasPostprocess() {
+		return append(dst, []rune(g.generate(g.rand, &g.mu))...)
+	}
+	dst, err := g.appendRunes(context.Background(), dst, g.rand, &g.mu)
+	if err != nil {
+		panic(err)
+	}
+	return dst
+}
+
+// GenerateRunes generates a random string as runes, without converting it from string.
+// The returned slice is owned by the caller.
+// It panics if the generation fails, in the same way as Generate.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateRunes() []rune {
+	return g.AppendRunes(make([]rune, 0, g.opts.expectedLength))
+}
+
+// AppendString appends a random string to dst in UTF-8, and returns the extended buffer.
+// It does not allocate if dst has enough capacity and the string is short.
+// It panics if the generation fails, in the same way as Generate.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) AppendString(dst []byte) []byte {
+	dst, err := g.tryAppendString(dst)
+	if err != nil {
+		panic(err)
+	}
+	return dst
+}
+
+// tryAppendString is same as AppendString, but returns an error instead of panicking.
+func (g *Generator) tryAppendString(dst []byte) ([]byte, error) {
+	if g.opts.hasPostprocess() {
+		s, err := g.tryGenerate(g.rand, &g.mu)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, s...), nil
+	}
+	var buf [64]rune
+	runes, err := g.appendRunes(context.Background(), buf[:0], g.rand, &g.mu)
+	if err != nil {
+		return dst, err
+	}
+	for _, r := range runes {
+		dst = utf8.AppendRune(dst, r)
+	}
+	return dst, nil
+}
@@ -0,0 +1,95 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	g1 := Must(New(`[あ-お]{2,3}-\d{3}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	g2 := Must(New(`[あ-お]{2,3}-\d{3}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	g3 := Must(New(`[あ-お]{2,3}-\d{3}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	for i := 0; i < 100; i++ {
+		want := g1.Generate()
+		if got := string(g2.AppendRunes([]rune("prefix:"))); got != "prefix:"+want {
+			t.Errorf("want %q, got %q", "prefix:"+want, got)
+		}
+		if got := string(g3.AppendString([]byte("prefix:"))); got != "prefix:"+want {
+			t.Errorf("want %q, got %q", "prefix:"+want, got)
+		}
+	}
+}
+
+func TestGenerateRunes(t *testing.T) {
+	g1 := Must(New(`[あ-お]{2,3}-\d{3}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	g2 := Must(New(`[あ-お]{2,3}-\d{3}`, syntax.Perl, rand.New(rand.NewSource(1)), WithExpectedLength(8)))
+	for i := 0; i < 100; i++ {
+		want := g1.Generate()
+		runes := g2.GenerateRunes()
+		if got := string(runes); got != want {
+			t.Errorf("want %q, got %q", want, got)
+		}
+		if runes[0] < 'あ' || runes[0] > 'お' {
+			t.Errorf("unexpected rune %q", runes[0])
+		}
+	}
+
+	// the slices are not shared.
+	a, b := g1.GenerateRunes(), g1.GenerateRunes()
+	a[0] = 'x'
+	if b[0] == 'x' {
+		t.Error("the runes are shared")
+	}
+}
+
+func TestAppendWithTransform(t *testing.T) {
+	g := Must(New(`[a-z]{3}`, syntax.Perl, nil, WithTransform(strings.ToUpper)))
+	if s := string(g.AppendString(nil)); strings.ToUpper(s) != s {
+		t.Errorf("want upper case, got %q", s)
+	}
+	if s := string(g.AppendRunes(nil)); strings.ToUpper(s) != s {
+		t.Errorf("want upper case, got %q", s)
+	}
+}
+
+func TestAppendAllocs(t *testing.T) {
+	g := Must(New(`\d{2,3}-\d{3,4}-\d{3,4}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	runes := make([]rune, 0, 64)
+	if n := testing.AllocsPerRun(100, func() {
+		runes = g.AppendRunes(runes[:0])
+	}); n != 0 {
+		t.Errorf("AppendRunes: want no allocation, got %f", n)
+	}
+	bytes := make([]byte, 0, 64)
+	if n := testing.AllocsPerRun(100, func() {
+		bytes = g.AppendString(bytes[:0])
+	}); n != 0 {
+		t.Errorf("AppendString: want no allocation, got %f", n)
+	}
+}
+
+func BenchmarkAppend(b *testing.B) {
+	g := Must(New(`\d{2,3}-\d{3,4}-\d{3,4}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	b.Run("Generate", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			g.Generate()
+		}
+	})
+	b.Run("AppendRunes", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]rune, 0, 64)
+		for i := 0; i < b.N; i++ {
+			buf = g.AppendRunes(buf[:0])
+		}
+	})
+	b.Run("AppendString", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 64)
+		for i := 0; i < b.N; i++ {
+			buf = g.AppendString(buf[:0])
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package rerand
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+)
+
+// Assertion is an additional regular expression that the strings of NewWithAssertions must or must not match.
+// It emulates lookarounds, e.g. `(?=.*\d)` is Assertion{Pattern: `\d`}.
+type Assertion struct {
+	// Pattern is the regular expression in the syntax of regexp.Compile.
+	// It matches anywhere in the string unless it is anchored by `^` and `$`.
+	Pattern string
+
+	// Negative makes the strings that match Pattern rejected, e.g. `(?!.*admin)`.
+	Negative bool
+}
+
+// NewWithAssertions returns new Generator that generates the strings matching the pattern and the assertions.
+// The strings that do not satisfy the assertions are rejected by WithFilter,
+// so the generation fails with *FilterError if no string satisfies them after the attempts of WithFilter.
+func NewWithAssertions(pattern string, flags syntax.Flags, r *rand.Rand, assertions []Assertion, opts ...Option) (*Generator, error) {
+	filters := make([]Option, 0, len(assertions))
+	for _, a := range assertions {
+		re, err := regexp.Compile(a.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rerand: invalid assertion %q: %w", a.Pattern, err)
+		}
+		negative := a.Negative
+		filters = append(filters, WithFilter(func(s string) bool {
+			return re.MatchString(s) != negative
+		}, 0))
+	}
+	return newGenerator(pattern, flags, r, append(filters, opts...))
+}
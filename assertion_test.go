@@ -0,0 +1,37 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestNewWithAssertions(t *testing.T) {
+	g := Must(NewWithAssertions(`[a-z0-9]{8}`, syntax.Perl, nil, []Assertion{
+		{Pattern: `\d`},
+		{Pattern: `[a-z]`},
+		{Pattern: `^0`, Negative: true},
+	}))
+	digit := regexp.MustCompile(`\d`)
+	letter := regexp.MustCompile(`[a-z]`)
+	for i := 0; i < 1000; i++ {
+		s := g.Generate()
+		if !digit.MatchString(s) || !letter.MatchString(s) || s[0] == '0' {
+			t.Fatalf("%q does not satisfy the assertions", s)
+		}
+	}
+}
+
+func TestNewWithAssertionsImpossible(t *testing.T) {
+	g := Must(NewWithAssertions(`[a-z]{8}`, syntax.Perl, nil, []Assertion{{Pattern: `\d`}}))
+	if _, err := g.TryGenerate(); !errors.Is(err, ErrFilterExhausted) {
+		t.Errorf("want ErrFilterExhausted, got %v", err)
+	}
+}
+
+func TestNewWithAssertionsInvalid(t *testing.T) {
+	if _, err := NewWithAssertions(`a`, syntax.Perl, nil, []Assertion{{Pattern: `(`}}); err == nil {
+		t.Error("want error, got nil")
+	}
+}
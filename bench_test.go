@@ -0,0 +1,155 @@
+package rerand
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// benchCases are the patterns of the benchmark suite.
+// They stress different parts of the generator, so that a regression in the instruction loop,
+// the rune generators or the pooling shows up in at least one of them.
+// Profile a case with e.g. `go test -run '^$' -bench 'Suite/deep-alternation' -cpuprofile cpu.out`.
+var benchCases = []struct {
+	name    string
+	pattern string
+}{
+	{"literal", `https://www\.example\.com/index\.html`},
+	{"small-class", `[a-z]{16}`},
+	{"large-class", `\pL{16}`},
+	{"any", `.{16}`},
+	{"wide-alternation", strings.Join(benchWords(256), "|")},
+	{"deep-alternation", benchDeepAlternation(32)},
+	{"word-list", strings.Join(natoAlphabet, "|")},
+	{"long-concatenation", strings.Repeat(`[a-z][0-9]x`, 300)},
+	{"long-repeat", `[a-z0-9]{1000}`},
+	{"optional", `(?:a?b?c?d?e?f?g?h?){8}`},
+}
+
+// benchWords returns n distinct words, which the parser does not factor into a character class.
+func benchWords(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%03dx", i)
+	}
+	return words
+}
+
+// benchDeepAlternation returns the pattern of the alternations nested depth times, e.g. `a0(?:|a1(?:|a2))`.
+func benchDeepAlternation(depth int) string {
+	var buf strings.Builder
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&buf, "a%d(?:b|", i)
+	}
+	buf.WriteString("c")
+	buf.WriteString(strings.Repeat(")", depth))
+	return buf.String()
+}
+
+func BenchmarkSuite(b *testing.B) {
+	for _, c := range benchCases {
+		g := Must(New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+		size := int64(len(g.Generate()))
+		b.Run(c.name+"/Generate", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(size)
+			for i := 0; i < b.N; i++ {
+				g.Generate()
+			}
+		})
+		b.Run(c.name+"/AppendString", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(size)
+			buf := make([]byte, 0, 8*size)
+			for i := 0; i < b.N; i++ {
+				buf = g.AppendString(buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrent(b *testing.B) {
+	for _, c := range benchCases {
+		for _, n := range []int{1, 8, 64} {
+			// all the goroutines share the generator, so the lock contention is measured.
+			g := Must(New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+			b.Run(fmt.Sprintf("%s/shared-%d", c.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				benchConcurrent(b, n, func(int) func() {
+					return func() { g.Generate() }
+				})
+			})
+
+			// each goroutine has its own clone.
+			b.Run(fmt.Sprintf("%s/clone-%d", c.name, n), func(b *testing.B) {
+				b.ReportAllocs()
+				benchConcurrent(b, n, func(i int) func() {
+					g := g.Clone(rand.New(rand.NewSource(int64(i))))
+					return func() { g.Generate() }
+				})
+			})
+		}
+	}
+}
+
+// benchConcurrent runs b.N calls of the functions that newFunc returns on n goroutines.
+func benchConcurrent(b *testing.B, n int, newFunc func(i int) func()) {
+	funcs := make([]func(), n)
+	for i := range funcs {
+		funcs[i] = newFunc(i)
+	}
+	b.ResetTimer()
+	var wg sync.WaitGroup
+	for i, f := range funcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := i; j < b.N; j += n {
+				f()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestBenchAllocs asserts the allocations of the benchmark suite,
+// so that a regression of the pooling fails the tests instead of only slowing the benchmarks.
+func TestBenchAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector makes extra allocations")
+	}
+	for _, c := range benchCases {
+		g := Must(New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+		buf := make([]byte, 0, 8*len(g.Generate()))
+		// AppendString generates the strings up to 64 runes on the stack.
+		if maxLen, _ := g.MaxLen(); maxLen <= 64 {
+			if n := testing.AllocsPerRun(100, func() {
+				buf = g.AppendString(buf[:0])
+			}); n != 0 {
+				t.Errorf("%s: AppendString: want no allocation, got %f", c.name, n)
+			}
+		}
+
+		// the string itself is the only allocation.
+		if n := testing.AllocsPerRun(100, func() {
+			g.Generate()
+		}); n > 1 {
+			t.Errorf("%s: Generate: want at most 1 allocation, got %f", c.name, n)
+		}
+	}
+}
+
+// TestBenchCases checks that the patterns of the benchmark suite generate matching strings.
+func TestBenchCases(t *testing.T) {
+	for _, c := range benchCases {
+		g := Must(New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1)), WithVerify()))
+		for i := 0; i < 10; i++ {
+			if _, err := g.TryGenerate(); err != nil {
+				t.Errorf("%s: %v", c.name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package rerand
+
+import (
+	"errors"
+	"math"
+	"regexp/syntax"
+)
+
+// ErrBoltzmannDiverged is returned if the Boltzmann sampler cannot find the parameter for the target length.
+var ErrBoltzmannDiverged = errors.New("rerand: boltzmann sampler diverged")
+
+// boltzmann sets the probabilities of alternations for the target expected length.
+//
+// Let F(pc) be the generating function of strings from pc weighted by z^length.
+// The Boltzmann sampler with the parameter z takes each branch with the probability proportional to F(branch).
+// The expected length is z F'(start) / F(start), which increases with z,
+// so z is searched by bisection.
+func boltzmann(inst []myinst, start int, distinctRunes bool, target float64) error {
+	weights := make([]float64, len(inst))
+	for i := range inst {
+		in := &inst[i]
+		if in.Op == syntax.InstRune || in.Op == syntax.InstRune1 {
+			weights[i] = 1
+			if distinctRunes {
+				weights[i] = float64(in.runeCount())
+			}
+		}
+	}
+
+	f := make([]float64, len(inst))
+	df := make([]float64, len(inst))
+	expected := func(z float64) (float64, bool) {
+		if !boltzmannSolve(inst, weights, z, f, df) {
+			return 0, false
+		}
+		if f[start] == 0 {
+			// underflow, z is too small
+			return 0, true
+		}
+		return z * df[start] / f[start], true
+	}
+
+	// bisection on log z
+	lo, hi := -700.0, 700.0
+	for i := 0; i < 100 && hi-lo > 1e-9; i++ {
+		mid := (lo + hi) / 2
+		if e, ok := expected(math.Exp(mid)); ok && e < target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	if !boltzmannSolve(inst, weights, math.Exp(lo), f, df) || f[start] == 0 {
+		if !boltzmannSolve(inst, weights, math.Exp(hi), f, df) || f[start] == 0 {
+			return ErrBoltzmannDiverged
+		}
+	}
+
+	for i := range inst {
+		in := &inst[i]
+		if in.Op != syntax.InstAlt {
+			continue
+		}
+		sum := f[in.Out] + f[in.Arg]
+		in.y = math.MaxInt64
+		switch {
+		case sum == 0 || f[in.Arg] == 0:
+			in.x = math.MaxInt64
+		case f[in.Out] == 0:
+			in.x = 0
+		default:
+			in.x = probability(f[in.Out] / sum)
+		}
+	}
+	return nil
+}
+
+// boltzmannSolve computes the generating functions f and their derivatives df at z by fixed-point iteration.
+// It returns false if they diverge.
+func boltzmannSolve(inst []myinst, weights []float64, z float64, f, df []float64) bool {
+	const maxIteration = 100000
+	const epsilon = 1e-12
+	const limit = 1e300
+	for i := range f {
+		f[i] = 0
+		df[i] = 0
+	}
+	for iter := 0; iter < maxIteration; iter++ {
+		converged := true
+		for i := len(inst) - 1; i >= 0; i-- {
+			in := &inst[i]
+			var v, dv float64
+			switch in.Op {
+			case syntax.InstMatch:
+				v = 1
+			case syntax.InstRune, syntax.InstRune1:
+				v = weights[i] * z * f[in.Out]
+				dv = weights[i] * (f[in.Out] + z*df[in.Out])
+			case syntax.InstAlt:
+				v = f[in.Out] + f[in.Arg]
+				dv = df[in.Out] + df[in.Arg]
+			case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+				v = f[in.Out]
+				dv = df[in.Out]
+			}
+			if v > limit || dv > limit || math.IsNaN(v) || math.IsNaN(dv) {
+				return false
+			}
+			if math.Abs(v-f[i]) > epsilon*v || math.Abs(dv-df[i]) > epsilon*dv {
+				converged = false
+			}
+			f[i] = v
+			df[i] = dv
+		}
+		if converged {
+			return true
+		}
+	}
+	return false
+}
+
+// probability converts p in [0, 1] into the threshold of rand.Int63n(math.MaxInt64).
+func probability(p float64) int64 {
+	v := p * math.MaxInt64
+	if v >= math.MaxInt64 {
+		// float64(math.MaxInt64) is 2^63, which overflows int64
+		return math.MaxInt64
+	}
+	if v <= 0 {
+		return 0
+	}
+	return int64(v)
+}
@@ -0,0 +1,73 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWithTargetLength(t *testing.T) {
+	cases := []struct {
+		pattern string
+		target  float64
+	}{
+		{`a*`, 10},
+		{`[a-z]+`, 200},
+		{`(ab|c)*d`, 50},
+		{`\w+@\w+\.com`, 30},
+		{`[a-z]{1,100}`, 20},
+	}
+	for _, c := range cases {
+		re := regexp.MustCompile(`^(?:` + c.pattern + `)$`)
+		g, err := New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(c.target))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.pattern, err)
+			continue
+		}
+		const N = 10000
+		sum := 0
+		for i := 0; i < N; i++ {
+			s := g.Generate()
+			if !re.MatchString(s) {
+				t.Errorf("%s: %q does not match", c.pattern, s)
+			}
+			sum += utf8.RuneCountInString(s)
+		}
+		mean := float64(sum) / N
+		if mean < c.target*0.9 || mean > c.target*1.1 {
+			t.Errorf("%s: want mean length about %f, got %f", c.pattern, c.target, mean)
+		}
+	}
+}
+
+func TestWithTargetLengthUnreachable(t *testing.T) {
+	g := Must(New(`[a-z]{1,5}`, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(100)))
+	for i := 0; i < 1000; i++ {
+		if s := g.Generate(); len(s) < 4 {
+			t.Errorf("want long strings, got %q", s)
+		}
+	}
+}
+
+func TestWithTargetLengthTooShort(t *testing.T) {
+	g := Must(New(`[a-z]{10,20}`, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(2)))
+	for i := 0; i < 1000; i++ {
+		if s := g.Generate(); len(s) > 11 {
+			t.Errorf("want short strings, got %q", s)
+		}
+	}
+}
+
+func TestWithTargetLengthDistinctRunes(t *testing.T) {
+	g := Must(NewDistinctRunes(`.+`, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(50)))
+	const N = 10000
+	sum := 0
+	for i := 0; i < N; i++ {
+		sum += utf8.RuneCountInString(g.Generate())
+	}
+	if mean := float64(sum) / N; mean < 45 || mean > 55 {
+		t.Errorf("want mean length about 50, got %f", mean)
+	}
+}
@@ -0,0 +1,108 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+)
+
+// The approximate sizes of the data structures of compilation in bytes.
+const (
+	progInstBytes = 40  // an instruction of syntax.Prog
+	instBytes     = 200 // an instruction of Generator, its bytecode and the caches of counting
+	bigIntBytes   = 32  // the header of big.Int, the words are counted separately
+)
+
+// ErrCompileBudget is the error wrapped by *BudgetError.
+var ErrCompileBudget = errors.New("rerand: compile budget exceeded")
+
+// BudgetError is returned by New if the compilation needs more memory than the budget of WithCompileBudget.
+type BudgetError struct {
+	// Budget is the budget in bytes.
+	Budget int64
+
+	// Used is the approximate memory in bytes at the time the budget is exceeded.
+	Used int64
+
+	// Stage is the stage of compilation, "program", "instructions" or "counting".
+	Stage string
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("rerand: compilation needs about %d bytes in %s, exceeding the budget of %d bytes", e.Used, e.Stage, e.Budget)
+}
+
+// Unwrap returns ErrCompileBudget.
+func (e *BudgetError) Unwrap() error {
+	return ErrCompileBudget
+}
+
+// WithCompileBudget aborts New with *BudgetError if the compilation needs more than about n bytes,
+// e.g. for the services that compile patterns given by users.
+// The memory is estimated before it is allocated as far as possible,
+// e.g. the program of the pattern is estimated from the parsed pattern,
+// but the estimation is approximate, and the memory of the parsed pattern itself is not counted.
+// Zero means no budget.
+func WithCompileBudget(n int64) Option {
+	return func(o *options) {
+		o.compileBudget = n
+	}
+}
+
+// compileBudget tracks the memory of compilation. nil means no budget.
+type compileBudget struct {
+	limit int64
+	used  int64
+}
+
+func newCompileBudget(limit int64) *compileBudget {
+	if limit == 0 {
+		return nil
+	}
+	return &compileBudget{limit: limit}
+}
+
+// charge adds n bytes, and returns *BudgetError if the budget is exceeded.
+func (b *compileBudget) charge(stage string, n int64) error {
+	if b == nil {
+		return nil
+	}
+	b.used += n
+	if b.used > b.limit {
+		return &BudgetError{Budget: b.limit, Used: b.used, Stage: stage}
+	}
+	return nil
+}
+
+// programBytes estimates the memory of the program compiled from re.
+func programBytes(re *syntax.Regexp) int64 {
+	const maxBytes = 1 << 62 // avoids overflows of the repetitions
+	var subs int64
+	for _, sub := range re.Sub {
+		subs = min(subs+programBytes(sub), maxBytes)
+	}
+	switch re.Op {
+	case syntax.OpLiteral:
+		return int64(len(re.Rune)) * progInstBytes
+	case syntax.OpCharClass:
+		return progInstBytes + int64(len(re.Rune))*4
+	case syntax.OpAlternate:
+		return subs + int64(len(re.Sub)-1)*progInstBytes
+	case syntax.OpCapture:
+		return subs + 2*progInstBytes
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		return subs + progInstBytes
+	case syntax.OpRepeat:
+		n := int64(re.Max)
+		if re.Max < 0 {
+			n = int64(re.Min) + 1
+		}
+		if subs > 0 && n > maxBytes/subs {
+			return maxBytes
+		}
+		return subs*n + n*progInstBytes
+	case syntax.OpConcat:
+		return subs
+	}
+	return subs + progInstBytes
+}
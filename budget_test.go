@@ -0,0 +1,77 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithCompileBudget(t *testing.T) {
+	words := make([]string, 10000)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%05d", i)
+	}
+	pattern := strings.Join(words, "|")
+
+	// the parser factors the common prefixes out, and the program has about 3,000 instructions.
+	tests := []struct {
+		budget int64
+		stage  string
+	}{
+		{64 << 10, "program"},
+		{256 << 10, "instructions"},
+	}
+	for _, tt := range tests {
+		_, err := New(pattern, syntax.Perl, nil, WithCompileBudget(tt.budget))
+		var be *BudgetError
+		if !errors.As(err, &be) || !errors.Is(err, ErrCompileBudget) {
+			t.Fatalf("want *BudgetError, got %v", err)
+		}
+		if be.Stage != tt.stage || be.Budget != tt.budget || be.Used <= be.Budget {
+			t.Errorf("unexpected error: %v", be)
+		}
+	}
+
+	if _, err := New(pattern, syntax.Perl, nil, WithCompileBudget(1<<30)); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+}
+
+func TestWithCompileBudgetCounting(t *testing.T) {
+	// few instructions, but the counts of strings are huge.
+	pattern := `[\x{0}-\x{10FFFF}]{0,1000}`
+	_, err := NewDistinctRunes(pattern, syntax.Perl, nil, WithCompileBudget(1<<20))
+	var be *BudgetError
+	if !errors.As(err, &be) || be.Stage != "counting" {
+		t.Fatalf("want *BudgetError in counting, got %v", err)
+	}
+	if _, err := New(pattern, syntax.Perl, nil, WithCompileBudget(1<<20)); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+}
+
+func TestProgramBytes(t *testing.T) {
+	for _, pattern := range []string{`a`, `abc|def`, `(a+)*b?`, `[a-z]{3,10}`, `(?:ab){2,}`, `\w+@\w+\.com`} {
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prog, err := syntax.Compile(re.Simplify())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the estimation is within a small factor of the actual size.
+		actual := int64(len(prog.Inst)) * progInstBytes
+		if got := programBytes(re); got < actual/4 || got > actual*4 {
+			t.Errorf("%s: want about %d, got %d", pattern, actual, got)
+		}
+	}
+}
+
+func TestWithCompileBudgetInvalid(t *testing.T) {
+	if _, err := New(`a`, syntax.Perl, nil, WithCompileBudget(-1)); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,141 @@
+package rerand
+
+import (
+	"math/big"
+	"math/rand"
+	"regexp/syntax"
+	"sync"
+)
+
+// genInst is an instruction of the generation program.
+// It is a flattened form of myinst for Generate.
+type genInst struct {
+	Op syntax.InstOp // InstRune, InstRune1, InstAlt, InstCapture, InstMatch or unsupported operations
+
+	// the next instructions. Arg is used by InstAlt, and is the capture slot in InstCapture.
+	Out, Arg uint32
+
+	// InstRune
+	runeGenerator *RuneGenerator
+	runeSource    RuneSource
+	grapheme      bool
+
+	// InstRune1
+	literal []rune
+
+	// InstAlt
+	x, y       int64
+	bigX, bigY *big.Int
+	table      *altTable // the chain of InstAlt beginning at the instruction, instead of x and y
+
+	// InstCapture that begins the group of WithDependency.
+	// Out is the end of the group.
+	dependency *dependency
+}
+
+// compileGenProg converts the instructions into the generation program.
+// InstCapture and InstNop are eliminated, the runs of InstRune1 are merged,
+// and only the reachable instructions are emitted.
+// If deps is not nil, InstCapture of the named groups are kept for WithDependency.
+// If tables is true, the long chains of InstAlt are replaced by altTable.
+// The first instruction of the generation program is the start.
+func compileGenProg(inst []myinst, start int, deps *dependencies, tables bool) []genInst {
+	index := make(map[uint32]uint32)
+	var prog []genInst
+	var queue []uint32
+
+	// skip returns the first instruction that is not InstCapture nor InstNop.
+	skip := func(pc uint32) uint32 {
+		for n := 0; n < len(inst); n++ {
+			switch inst[pc].Op {
+			case syntax.InstCapture:
+				if deps.isNamed(inst[pc].Arg) {
+					return pc
+				}
+				pc = inst[pc].Out
+			case syntax.InstNop:
+				pc = inst[pc].Out
+			default:
+				return pc
+			}
+		}
+		return pc // the infinite loop of InstNop
+	}
+	// emit returns the index of the instruction in the generation program.
+	emit := func(pc uint32) uint32 {
+		pc = skip(pc)
+		if i, ok := index[pc]; ok {
+			return i
+		}
+		i := uint32(len(prog))
+		index[pc] = i
+		prog = append(prog, genInst{})
+		queue = append(queue, pc)
+		return i
+	}
+
+	emit(uint32(start))
+	for len(queue) > 0 {
+		pc := queue[0]
+		queue = queue[1:]
+		in := &inst[pc]
+		gi := genInst{Op: in.Op}
+		switch in.Op {
+		case syntax.InstRune:
+			gi.runeGenerator = in.runeGenerator
+			gi.runeSource = in.runeSource
+			gi.grapheme = in.grapheme && in.runeSource == nil
+			gi.Out = emit(in.Out)
+		case syntax.InstRune1:
+			gi.literal = in.literal
+			gi.Out = emit(in.literalOut)
+		case syntax.InstAlt:
+			gi.x, gi.y = in.x, in.y
+			gi.bigX, gi.bigY = in.bigX, in.bigY
+			if tables {
+				if outs, probs := altChain(inst, pc, skip); len(outs) >= minAltTableBranches {
+					for i, out := range outs {
+						outs[i] = emit(out)
+					}
+					gi.table = newAltTable(outs, probs)
+					break
+				}
+			}
+			gi.Out = emit(in.Out)
+			gi.Arg = emit(in.Arg)
+		case syntax.InstCapture:
+			gi.Arg = in.Arg
+			if d := deps.dependency(in.Arg); d != nil {
+				// skip the group, which is generated by the dependency.
+				gi.dependency = d
+				gi.Out = emit(groupEnd(inst, pc))
+			} else {
+				gi.Out = emit(in.Out)
+			}
+		}
+		prog[index[pc]] = gi
+	}
+	return prog
+}
+
+// generateRune generates a random rune by the instruction.
+// mu guards r.
+func (in *genInst) generateRune(r *rand.Rand, mu sync.Locker) rune {
+	if in.runeSource != nil {
+		mu.Lock()
+		defer mu.Unlock()
+		return in.runeSource.Rune(r)
+	}
+	return in.runeGenerator.generate(r, mu)
+}
+
+// altBig reports whether InstAlt takes Out, if the probability is too precise for int64.
+// It is only for StreamV1, and kept out of the loop of Generate.
+// mu guards r.
+func (in *genInst) altBig(r *rand.Rand, mu sync.Locker) bool {
+	var a big.Int
+	mu.Lock()
+	a.Rand(r, in.bigY)
+	mu.Unlock()
+	return a.Cmp(in.bigX) < 0
+}
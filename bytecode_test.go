@@ -0,0 +1,89 @@
+package rerand
+
+import (
+	"math/big"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestCompileGenProg(t *testing.T) {
+	g := Must(New(`(?P<user>[a-z]{3,5})@((example|test)\.)?(com|org)`, syntax.Perl, nil))
+	if len(g.code) == 0 {
+		t.Fatal("empty program")
+	}
+	for i, in := range g.code {
+		switch in.Op {
+		case syntax.InstRune, syntax.InstRune1, syntax.InstAlt, syntax.InstMatch:
+		default:
+			t.Errorf("%d: unexpected operation %v", i, in.Op)
+		}
+		if int(in.Out) >= len(g.code) || int(in.Arg) >= len(g.code) {
+			t.Errorf("%d: out of range", i)
+		}
+	}
+	if len(g.code) >= len(g.inst) {
+		t.Errorf("want shorter than %d, got %d", len(g.inst), len(g.code))
+	}
+}
+
+func BenchmarkGenerateComplex(b *testing.B) {
+	g := Must(New(`(?:(?:[a-z0-9]{1,8}\.){1,3}(?:com|org|net|jp)|(?:\d{1,3}\.){3}\d{1,3})(?::\d{2,5})?(?:/[a-zA-Z0-9_-]{1,8}){0,4}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func TestFixedPoint(t *testing.T) {
+	y := new(big.Int).Lsh(big.NewInt(1), 100)
+	tests := []struct {
+		x    *big.Int
+		want int64
+	}{
+		{big.NewInt(0), 0},
+		{big.NewInt(1), 1},
+		{new(big.Int).Rsh(y, 1), fixedPointOne / 2},
+		{new(big.Int).Sub(y, big.NewInt(1)), fixedPointOne - 1},
+		{y, fixedPointOne},
+	}
+	for _, tt := range tests {
+		x, d := fixedPoint(tt.x, y)
+		if x != tt.want || d != fixedPointOne {
+			t.Errorf("fixedPoint(%v, %v): want %d/%d, got %d/%d", tt.x, y, tt.want, int64(fixedPointOne), x, d)
+		}
+	}
+}
+
+func TestGenerateWithoutBigInt(t *testing.T) {
+	pattern := `[a-z]{0,20}`
+	g := Must(NewDistinctRunes(pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+	for i, in := range g.code {
+		if in.bigY != nil {
+			t.Errorf("%d: want no big.Int", i)
+		}
+	}
+	re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+	runes := make([]rune, 0, 64)
+	if n := testing.AllocsPerRun(100, func() {
+		runes = g.AppendRunes(runes[:0])
+	}); n != 0 {
+		t.Errorf("want no allocation, got %f", n)
+	}
+
+	// StreamV1 keeps the exact probabilities.
+	g = Must(NewDistinctRunes(pattern, syntax.Perl, nil, WithStreamVersion(StreamV1)))
+	var found bool
+	for _, in := range g.code {
+		found = found || in.bigY != nil
+	}
+	if !found {
+		t.Error("want big.Int in StreamV1")
+	}
+}
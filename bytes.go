@@ -0,0 +1,129 @@
+package rerand
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+)
+
+// ErrNotByte is returned by NewBytes if the pattern has runes beyond \xFF.
+var ErrNotByte = errors.New("rerand: the pattern has runes beyond \\xFF")
+
+// BytesGenerator is random byte sequence generator.
+// It generates each rune of the pattern as a byte, without UTF-8 encoding.
+type BytesGenerator struct {
+	g *Generator
+}
+
+// NewBytes returns new BytesGenerator, which generates byte sequences matching the pattern
+// in the Latin-1 style, e.g. `[\x00-\xff]{16}` generates 16 bytes.
+// `.` and the negated character classes generate bytes from \x00 to \xFF.
+// It returns ErrNotByte if the pattern has runes beyond \xFF.
+// The options WithFilter and WithTransform see the strings whose runes are the bytes,
+// and the transforms must not produce runes beyond \xFF.
+func NewBytes(pattern string, flags syntax.Flags, r *rand.Rand, opts ...Option) (*BytesGenerator, error) {
+	opts = append(opts[:len(opts):len(opts)], func(o *options) {
+		o.bytes = true
+	})
+	g, err := newGenerator(pattern, flags, r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &BytesGenerator{g: g}, nil
+}
+
+func (g *BytesGenerator) String() string {
+	return g.g.pattern
+}
+
+// Generate generates a random byte sequence.
+// It panics if the generation fails, e.g. no string passes the filter of WithFilter.
+// It is safe for concurrent use by multiple goroutines.
+func (g *BytesGenerator) Generate() []byte {
+	b, err := g.TryGenerate()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryGenerate generates a random byte sequence.
+// It returns an error if the generation fails, e.g. no string passes the filter of WithFilter.
+// It is safe for concurrent use by multiple goroutines.
+func (g *BytesGenerator) TryGenerate() ([]byte, error) {
+	return g.tryAppend(nil)
+}
+
+// AppendBytes appends a random byte sequence to dst, and returns the extended buffer.
+// It does not allocate if dst has enough capacity and the sequence is short.
+// It panics if the generation fails, in the same way as Generate.
+// It is safe for concurrent use by multiple goroutines.
+func (g *BytesGenerator) AppendBytes(dst []byte) []byte {
+	dst, err := g.tryAppend(dst)
+	if err != nil {
+		panic(err)
+	}
+	return dst
+}
+
+func (g *BytesGenerator) tryAppend(dst []byte) ([]byte, error) {
+	if g.g.opts.hasPostprocess() {
+		s, err := g.g.TryGenerate()
+		if err != nil {
+			return dst, err
+		}
+		for _, c := range s {
+			dst = append(dst, byte(c))
+		}
+		return dst, nil
+	}
+	var buf [64]rune
+	runes, err := g.g.appendRunes(context.Background(), buf[:0], g.g.rand, &g.g.mu)
+	if err != nil {
+		return dst, err
+	}
+	for _, c := range runes {
+		dst = append(dst, byte(c))
+	}
+	return dst, nil
+}
+
+// limitBytes limits the runes of the program to bytes.
+func limitBytes(prog *syntax.Prog) error {
+	for i := range prog.Inst {
+		in := &prog.Inst[i]
+		switch in.Op {
+		case syntax.InstRuneAny:
+			in.Op = syntax.InstRune
+			in.Rune = []rune{0, 0xFF}
+		case syntax.InstRuneAnyNotNL:
+			in.Op = syntax.InstRune
+			in.Rune = []rune{0, '\n' - 1, '\n' + 1, 0xFF}
+		case syntax.InstRune1:
+			if in.Rune[0] > 0xFF {
+				return ErrNotByte
+			}
+		case syntax.InstRune:
+			if len(in.Rune) == 1 {
+				if in.Rune[0] > 0xFF {
+					return ErrNotByte
+				}
+				continue
+			}
+			var runes []rune
+			for j := 0; j < len(in.Rune); j += 2 {
+				lo, hi := in.Rune[j], in.Rune[j+1]
+				if lo > 0xFF {
+					break
+				}
+				runes = append(runes, lo, min(hi, 0xFF))
+			}
+			if len(runes) == 0 {
+				return ErrNotByte
+			}
+			in.Rune = runes
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package rerand
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestNewBytes(t *testing.T) {
+	g := mustBytes(NewBytes(`\x7f\x80[\x00-\xff]{16}\xff`, syntax.Perl, rand.New(rand.NewSource(1))))
+	var high bool
+	for i := 0; i < 100; i++ {
+		b := g.Generate()
+		if len(b) != 19 {
+			t.Fatalf("want 19 bytes, got %d: %x", len(b), b)
+		}
+		if b[0] != 0x7f || b[1] != 0x80 || b[18] != 0xff {
+			t.Errorf("unexpected bytes %x", b)
+		}
+		high = high || bytes.ContainsFunc(b[2:18], func(r rune) bool { return r >= 0x80 })
+	}
+	if !high {
+		t.Error("want high bytes")
+	}
+}
+
+func TestNewBytesAny(t *testing.T) {
+	patterns := []string{`.{32}`, `[^a]{32}`, `\PL{32}`, `(?s:.{32})`}
+	for _, pattern := range patterns {
+		g := mustBytes(NewBytes(pattern, syntax.Perl, nil))
+		for i := 0; i < 100; i++ {
+			if b := g.AppendBytes(nil); len(b) != 32 {
+				t.Errorf("%s: want 32 bytes, got %x", pattern, b)
+			}
+		}
+	}
+}
+
+func TestNewBytesAllocs(t *testing.T) {
+	g := mustBytes(NewBytes(`[\x00-\xff]{16}`, syntax.Perl, nil))
+	buf := make([]byte, 0, 64)
+	if n := testing.AllocsPerRun(100, func() {
+		buf = g.AppendBytes(buf[:0])
+	}); n != 0 {
+		t.Errorf("want no allocation, got %f", n)
+	}
+}
+
+func TestNewBytesError(t *testing.T) {
+	for _, pattern := range []string{`あ`, `[あ-お]`, `\p{Hiragana}`} {
+		if _, err := NewBytes(pattern, syntax.Perl, nil); !errors.Is(err, ErrNotByte) {
+			t.Errorf("%s: want ErrNotByte, got %v", pattern, err)
+		}
+	}
+}
+
+func mustBytes(g *BytesGenerator, err error) *BytesGenerator {
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
@@ -0,0 +1,111 @@
+package rerand
+
+import (
+	"container/list"
+	"regexp/syntax"
+	"sync"
+)
+
+// DefaultCacheSize is the number of Generators in Cache created with non-positive size.
+const DefaultCacheSize = 128
+
+// Cache is a cache of compiled Generators keyed by the patterns, the flags and the options,
+// e.g. for the HTTP handlers that receive the same patterns repeatedly.
+// It evicts the least recently used Generator if it is full.
+// It is safe for concurrent use by multiple goroutines.
+type Cache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	stats   CacheStats
+}
+
+// CacheStats is the statistics of Cache.
+type CacheStats struct {
+	// Hits is the number of the calls of Get that return a cached Generator.
+	Hits uint64
+
+	// Misses is the number of the calls of Get that compile the pattern.
+	Misses uint64
+
+	// Evictions is the number of the Generators evicted from the cache.
+	Evictions uint64
+
+	// Len is the number of the cached Generators.
+	Len int
+}
+
+type cacheEntry struct {
+	key string
+	gen *Generator
+}
+
+// NewCache returns new Cache that holds at most size Generators.
+// If size is not positive, DefaultCacheSize is used.
+func NewCache(size int) *Cache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &Cache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the cached Generator compiled from the pattern with the flags and opts, or compiles new one.
+// The Generators are shared by the callers of Get, and their random number generators are seeded with current time.
+// The options are compared by their text form of MarshalText,
+// so the Generators with the options that cannot be marshaled, e.g. WithFilter, are compiled every time and never cached.
+// The errors of compilation are not cached.
+func (c *Cache) Get(pattern string, flags syntax.Flags, opts ...Option) (*Generator, error) {
+	key, err := newOptions(opts).text(pattern, flags)
+	if err != nil {
+		c.mu.Lock()
+		c.stats.Misses++
+		c.mu.Unlock()
+		return New(pattern, flags, nil, opts...)
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		c.stats.Hits++
+		c.mu.Unlock()
+		return e.Value.(*cacheEntry).gen, nil
+	}
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	g, err := New(pattern, flags, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		// another goroutine compiled the same pattern.
+		c.lru.MoveToFront(e)
+		return e.Value.(*cacheEntry).gen, nil
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, gen: g})
+	for c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+	return g, nil
+}
+
+// Stats returns the statistics of c.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Len = c.lru.Len()
+	return stats
+}
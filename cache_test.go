@@ -0,0 +1,83 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+	g1, err := c.Get(`[a-z]{3}`, syntax.Perl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, err := c.Get(`[a-z]{3}`, syntax.Perl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g1 != g2 {
+		t.Error("want the cached generator")
+	}
+
+	// the flags and the options are parts of the key.
+	g3, _ := c.Get(`[a-z]{3}`, syntax.Perl|syntax.FoldCase)
+	g4, _ := c.Get(`[a-z]{3}`, syntax.Perl, WithDistinctRunes())
+	if g3 == g1 || g4 == g1 || g3 == g4 {
+		t.Error("want different generators")
+	}
+
+	want := CacheStats{Hits: 1, Misses: 3, Evictions: 1, Len: 2}
+	if got := c.Stats(); got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	// the least recently used one is evicted.
+	if g, _ := c.Get(`[a-z]{3}`, syntax.Perl); g == g1 {
+		t.Error("want g1 to be evicted")
+	}
+}
+
+func TestCacheUncacheable(t *testing.T) {
+	c := NewCache(0)
+	filter := WithFilter(func(s string) bool { return s != "" }, 10)
+	g1, err := c.Get(`a`, syntax.Perl, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, _ := c.Get(`a`, syntax.Perl, filter)
+	if g1 == g2 {
+		t.Error("want not to be cached")
+	}
+	if _, err := c.Get(`(`, syntax.Perl); err == nil {
+		t.Error("want error, got nil")
+	}
+	want := CacheStats{Misses: 3}
+	if got := c.Stats(); got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	c := NewCache(4)
+	patterns := []string{`a+`, `b{1,3}`, `[cd]`, `e|f`, `g?`}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				g, err := c.Get(patterns[j%len(patterns)], syntax.Perl, WithTargetLength(2))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				g.Generate()
+			}
+		}()
+	}
+	wg.Wait()
+	if s := c.Stats(); s.Hits+s.Misses != 800 || s.Len != 4 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
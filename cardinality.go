@@ -0,0 +1,86 @@
+package rerand
+
+import (
+	"errors"
+	"math/big"
+	"regexp/syntax"
+)
+
+// CardinalityLen returns the number of the strings of at most maxLen runes that g generates.
+// It is finite even if the pattern has unbounded repetitions, e.g. with WithTargetLength.
+// If the pattern is ambiguous, e.g. `(?:ab|a)b?`, the strings generated in more than one way are counted more than once.
+// Like Enumerate, it does not take WithDependency, WithGraphemeClusters and the options of post-processing into account.
+func (g *Generator) CardinalityLen(maxLen int) (*big.Int, error) {
+	if maxLen < 0 {
+		return nil, errors.New("rerand: negative length")
+	}
+
+	total := new(big.Int)
+	var prev []*big.Int // the counts of the strings of length l-1
+	for l := 0; l <= maxLen; l++ {
+		cnt, err := g.distinctCountsLen(prev, l)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, cnt[g.prog.Start])
+		prev = cnt
+	}
+	return total, nil
+}
+
+// distinctCountsLen returns the number of the strings of length l that each instruction generates,
+// counting the runes of InstRune as distinct strings.
+// prev is the counts of length l-1, and it is ignored if l is zero.
+func (g *Generator) distinctCountsLen(prev []*big.Int, l int) ([]*big.Int, error) {
+	cnt := make([]*big.Int, len(g.inst))
+	visited := make([]bool, len(g.inst))
+	var loop uint32 // the instruction where the empty loop is detected
+	var count func(pc uint32) *big.Int
+	count = func(pc uint32) *big.Int {
+		if cnt[pc] != nil {
+			return cnt[pc]
+		}
+		if visited[pc] {
+			loop = pc
+			panic(ErrTooManyRepeat)
+		}
+		visited[pc] = true
+		in := &g.inst[pc]
+		ret := new(big.Int)
+		switch in.Op {
+		case syntax.InstMatch:
+			if l == 0 {
+				ret.SetInt64(1)
+			}
+		case syntax.InstRune, syntax.InstRune1:
+			if l > 0 {
+				ret.Mul(prev[in.Out], big.NewInt(in.runeCount()))
+			}
+		case syntax.InstAlt:
+			ret.Add(count(in.Out), count(in.Arg))
+		case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+			ret.Set(count(in.Out))
+		}
+		cnt[pc] = ret
+		return ret
+	}
+
+	err := func() (err error) {
+		defer func() {
+			if e := recover(); e != nil {
+				if e != ErrTooManyRepeat {
+					panic(e)
+				}
+				err = newRepeatError(g.pattern, g.flags, loop, true)
+			}
+		}()
+		for pc := range g.inst {
+			count(uint32(pc))
+		}
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+	return cnt, nil
+}
@@ -0,0 +1,57 @@
+package rerand
+
+import (
+	"math/big"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestCardinalityLen(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    []Option
+		maxLen  int
+		want    int64
+	}{
+		{`[a-z]{3}`, nil, 3, 26 * 26 * 26},
+		{`[a-z]{3}`, nil, 2, 0},
+		{`[ab]{1,3}`, nil, 2, 2 + 4},
+		{`[ab]+`, []Option{WithTargetLength(3)}, 3, 2 + 4 + 8},
+		{`[ab]*`, []Option{WithTargetLength(3)}, 0, 1},
+		{`x|[0-9]+`, []Option{WithTargetLength(3)}, 2, 1 + 10 + 100},
+		{`[ab]{2}`, []Option{WithDistinctRunes()}, 5, 4},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, nil, tt.opts...))
+		got, err := g.CardinalityLen(tt.maxLen)
+		if err != nil {
+			t.Errorf("%s: %v", tt.pattern, err)
+			continue
+		}
+		if got.Cmp(big.NewInt(tt.want)) != 0 {
+			t.Errorf("%s, %d: want %d, got %s", tt.pattern, tt.maxLen, tt.want, got)
+		}
+	}
+}
+
+func TestCardinalityLenLarge(t *testing.T) {
+	g := Must(New(`[0-9a-f]+`, syntax.Perl, nil, WithTargetLength(32)))
+	got, err := g.CardinalityLen(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 16 + 16^2 + ... + 16^32 = (16^33 - 16) / 15
+	want := new(big.Int).Exp(big.NewInt(16), big.NewInt(33), nil)
+	want.Sub(want, big.NewInt(16))
+	want.Div(want, big.NewInt(15))
+	if got.Cmp(want) != 0 {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestCardinalityLenNegative(t *testing.T) {
+	g := Must(New(`a`, syntax.Perl, nil))
+	if _, err := g.CardinalityLen(-1); err == nil {
+		t.Error("want error, got nil")
+	}
+}
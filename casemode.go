@@ -0,0 +1,167 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"slices"
+	"unicode"
+)
+
+// CaseMode is the casing of the runes that are matched case-insensitively, e.g. by `(?i)` or syntax.FoldCase.
+type CaseMode int
+
+const (
+	// CaseAsPattern generates the literals in the case of the pattern,
+	// and the character classes in both cases. It is the default.
+	// For example, `(?i)hello[a-c]` generates HELLOa and HELLOB, because regexp/syntax canonicalizes the literals to upper case.
+	CaseAsPattern CaseMode = iota
+
+	// CaseLower generates the lower case, e.g. `(?i)hello[a-c]` generates helloa and hellob.
+	CaseLower
+
+	// CaseUpper generates the upper case, e.g. `(?i)hello[a-c]` generates HELLOA and HELLOB.
+	CaseUpper
+
+	// CaseMixed chooses the case of every rune uniformly at random, e.g. `(?i)hello[a-c]` generates hElLoA.
+	CaseMixed
+)
+
+// WithCase sets the casing of the runes that are matched case-insensitively.
+// The runes matched case-sensitively, e.g. `[A-Za-z]` without `(?i)`, are not affected.
+// The generated strings still match the pattern.
+func WithCase(mode CaseMode) Option {
+	return func(o *options) {
+		o.caseMode = mode
+	}
+}
+
+// applyCase returns re with the case-insensitive literals and character classes replaced by the ones of mode.
+// re is not modified.
+func applyCase(re *syntax.Regexp, mode CaseMode) *syntax.Regexp {
+	if mode == CaseAsPattern {
+		return re
+	}
+	ret := *re
+	ret.Sub0 = [1]*syntax.Regexp{}
+	if len(re.Sub) > 0 {
+		ret.Sub = make([]*syntax.Regexp, len(re.Sub))
+		for i, sub := range re.Sub {
+			ret.Sub[i] = applyCase(sub, mode)
+		}
+	}
+	if re.Flags&syntax.FoldCase == 0 {
+		return &ret
+	}
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		if mode == CaseMixed {
+			return mixedLiteral(re)
+		}
+		ret.Rune = make([]rune, len(re.Rune))
+		for i, r := range re.Rune {
+			ret.Rune[i] = foldTo(r, mode)
+		}
+		ret.Rune0 = [2]rune{}
+		ret.Flags &^= syntax.FoldCase
+	case syntax.OpCharClass:
+		if mode != CaseMixed {
+			ret.Rune = caseClass(re.Rune, mode)
+			ret.Rune0 = [2]rune{}
+		}
+	}
+	return &ret
+}
+
+// foldTo returns the rune in the case of mode that is equivalent to r under simple case folding,
+// or r if there is no such rune.
+func foldTo(r rune, mode CaseMode) rune {
+	to := unicode.ToLower
+	if mode == CaseUpper {
+		to = unicode.ToUpper
+	}
+	if c := to(r); c != r && equalFold(r, c) {
+		return c
+	}
+	return r
+}
+
+// equalFold reports whether r and c are equivalent under simple case folding.
+func equalFold(r, c rune) bool {
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f == c {
+			return true
+		}
+	}
+	return r == c
+}
+
+// mixedLiteral returns the concatenation of the character classes that consist of the case variants of each rune of re.
+func mixedLiteral(re *syntax.Regexp) *syntax.Regexp {
+	ret := &syntax.Regexp{Op: syntax.OpConcat, Flags: re.Flags &^ syntax.FoldCase}
+	for _, r := range re.Rune {
+		fold := []rune{r}
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			fold = append(fold, f)
+		}
+		sub := &syntax.Regexp{Op: syntax.OpLiteral, Flags: ret.Flags, Rune: []rune{r}}
+		if len(fold) > 1 {
+			slices.Sort(fold)
+			sub.Op = syntax.OpCharClass
+			sub.Rune = make([]rune, 0, 2*len(fold))
+			for _, f := range fold {
+				sub.Rune = append(sub.Rune, f, f)
+			}
+		}
+		ret.Sub = append(ret.Sub, sub)
+	}
+	if len(ret.Sub) == 1 {
+		return ret.Sub[0]
+	}
+	return ret
+}
+
+// caseClass returns the ranges of runes without the ones that are not in the case of mode,
+// if the equivalent rune in the case of mode is in the ranges.
+func caseClass(runes []rune, mode CaseMode) []rune {
+	// the runes to be removed are in the other cases.
+	tables := []*unicode.RangeTable{unicode.Upper, unicode.Title}
+	if mode == CaseUpper {
+		tables = []*unicode.RangeTable{unicode.Lower, unicode.Title}
+	}
+	var removed []rune
+	for _, table := range tables {
+		for _, r16 := range table.R16 {
+			for r := rune(r16.Lo); r <= rune(r16.Hi); r += rune(r16.Stride) {
+				removed = appendRemoved(removed, runes, r, mode)
+			}
+		}
+		for _, r32 := range table.R32 {
+			for r := rune(r32.Lo); r <= rune(r32.Hi); r += rune(r32.Stride) {
+				removed = appendRemoved(removed, runes, r, mode)
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return runes
+	}
+	slices.Sort(removed)
+	return subtractRunes(runes, slices.Compact(removed))
+}
+
+// appendRemoved appends r to removed if r and the equivalent rune in the case of mode are in runes.
+func appendRemoved(removed, runes []rune, r rune, mode CaseMode) []rune {
+	if c := foldTo(r, mode); c != r && inRanges(runes, r) && inRanges(runes, c) {
+		return append(removed, r)
+	}
+	return removed
+}
+
+// inRanges reports whether r is in the ranges of runes.
+func inRanges(runes []rune, r rune) bool {
+	for i := 0; i+1 < len(runes); i += 2 {
+		if runes[i] <= r && r <= runes[i+1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package rerand
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestWithCase(t *testing.T) {
+	tests := []struct {
+		pattern string
+		mode    CaseMode
+		check   func(s string) bool
+	}{
+		{`(?i)hello[a-c]`, CaseLower, func(s string) bool { return s == strings.ToLower(s) }},
+		{`(?i)hello[a-c]`, CaseUpper, func(s string) bool { return s == strings.ToUpper(s) }},
+		{`(?i)\pL{20}`, CaseLower, func(s string) bool {
+			// the upper case runes without the lower case, e.g. U+1D5DF, are kept.
+			return !strings.ContainsFunc(s, func(r rune) bool { return unicode.ToLower(r) != r && equalFold(r, unicode.ToLower(r)) })
+		}},
+		{`(?i)k`, CaseLower, func(s string) bool { return s == "k" }},
+		{`(?i:abc)DEF`, CaseLower, func(s string) bool { return s == "abcDEF" }},
+		{`[A-Z]{20}`, CaseLower, func(s string) bool { return s == strings.ToUpper(s) }},
+		{`(?i)hello`, CaseAsPattern, func(s string) bool { return s == "HELLO" }},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, nil, WithCase(tt.mode), WithVerify()))
+		for i := 0; i < 100; i++ {
+			if s := g.Generate(); !tt.check(s) {
+				t.Errorf("%s, %d: unexpected %q", tt.pattern, tt.mode, s)
+				break
+			}
+		}
+	}
+}
+
+func TestWithCaseMixed(t *testing.T) {
+	re := regexp.MustCompile(`^(?i)hello$`)
+	g := Must(New(`(?i)hello`, syntax.Perl, nil, WithCase(CaseMixed)))
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		s := g.Generate()
+		if !re.MatchString(s) {
+			t.Fatalf("unexpected %q", s)
+		}
+		seen[s] = true
+	}
+	// 32 variants of hello
+	if len(seen) != 32 {
+		t.Errorf("want 32 variants, got %d", len(seen))
+	}
+}
+
+func TestWithCaseError(t *testing.T) {
+	if _, err := New(`a`, syntax.Perl, nil, WithCase(CaseMixed+1)); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,151 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strings"
+)
+
+// Diagnostic is a problem of a pattern reported by Check.
+type Diagnostic struct {
+	// Offset is the byte offset of the construct in the pattern.
+	Offset int
+
+	// Expr is the construct.
+	Expr string
+
+	// Message describes the problem.
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d: %s: %s", d.Offset, d.Expr, d.Message)
+}
+
+// Check reports the constructs of the pattern that Generator does not support:
+// syntax errors, unbounded repetitions, empty-width assertions and character classes that match nothing.
+// It is for services that accept user-supplied patterns, so that they can reject them before New.
+// It returns nil if no problem is found.
+func Check(pattern string, flags syntax.Flags) []Diagnostic {
+	if _, err := syntax.Parse(pattern, flags); err != nil {
+		d := Diagnostic{Message: err.Error()}
+		var serr *syntax.Error
+		if errors.As(err, &serr) {
+			d.Expr = serr.Expr
+			d.Offset = max(strings.Index(pattern, serr.Expr), 0)
+			d.Message = serr.Code.String()
+		}
+		return []Diagnostic{d}
+	}
+	if flags&syntax.Literal != 0 {
+		return nil
+	}
+
+	var diags []Diagnostic
+	report := func(offset int, expr, message string) {
+		diags = append(diags, Diagnostic{Offset: offset, Expr: expr, Message: message})
+	}
+	perl := flags&syntax.PerlX != 0
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '\\':
+			if i+1 >= len(pattern) {
+				break
+			}
+			switch e := pattern[i+1]; e {
+			case 'A', 'z', 'b', 'B':
+				if perl {
+					report(i, pattern[i:i+2], "empty-width assertion is not supported")
+				}
+				i++
+			case 'Q':
+				if !perl {
+					i++
+					break
+				}
+				end := strings.Index(pattern[i:], `\E`)
+				if end < 0 {
+					i = len(pattern)
+				} else {
+					i += end + 1
+				}
+			case 'p', 'P', 'x':
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '{' {
+					if end := strings.IndexByte(pattern[i:], '}'); end >= 0 {
+						i += end
+					}
+				}
+			default:
+				i++
+			}
+		case '[':
+			end := classEnd(pattern, i)
+			class := pattern[i:end]
+			if re, err := syntax.Parse(class, flags); err == nil && (re.Op == syntax.OpNoMatch || re.Op == syntax.OpCharClass && len(re.Rune) == 0) {
+				report(i, class, "character class matches nothing")
+			}
+			i = end - 1
+		case '^', '$':
+			report(i, string(c), "empty-width assertion is not supported")
+		case '*', '+':
+			report(i, string(c), "unbounded repetition is not supported; use {min,max} or WithTargetLength")
+		case '{':
+			if end := strings.IndexByte(pattern[i:], '}'); end >= 0 {
+				repeat := pattern[i : i+end+1]
+				if isUnboundedRepeat(repeat) {
+					report(i, repeat, "unbounded repetition is not supported; use {min,max} or WithTargetLength")
+				}
+			}
+		case '(':
+			if strings.HasPrefix(pattern[i:], "(?") {
+				// skip the flags, so that `(?i)` is not reported
+				i++
+			}
+		}
+	}
+	return diags
+}
+
+// classEnd returns the index after the character class beginning at i.
+func classEnd(pattern string, i int) int {
+	j := i + 1
+	if j < len(pattern) && pattern[j] == '^' {
+		j++
+	}
+	if j < len(pattern) && pattern[j] == ']' {
+		j++ // a leading ] is literal
+	}
+	for j < len(pattern) {
+		switch {
+		case pattern[j] == '\\':
+			j += 2
+			continue
+		case strings.HasPrefix(pattern[j:], "[:"):
+			if end := strings.Index(pattern[j:], ":]"); end >= 0 {
+				j += end + 2
+				continue
+			}
+		case pattern[j] == ']':
+			return j + 1
+		}
+		j++
+	}
+	return len(pattern)
+}
+
+// isUnboundedRepeat reports whether s is a repetition such as {n,}.
+func isUnboundedRepeat(s string) bool {
+	body := s[1 : len(s)-1]
+	n, ok := strings.CutSuffix(body, ",")
+	if !ok || n == "" {
+		return false
+	}
+	for _, c := range n {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
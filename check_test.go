@@ -0,0 +1,44 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []Diagnostic
+	}{
+		{`\d{3}-\d{4}`, nil},
+		{`(?i)[a-z]{1,3}\{2,\}`, nil},
+		{`[*+^$]{2}\*\+`, nil},
+		{`a*b`, []Diagnostic{{Offset: 1, Expr: "*"}}},
+		{`(ab)+`, []Diagnostic{{Offset: 4, Expr: "+"}}},
+		{`a{2,}b{2,3}`, []Diagnostic{{Offset: 1, Expr: "{2,}"}}},
+		{`^abc$`, []Diagnostic{{Offset: 0, Expr: "^"}, {Offset: 4, Expr: "$"}}},
+		{`\bword\B`, []Diagnostic{{Offset: 0, Expr: `\b`}, {Offset: 6, Expr: `\B`}}},
+		{`x[^\x00-\x{10FFFF}]`, []Diagnostic{{Offset: 1, Expr: `[^\x00-\x{10FFFF}]`}}},
+		{`[]a]\p{Greek}\Q*+\E`, nil},
+		{`a(b`, []Diagnostic{{Offset: 0, Expr: "a(b"}}},
+		{`x**`, []Diagnostic{{Offset: 1, Expr: "**"}}},
+	}
+	for _, tt := range tests {
+		got := Check(tt.pattern, syntax.Perl)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: want %d diagnostics, got %v", tt.pattern, len(tt.want), got)
+			continue
+		}
+		for i, d := range got {
+			if d.Offset != tt.want[i].Offset || d.Expr != tt.want[i].Expr || d.Message == "" {
+				t.Errorf("%s: want %d: %s, got %v", tt.pattern, tt.want[i].Offset, tt.want[i].Expr, d)
+			}
+		}
+	}
+}
+
+func TestCheckLiteral(t *testing.T) {
+	if got := Check(`a*^$`, syntax.Literal); got != nil {
+		t.Errorf("want nil, got %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package rerand
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"regexp/syntax"
+)
+
+// ErrInvalidCheckpoint is returned by ResumeGenerator if the checkpoint is broken, or taken by another generator.
+var ErrInvalidCheckpoint = errors.New("rerand: invalid checkpoint")
+
+// checkpointMagic begins the checkpoint, and includes the version of the format.
+const checkpointMagic = "rerand-checkpoint\x01"
+
+// Checkpoint returns the state of g to continue the generation by ResumeGenerator,
+// i.e. the state of the random number generator and the Bloom filter of WithProbabilisticUnique.
+// The state of the random number generator is available in the same way as State, otherwise ErrStateUnavailable is returned.
+// g must not be used while the checkpoint is taken.
+func (g *Generator) Checkpoint() ([]byte, error) {
+	if g.src == nil {
+		return nil, ErrStateUnavailable
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	state, err := g.src.pcg.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := []byte(checkpointMagic)
+	buf = binary.AppendUvarint(buf, uint64(len(g.pattern)))
+	buf = append(buf, g.pattern...)
+	buf = binary.AppendUvarint(buf, uint64(g.flags))
+	buf = binary.AppendUvarint(buf, uint64(len(state)))
+	buf = append(buf, state...)
+	if f := g.opts.unique; f != nil {
+		buf = binary.AppendUvarint(buf, f.m)
+		buf = binary.AppendUvarint(buf, uint64(f.k))
+		for i := range f.bits {
+			buf = binary.LittleEndian.AppendUint64(buf, f.bits[i].Load())
+		}
+	} else {
+		buf = binary.AppendUvarint(buf, 0)
+	}
+	return buf, nil
+}
+
+// ResumeGenerator returns new Generator that continues the generation from the checkpoint taken by Checkpoint.
+// pattern, flags and opts must be the same as the Generator that took the checkpoint,
+// and then it generates the same strings as the Generator would generate after the checkpoint.
+// With WithProbabilisticUnique, it never generates the strings generated before the checkpoint.
+func ResumeGenerator(pattern string, flags syntax.Flags, checkpoint []byte, opts ...Option) (*Generator, error) {
+	g, err := newGenerator(pattern, flags, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(checkpoint)
+	magic := make([]byte, len(checkpointMagic))
+	if _, err := r.Read(magic); err != nil || string(magic) != checkpointMagic {
+		return nil, ErrInvalidCheckpoint
+	}
+	readBytes := func() ([]byte, error) {
+		n, err := binary.ReadUvarint(r)
+		if err != nil || n > uint64(r.Len()) {
+			return nil, ErrInvalidCheckpoint
+		}
+		b := make([]byte, n)
+		r.Read(b)
+		return b, nil
+	}
+	p, err := readBytes()
+	if err != nil || string(p) != pattern {
+		return nil, ErrInvalidCheckpoint
+	}
+	f, err := binary.ReadUvarint(r)
+	if err != nil || syntax.Flags(f) != flags {
+		return nil, ErrInvalidCheckpoint
+	}
+	state, err := readBytes()
+	if err != nil {
+		return nil, err
+	}
+	if err := g.src.pcg.UnmarshalBinary(state); err != nil {
+		return nil, ErrInvalidCheckpoint
+	}
+
+	m, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, ErrInvalidCheckpoint
+	}
+	bloom := g.opts.unique
+	if m == 0 {
+		if bloom != nil || r.Len() != 0 {
+			return nil, ErrInvalidCheckpoint
+		}
+		return g, nil
+	}
+	k, err := binary.ReadUvarint(r)
+	if err != nil || bloom == nil || bloom.m != m || uint64(bloom.k) != k || uint64(r.Len()) != m/8 {
+		return nil, ErrInvalidCheckpoint
+	}
+	var word [8]byte
+	for i := range bloom.bits {
+		r.Read(word[:])
+		bloom.bits[i].Store(binary.LittleEndian.Uint64(word[:]))
+	}
+	return g, nil
+}
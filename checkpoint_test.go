@@ -0,0 +1,79 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestCheckpoint(t *testing.T) {
+	g := Must(New(`[a-z]{8}`, syntax.Perl, nil))
+	for i := 0; i < 10; i++ {
+		g.Generate()
+	}
+	cp, err := g.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := ResumeGenerator(`[a-z]{8}`, syntax.Perl, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if a, b := g.Generate(), resumed.Generate(); a != b {
+			t.Errorf("want %q, got %q", a, b)
+		}
+	}
+}
+
+func TestCheckpointUnique(t *testing.T) {
+	opt := func() Option { return WithProbabilisticUnique(1000, 0.001) }
+	g := Must(New(`[a-z]{2}`, syntax.Perl, nil, opt()))
+	seen := map[string]bool{}
+	for i := 0; i < 300; i++ {
+		seen[g.Generate()] = true
+	}
+	cp, err := g.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumed, err := ResumeGenerator(`[a-z]{2}`, syntax.Perl, cp, opt())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 300; i++ {
+		s := resumed.Generate()
+		if seen[s] {
+			t.Fatalf("%q is generated before the checkpoint", s)
+		}
+		seen[s] = true
+	}
+}
+
+func TestResumeGeneratorInvalid(t *testing.T) {
+	g := Must(New(`[a-z]{8}`, syntax.Perl, nil, WithProbabilisticUnique(1000, 0.01)))
+	cp, err := g.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		pattern    string
+		checkpoint []byte
+		opts       []Option
+	}{
+		{"another pattern", `[a-z]{9}`, cp, []Option{WithProbabilisticUnique(1000, 0.01)}},
+		{"another filter", `[a-z]{8}`, cp, []Option{WithProbabilisticUnique(2000, 0.01)}},
+		{"no filter", `[a-z]{8}`, cp, nil},
+		{"truncated", `[a-z]{8}`, cp[:len(cp)-1], []Option{WithProbabilisticUnique(1000, 0.01)}},
+		{"broken", `[a-z]{8}`, []byte("broken"), nil},
+	}
+	for _, tt := range tests {
+		_, err := ResumeGenerator(tt.pattern, syntax.Perl, tt.checkpoint, tt.opts...)
+		if !errors.Is(err, ErrInvalidCheckpoint) {
+			t.Errorf("%s: want ErrInvalidCheckpoint, got %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package rerand
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Clone returns new Generator that shares the compiled program with g, and uses r.
+// Compiling the pattern is not repeated, so it is cheap to create a Generator for each goroutine,
+// which never contends with other goroutines for the random number generator.
+// If r is nil, the random number generator seeded with current time is used.
+func (g *Generator) Clone(r *rand.Rand) *Generator {
+	var src *pcgSource
+	if r == nil {
+		src = newPCGSource(time.Now().UnixNano())
+		r = rand.New(src)
+	}
+	return &Generator{
+		pattern:      g.pattern,
+		flags:        g.flags,
+		re:           g.re,
+		opts:         g.opts,
+		prog:         g.prog,
+		inst:         g.inst,
+		code:         g.code,
+		min:          g.min,
+		max:          g.max,
+		runes:        g.runes,
+		weighted:     g.weighted,
+		rand:         r,
+		src:          src,
+		lengthCounts: g.lengthCounts,
+		verifier:     g.verifier,
+		interned:     g.interned,
+	}
+}
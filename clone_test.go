@@ -0,0 +1,40 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"sync"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	g := Must(New(`\d{2,3}-\d{3,4}-\d{3,4}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	c := g.Clone(rand.New(rand.NewSource(1)))
+	for i := 0; i < 100; i++ {
+		if a, b := g.Generate(), c.Generate(); a != b {
+			t.Errorf("want same strings, got %q and %q", a, b)
+		}
+	}
+}
+
+func TestCloneConcurrent(t *testing.T) {
+	re := regexp.MustCompile(`^[a-z]{1,8}$`)
+	g := Must(New(`[a-z]{1,8}`, syntax.Perl, nil))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(c *Generator) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if s := c.Generate(); !re.MatchString(s) {
+					t.Errorf("%q does not match", s)
+				}
+				if _, err := c.GenerateLen(3); err != nil {
+					t.Error(err)
+				}
+			}
+		}(g.Clone(nil))
+	}
+	wg.Wait()
+}
@@ -1,20 +1,66 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
-	"regexp/syntax"
-
 	"math"
+	"math/rand"
+	"os"
+	"regexp/syntax"
+	"strings"
+	"time"
 
 	rerand "github.com/shogo82148/go-rerand"
 )
 
+var flagNames = map[string]syntax.Flags{
+	"foldcase":      syntax.FoldCase,
+	"literal":       syntax.Literal,
+	"classnl":       syntax.ClassNL,
+	"dotnl":         syntax.DotNL,
+	"oneline":       syntax.OneLine,
+	"nongreedy":     syntax.NonGreedy,
+	"perlx":         syntax.PerlX,
+	"unicodegroups": syntax.UnicodeGroups,
+	"wasdollar":     syntax.WasDollar,
+	"simple":        syntax.Simple,
+	"matchnl":       syntax.MatchNL,
+	"perl":          syntax.Perl,
+	"posix":         syntax.POSIX,
+}
+
+// parseFlags parses comma separated names of syntax.Flags, e.g. "perl,foldcase".
+func parseFlags(s string) (syntax.Flags, error) {
+	var flags syntax.Flags
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		f, ok := flagNames[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown flag: %q", name)
+		}
+		flags |= f
+	}
+	return flags, nil
+}
+
 func main() {
 	var n int
 	var distinctRunes bool
 	var prob float64
+	var pattern string
+	var seed int64
+	var flagsStr string
+	var delimiter string
+	var nul bool
+	var jsonOutput bool
+	var out string
 	var help bool
 	flag.IntVar(&n, "n", 1, "the number of random strings")
 	flag.IntVar(&n, "number", 1, "the number of random strings")
@@ -22,6 +68,14 @@ func main() {
 	flag.BoolVar(&distinctRunes, "distinct-runes", false, "distinct runes")
 	flag.Float64Var(&prob, "p", 0, "the probability for AltInst")
 	flag.Float64Var(&prob, "prob", 0, "the probability for AltInst")
+	flag.StringVar(&pattern, "pattern", "", "the regular expression (default the first argument)")
+	flag.Int64Var(&seed, "seed", 0, "the seed of the random number generator (default current time)")
+	flag.StringVar(&flagsStr, "flags", "perl", "comma separated syntax flags, e.g. perl,foldcase")
+	flag.StringVar(&delimiter, "delimiter", "\n", "the delimiter between random strings")
+	flag.BoolVar(&nul, "0", false, "separate random strings by NUL character")
+	flag.BoolVar(&jsonOutput, "json", false, "output random strings as a JSON array")
+	flag.StringVar(&out, "o", "", "write to the file instead of stdout")
+	flag.StringVar(&out, "out", "", "write to the file instead of stdout")
 	flag.BoolVar(&help, "h", false, "show help message")
 	flag.BoolVar(&help, "help", false, "show help message")
 	flag.Parse()
@@ -31,22 +85,113 @@ func main() {
 		return
 	}
 
+	if jsonOutput && nul {
+		log.Fatal("-json and -0 cannot be used together")
+	}
+	if pattern == "" {
+		pattern = flag.Arg(0)
+	}
+	flags, err := parseFlags(flagsStr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	seeded := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seeded = true
+		}
+	})
+	if !seeded {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed))
+
 	var g *rerand.Generator
-	var err error
 	if distinctRunes {
-		g, err = rerand.NewDistinctRunes(flag.Arg(0), syntax.Perl, nil)
+		g, err = rerand.NewDistinctRunes(pattern, flags, r)
 	} else if prob > 0 {
 		if prob >= 1 {
 			log.Fatal("prob must be less than 1")
 		}
-		g, err = rerand.NewWithProbability(flag.Arg(0), syntax.Perl, nil, int64(math.MaxInt64*prob))
+		g, err = rerand.NewWithProbability(pattern, flags, r, int64(math.MaxInt64*prob))
+	} else {
+		g, err = rerand.New(pattern, flags, r)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if nul {
+		delimiter = "\x00"
+	}
+	write := func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		var err error
+		if jsonOutput {
+			err = writeJSON(bw, g, n)
+		} else {
+			err = writeDelimited(bw, g, n, delimiter)
+		}
+		if err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+
+	if out == "" {
+		err = write(os.Stdout)
 	} else {
-		g, err = rerand.New(flag.Arg(0), syntax.Perl, nil)
+		err = writeFile(out, write)
 	}
 	if err != nil {
 		log.Fatal(err)
 	}
+}
+
+// writeFile creates the file named name and writes it by write.
+// The error of closing the file is reported, because the written data may be lost.
+func writeFile(name string, write func(w io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeDelimited(w *bufio.Writer, g *rerand.Generator, n int, delimiter string) error {
 	for i := 0; i < n; i++ {
-		fmt.Println(g.Generate())
+		if _, err := w.WriteString(g.Generate()); err != nil {
+			return err
+		}
+		if _, err := w.WriteString(delimiter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w *bufio.Writer, g *rerand.Generator, n int) error {
+	if err := w.WriteByte('['); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			if err := w.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		b, err := json.Marshal(g.Generate())
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
 	}
+	_, err := w.WriteString("]\n")
+	return err
 }
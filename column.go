@@ -0,0 +1,31 @@
+package rerand
+
+// GenerateColumn fills dst with random strings, e.g. a column of a columnar backend.
+// The lock of g is taken once for all strings, so it is faster than calling Generate for each string,
+// but the other goroutines wait for the whole column.
+// It panics if the generation fails, in the same way as Generate.
+func (g *Generator) GenerateColumn(dst []string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i := range dst {
+		dst[i] = g.generate(g.rand, nopLocker{})
+	}
+}
+
+// GenerateColumns generates n random records as columns, i.e. maps the name of each field to its n values.
+// All values of a field are generated before the next field, in the order of Fields,
+// so the records differ from the ones of GenerateRecord with the same seed.
+// It is safe for concurrent use by multiple goroutines.
+func (s *Schema) GenerateColumns(n int) map[string][]string {
+	columns := make(map[string][]string, len(s.names))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, g := range s.gens {
+		column := make([]string, n)
+		for j := range column {
+			column[j] = g.generate(s.rand, nopLocker{})
+		}
+		columns[s.names[i]] = column
+	}
+	return columns
+}
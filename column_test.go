@@ -0,0 +1,70 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestGenerateColumn(t *testing.T) {
+	g1 := Must(New(`[a-z]{3}[0-9]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	g2 := Must(New(`[a-z]{3}[0-9]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	column := make([]string, 100)
+	g1.GenerateColumn(column)
+	for i, s := range column {
+		// same as Generate with the same seed.
+		if want := g2.Generate(); s != want {
+			t.Errorf("%d: want %q, got %q", i, want, s)
+		}
+	}
+}
+
+func TestGenerateColumns(t *testing.T) {
+	s, err := NewSchema(map[string]string{
+		"id":   `[0-9]{4}`,
+		"name": `[A-Z][a-z]{2,5}`,
+	}, syntax.Perl, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := s.GenerateColumns(50)
+	if len(columns) != 2 {
+		t.Fatalf("want 2 columns, got %d", len(columns))
+	}
+	patterns := map[string]*regexp.Regexp{
+		"id":   regexp.MustCompile(`^[0-9]{4}$`),
+		"name": regexp.MustCompile(`^[A-Z][a-z]{2,5}$`),
+	}
+	for name, re := range patterns {
+		if len(columns[name]) != 50 {
+			t.Errorf("%s: want 50 values, got %d", name, len(columns[name]))
+		}
+		for _, v := range columns[name] {
+			if !re.MatchString(v) {
+				t.Errorf("%s: unexpected value %q", name, v)
+			}
+		}
+	}
+
+	if columns := s.GenerateColumns(0); len(columns["id"]) != 0 || len(columns) != 2 {
+		t.Errorf("unexpected columns: %v", columns)
+	}
+}
+
+func BenchmarkGenerateColumn(b *testing.B) {
+	g := Must(New(`[a-z]{8}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	column := make([]string, 1024)
+	b.Run("Generate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range column {
+				column[j] = g.Generate()
+			}
+		}
+	})
+	b.Run("GenerateColumn", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			g.GenerateColumn(column)
+		}
+	})
+}
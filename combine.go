@@ -0,0 +1,166 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+)
+
+// Concat returns new Generator that generates the concatenation of the strings generated by gens.
+// The parsed patterns of gens are compiled again, and the options of gens are not inherited.
+// The random number generator of the new Generator is seeded from the first generator.
+func Concat(gens ...*Generator) (*Generator, error) {
+	if len(gens) == 0 {
+		return nil, errors.New("rerand: no generators")
+	}
+	subs := combineSubs(gens)
+	re := &syntax.Regexp{
+		Op:  syntax.OpConcat,
+		Sub: subs,
+	}
+	return combine(gens[0], re, combinePattern(gens, ""), nil)
+}
+
+// Union returns new Generator that chooses one of gens with the probability proportional to weights,
+// and generates the string by it.
+// The parsed patterns of gens are compiled again, and the options of gens are not inherited.
+// The random number generator of the new Generator is seeded from the first generator.
+func Union(weights []float64, gens ...*Generator) (*Generator, error) {
+	if len(gens) == 0 {
+		return nil, errors.New("rerand: no generators")
+	}
+	if len(weights) != len(gens) {
+		return nil, errors.New("rerand: the number of weights must be the number of generators")
+	}
+	intWeights, err := scaleWeights(weights)
+	if err != nil {
+		return nil, err
+	}
+
+	// drop the generators that are never chosen.
+	var chosen []*Generator
+	var chosenWeights []int64
+	for i, w := range intWeights {
+		if w > 0 {
+			chosen = append(chosen, gens[i])
+			chosenWeights = append(chosenWeights, w)
+		}
+	}
+	subs := combineSubs(chosen)
+	if len(subs) == 1 {
+		return combine(gens[0], subs[0], chosen[0].pattern, nil)
+	}
+	re := &syntax.Regexp{
+		Op:  syntax.OpAlternate,
+		Sub: subs,
+	}
+	return combine(gens[0], re, combinePattern(chosen, "|"), chosenWeights)
+}
+
+// scaleWeights converts weights into int64 proportional to them.
+func scaleWeights(weights []float64) ([]int64, error) {
+	var sum, max float64
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, errors.New("rerand: invalid weight")
+		}
+		sum += w
+		max = math.Max(max, w)
+	}
+	if sum <= 0 {
+		return nil, errors.New("rerand: the sum of weights must be positive")
+	}
+	ret := make([]int64, len(weights))
+	for i, w := range weights {
+		ret[i] = int64(w / max * (1 << 52))
+	}
+	return ret, nil
+}
+
+// Repeat returns new Generator that generates the concatenation of min to max strings generated by g.
+// The parsed pattern of g is compiled again, and the options of g are not inherited.
+// It fails with *BudgetError if the program of the repetition exceeds combineBudget, e.g. `a{1000}` repeated 1000 times.
+// The random number generator of the new Generator is seeded from g.
+func Repeat(g *Generator, min, max int) (*Generator, error) {
+	if min < 0 || min > max || max > 1000 {
+		return nil, errors.New("rerand: invalid repeat count")
+	}
+	re := &syntax.Regexp{
+		Op:    syntax.OpRepeat,
+		Flags: g.re.Flags,
+		Sub:   []*syntax.Regexp{g.re},
+		Min:   min,
+		Max:   max,
+	}
+	return combine(g, re, "(?:"+g.pattern+")"+repeatSuffix(min, max), nil)
+}
+
+func repeatSuffix(min, max int) string {
+	if min == max {
+		return fmt.Sprintf("{%d}", min)
+	}
+	return fmt.Sprintf("{%d,%d}", min, max)
+}
+
+// combineSubs returns the patterns of gens with renumbered capture groups.
+func combineSubs(gens []*Generator) []*syntax.Regexp {
+	subs := make([]*syntax.Regexp, len(gens))
+	var offset int
+	for i, g := range gens {
+		subs[i] = renumberCaps(g.re, offset)
+		offset += g.re.MaxCap()
+	}
+	return subs
+}
+
+// combinePattern joins the patterns of gens for String.
+func combinePattern(gens []*Generator, sep string) string {
+	patterns := make([]string, len(gens))
+	for i, g := range gens {
+		patterns[i] = "(?:" + g.pattern + ")"
+	}
+	return strings.Join(patterns, sep)
+}
+
+// renumberCaps returns the copy of re whose capture groups are shifted by offset.
+func renumberCaps(re *syntax.Regexp, offset int) *syntax.Regexp {
+	if offset == 0 {
+		return re
+	}
+	re2 := *re
+	if re.Op == syntax.OpCapture {
+		re2.Cap += offset
+	}
+	if len(re.Sub) > 0 {
+		re2.Sub = make([]*syntax.Regexp, len(re.Sub))
+		for i, sub := range re.Sub {
+			re2.Sub[i] = renumberCaps(sub, offset)
+		}
+	}
+	return &re2
+}
+
+// combineBudget is the compile budget of the combined patterns in bytes.
+// The combinators take no options, and the parser does not check the combined patterns,
+// e.g. the nested repetitions of Repeat.
+const combineBudget = 16 << 20
+
+// combine compiles re into new Generator, whose random number generator is seeded from g.
+func combine(g *Generator, re *syntax.Regexp, pattern string, unionWeights []int64) (*Generator, error) {
+	if err := newCompileBudget(combineBudget).charge("program", programBytes(re)); err != nil {
+		return nil, err
+	}
+	g.mu.Lock()
+	seed := g.rand.Int63()
+	g.mu.Unlock()
+	src := newPCGSource(seed)
+	gen, err := compileGenerator(re, pattern, g.flags, rand.New(src), nil, unionWeights)
+	if err != nil {
+		return nil, err
+	}
+	gen.src = src
+	return gen, nil
+}
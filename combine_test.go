@@ -0,0 +1,93 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	g1 := Must(NewSeeded(`(?P<user>[a-z]{3,5})`, syntax.Perl, 1))
+	g2 := Must(NewSeeded(`@(?P<domain>example|test)\.(com|org)`, syntax.Perl, 2))
+	g := Must(Concat(g1, g2))
+	re := regexp.MustCompile(`\A[a-z]{3,5}@(?:example|test)\.(?:com|org)\z`)
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+	if names := g.re.CapNames(); names[1] != "user" || names[2] != "domain" {
+		t.Errorf("unexpected capture names: %v", names)
+	}
+	if _, err := Concat(); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestUnion(t *testing.T) {
+	valid := Must(NewSeeded(`[0-9]{8}`, syntax.Perl, 1))
+	legacy := Must(NewSeeded(`L[0-9]{4}`, syntax.Perl, 2))
+	junk := Must(NewSeeded(`[a-z]{8}`, syntax.Perl, 3))
+	g := Must(Union([]float64{90, 10, 0}, valid, legacy, junk))
+	var nvalid, nlegacy int
+	const n = 10000
+	for i := 0; i < n; i++ {
+		s := g.Generate()
+		switch {
+		case strings.HasPrefix(s, "L"):
+			nlegacy++
+		case len(s) == 8 && s[0] >= '0' && s[0] <= '9':
+			nvalid++
+		default:
+			t.Fatalf("unexpected string %q", s)
+		}
+	}
+	if nvalid < n*85/100 || nvalid > n*95/100 {
+		t.Errorf("want about 90%% valid, got %d", nvalid)
+	}
+	if nvalid+nlegacy != n {
+		t.Errorf("want no junk")
+	}
+
+	for _, weights := range [][]float64{{1}, {-1, 1}, {0, 0}} {
+		if _, err := Union(weights, valid, legacy); err == nil {
+			t.Errorf("%v: want error, got nil", weights)
+		}
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	g := Must(Repeat(Must(New(`[a-z]{2}-`, syntax.Perl, nil)), 1, 3))
+	re := regexp.MustCompile(`\A(?:[a-z]{2}-){1,3}\z`)
+	seen := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		s := g.Generate()
+		if !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+		seen[len(s)/3] = true
+	}
+	if !seen[1] || !seen[2] || !seen[3] {
+		t.Errorf("want all counts, got %v", seen)
+	}
+	if got, want := g.String(), `(?:[a-z]{2}-){1,3}`; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if _, err := Repeat(g, 3, 1); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestRepeatTooLarge(t *testing.T) {
+	_, err := Repeat(Must(New(`a{1000}`, syntax.Perl, nil)), 1000, 1000)
+	var budgetErr *BudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Errorf("want *BudgetError, got %v", err)
+	}
+	g := Must(Repeat(Must(New(`a{100}`, syntax.Perl, nil)), 10, 10))
+	if _, err := Concat(g, g); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,142 @@
+package rerand
+
+import (
+	"fmt"
+	"math"
+)
+
+// corpusSmoothing is the pseudo-count added to every symbol for the divergences of CompareCorpora,
+// so that the divergences are finite even if a symbol appears in only one corpus.
+const corpusSmoothing = 0.5
+
+// Report is the difference between the distributions of two corpora, returned by CompareCorpora.
+// The divergences are the Kullback-Leibler divergences KL(a||b) in bits, smoothed by adding 0.5 to every count.
+type Report struct {
+	// NA and NB are the numbers of the strings of the corpora.
+	NA, NB int
+
+	// MeanLengthA and MeanLengthB are the mean numbers of the runes of the strings.
+	MeanLengthA, MeanLengthB float64
+
+	// LengthDivergence is the divergence of the distributions of the numbers of the runes.
+	LengthDivergence float64
+
+	// PositionDivergence is the divergences of the distributions of the runes at each position.
+	// The strings shorter than the position are counted as the end of string.
+	PositionDivergence []float64
+
+	// MaxPositionDivergence is the maximum of PositionDivergence, at the position MaxPosition.
+	MaxPositionDivergence float64
+	MaxPosition           int
+}
+
+// CompareCorpora compares the distributions of the lengths and the runes at each position of the corpora a and b,
+// e.g. the outputs of a generator before and after changing the pattern or upgrading rerand,
+// so that the tests fail if the distribution shifts materially.
+// If a or b is empty, the divergences are NaN.
+func CompareCorpora(a, b []string) Report {
+	ra, rb := corpusRunes(a), corpusRunes(b)
+	rep := Report{
+		NA:          len(a),
+		NB:          len(b),
+		MeanLengthA: meanLength(ra),
+		MeanLengthB: meanLength(rb),
+	}
+	if len(a) == 0 || len(b) == 0 {
+		rep.LengthDivergence = math.NaN()
+		rep.MaxPositionDivergence = math.NaN()
+		return rep
+	}
+
+	lengthsA, lengthsB := map[int]int{}, map[int]int{}
+	var maxLen int
+	for _, s := range ra {
+		lengthsA[len(s)]++
+		maxLen = max(maxLen, len(s))
+	}
+	for _, s := range rb {
+		lengthsB[len(s)]++
+		maxLen = max(maxLen, len(s))
+	}
+	rep.LengthDivergence = divergence(lengthsA, lengthsB, len(a), len(b))
+
+	const end = -1 // the symbol of the end of string
+	rep.PositionDivergence = make([]float64, maxLen)
+	for i := 0; i < maxLen; i++ {
+		countsA, countsB := map[rune]int{}, map[rune]int{}
+		for _, s := range ra {
+			if i < len(s) {
+				countsA[s[i]]++
+			} else {
+				countsA[end]++
+			}
+		}
+		for _, s := range rb {
+			if i < len(s) {
+				countsB[s[i]]++
+			} else {
+				countsB[end]++
+			}
+		}
+		d := divergence(countsA, countsB, len(a), len(b))
+		rep.PositionDivergence[i] = d
+		if d > rep.MaxPositionDivergence {
+			rep.MaxPositionDivergence = d
+			rep.MaxPosition = i
+		}
+	}
+	return rep
+}
+
+// Divergence returns the maximum of LengthDivergence and MaxPositionDivergence,
+// which is compared with a threshold to gate the changes.
+func (r *Report) Divergence() float64 {
+	if math.IsNaN(r.LengthDivergence) {
+		return math.NaN()
+	}
+	return max(r.LengthDivergence, r.MaxPositionDivergence)
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("n: %d/%d, mean length: %.4g/%.4g, length divergence: %.4g, max position divergence: %.4g at %d",
+		r.NA, r.NB, r.MeanLengthA, r.MeanLengthB, r.LengthDivergence, r.MaxPositionDivergence, r.MaxPosition)
+}
+
+func corpusRunes(corpus []string) [][]rune {
+	ret := make([][]rune, len(corpus))
+	for i, s := range corpus {
+		ret[i] = []rune(s)
+	}
+	return ret
+}
+
+func meanLength(corpus [][]rune) float64 {
+	if len(corpus) == 0 {
+		return math.NaN()
+	}
+	var sum int
+	for _, s := range corpus {
+		sum += len(s)
+	}
+	return float64(sum) / float64(len(corpus))
+}
+
+// divergence returns the smoothed Kullback-Leibler divergence KL(a||b) in bits,
+// where na and nb are the sums of the counts.
+func divergence[K comparable](a, b map[K]int, na, nb int) float64 {
+	symbols := make(map[K]struct{}, len(a)+len(b))
+	for k := range a {
+		symbols[k] = struct{}{}
+	}
+	for k := range b {
+		symbols[k] = struct{}{}
+	}
+	k := float64(len(symbols))
+	var d float64
+	for s := range symbols {
+		p := (float64(a[s]) + corpusSmoothing) / (float64(na) + corpusSmoothing*k)
+		q := (float64(b[s]) + corpusSmoothing) / (float64(nb) + corpusSmoothing*k)
+		d += p * math.Log2(p/q)
+	}
+	return max(d, 0)
+}
@@ -0,0 +1,60 @@
+package rerand
+
+import (
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func generateCorpus(pattern string, seed int64, n int) []string {
+	g := Must(New(pattern, syntax.Perl, rand.New(rand.NewSource(seed))))
+	ret := make([]string, n)
+	for i := range ret {
+		ret[i] = g.Generate()
+	}
+	return ret
+}
+
+func TestCompareCorpora(t *testing.T) {
+	a := generateCorpus(`[a-f]{3}`, 1, 5000)
+	b := generateCorpus(`[a-f]{3}`, 2, 5000)
+	r := CompareCorpora(a, b)
+	if r.NA != 5000 || r.NB != 5000 || len(r.PositionDivergence) != 3 {
+		t.Fatalf("unexpected report: %s", &r)
+	}
+	if r.MeanLengthA != 3 || r.MeanLengthB != 3 {
+		t.Errorf("want mean length 3, got %s", &r)
+	}
+	if d := r.Divergence(); d > 0.01 {
+		t.Errorf("same distributions diverge: %s", &r)
+	}
+
+	// the third rune is shifted.
+	c := generateCorpus(`[a-f]{2}[a-c]`, 3, 5000)
+	r = CompareCorpora(a, c)
+	if r.MaxPosition != 2 || r.MaxPositionDivergence < 0.1 {
+		t.Errorf("the shift is not detected: %s", &r)
+	}
+	if r.LengthDivergence > 0.01 {
+		t.Errorf("the lengths diverge: %s", &r)
+	}
+
+	// the lengths are shifted.
+	d := generateCorpus(`[a-f]{2,4}`, 4, 5000)
+	r = CompareCorpora(a, d)
+	if r.LengthDivergence < 0.1 {
+		t.Errorf("the shift is not detected: %s", &r)
+	}
+}
+
+func TestCompareCorporaEmpty(t *testing.T) {
+	r := CompareCorpora(nil, []string{"a"})
+	if !math.IsNaN(r.Divergence()) || r.NB != 1 {
+		t.Errorf("unexpected report: %s", &r)
+	}
+	r = CompareCorpora([]string{""}, []string{""})
+	if r.Divergence() != 0 || len(r.PositionDivergence) != 0 {
+		t.Errorf("unexpected report: %s", &r)
+	}
+}
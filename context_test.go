@@ -0,0 +1,64 @@
+package rerand
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+	"time"
+)
+
+func TestGenerateContext(t *testing.T) {
+	g := Must(New(`[a-z]{8}`, syntax.Perl, nil))
+	s, err := g.GenerateContext(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 8 {
+		t.Errorf("unexpected %q", s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.GenerateContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("want context.Canceled, got %v", err)
+	}
+}
+
+func TestGenerateContextFilter(t *testing.T) {
+	// the filter never passes, and takes long time.
+	g := Must(New(`a`, syntax.Perl, nil, WithFilter(func(string) bool {
+		time.Sleep(10 * time.Millisecond)
+		return false
+	}, 1000000)))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := g.GenerateContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("want context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestGenerateContextRepeat(t *testing.T) {
+	g := Must(New(`a*`, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(1e6)))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the context is checked after ctxCheckInterval repetitions.
+	for i := 0; i < 100; i++ {
+		runes, err := g.appendRunes(ctx, nil, g.rand, &g.mu)
+		if errors.Is(err, context.Canceled) {
+			if len(runes) < ctxCheckInterval-1 {
+				t.Errorf("canceled too early: %d", len(runes))
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runes) >= ctxCheckInterval {
+			t.Fatalf("not canceled: %d", len(runes))
+		}
+	}
+	t.Error("want context.Canceled")
+}
@@ -0,0 +1,141 @@
+package rerand
+
+import (
+	"fmt"
+	"regexp/syntax"
+)
+
+// CoverageReport is the coverage of the program by strings, returned by Coverage.
+type CoverageReport struct {
+	// Branches is the branches of the alternations, in the order of the program counter.
+	Branches []BranchCoverage
+
+	// Ranges is the ranges of the character classes, in the order of the program counter.
+	Ranges []RangeCoverage
+
+	// Unmatched is the strings that the generator never generates.
+	Unmatched []string
+}
+
+// BranchCoverage is the number of the strings that take a branch of InstAlt.
+type BranchCoverage struct {
+	// PC is the program counter of InstAlt in Program.
+	PC uint32
+
+	// Branch is 0 for Out, and 1 for Arg.
+	Branch int
+
+	// Count is the number of the times the branch is taken.
+	Count int
+}
+
+// RangeCoverage is the number of the runes generated from a range of InstRune.
+type RangeCoverage struct {
+	// PC is the program counter of InstRune in Program.
+	// InstRuneAny and InstRuneAnyNotNL are reported as the ranges of InstRune.
+	PC uint32
+
+	// Lo and Hi are the first and the last runes of the range.
+	Lo, Hi rune
+
+	// Count is the number of the runes generated from the range.
+	Count int
+}
+
+// Coverage reports which branches of alternations and ranges of character classes are exercised by the strings,
+// e.g. a fixture corpus, or the strings generated by g.
+// If a string is generated in more than one way, the one that prefers the first branches is counted.
+// The branches that never lead to a match, e.g. pruned by WithNonEmpty, are not reported.
+// It does not take WithDependency, WithGraphemeClusters and the options of post-processing into account.
+func Coverage(g *Generator, outputs []string) CoverageReport {
+	reachable := g.reachable()
+
+	var report CoverageReport
+	branches := make(map[[2]uint32]int) // the index of Branches by pc and branch
+	ranges := make(map[uint32]int)      // the index of the first range of pc in Ranges
+	for pc := range g.inst {
+		if !reachable[pc] {
+			continue
+		}
+		in := &g.inst[pc]
+		switch in.Op {
+		case syntax.InstAlt:
+			for branch, next := range [2]uint32{in.Out, in.Arg} {
+				if reachable[next] {
+					branches[[2]uint32{uint32(pc), uint32(branch)}] = len(report.Branches)
+					report.Branches = append(report.Branches, BranchCoverage{PC: uint32(pc), Branch: branch})
+				}
+			}
+		case syntax.InstRune:
+			ranges[uint32(pc)] = len(report.Ranges)
+			runes := in.runeGenerator.runes
+			if len(runes) == 1 {
+				report.Ranges = append(report.Ranges, RangeCoverage{PC: uint32(pc), Lo: runes[0], Hi: runes[0]})
+				continue
+			}
+			for i := 0; i+1 < len(runes); i += 2 {
+				report.Ranges = append(report.Ranges, RangeCoverage{PC: uint32(pc), Lo: runes[i], Hi: runes[i+1]})
+			}
+		}
+	}
+
+	for _, s := range outputs {
+		steps, ok := g.parse(s)
+		if !ok {
+			report.Unmatched = append(report.Unmatched, s)
+			continue
+		}
+		for _, step := range steps {
+			in := &g.inst[step.pc]
+			if in.Op == syntax.InstAlt {
+				if i, ok := branches[[2]uint32{step.pc, uint32(step.choice)}]; ok {
+					report.Branches[i].Count++
+				}
+				continue
+			}
+			for i := ranges[step.pc]; i < len(report.Ranges) && report.Ranges[i].PC == step.pc; i++ {
+				if r := &report.Ranges[i]; r.Lo <= step.choice && step.choice <= r.Hi {
+					r.Count++
+					break
+				}
+			}
+		}
+	}
+	return report
+}
+
+// BranchPercent returns the percentage of the branches taken at least once.
+// It is 100 if there is no branch.
+func (r *CoverageReport) BranchPercent() float64 {
+	var covered int
+	for _, b := range r.Branches {
+		if b.Count > 0 {
+			covered++
+		}
+	}
+	return percent(covered, len(r.Branches))
+}
+
+// RangePercent returns the percentage of the ranges that generated at least one rune.
+// It is 100 if there is no range.
+func (r *CoverageReport) RangePercent() float64 {
+	var covered int
+	for _, rg := range r.Ranges {
+		if rg.Count > 0 {
+			covered++
+		}
+	}
+	return percent(covered, len(r.Ranges))
+}
+
+func (r *CoverageReport) String() string {
+	return fmt.Sprintf("branches: %.1f%% of %d, ranges: %.1f%% of %d, unmatched: %d",
+		r.BranchPercent(), len(r.Branches), r.RangePercent(), len(r.Ranges), len(r.Unmatched))
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,51 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"testing"
+)
+
+func TestCoverage(t *testing.T) {
+	g := Must(New(`(?:foo|bar)[a-c0-9]`, syntax.Perl, nil))
+
+	report := Coverage(g, []string{"fooa", "foob", "xyz"})
+	if len(report.Branches) != 2 || len(report.Ranges) != 2 {
+		t.Fatalf("want 2 branches and 2 ranges, got %v", report)
+	}
+	if got := report.BranchPercent(); got != 50 {
+		t.Errorf("want 50%% of branches, got %g", got)
+	}
+	if got := report.RangePercent(); got != 50 {
+		t.Errorf("want 50%% of ranges, got %g", got)
+	}
+	if len(report.Unmatched) != 1 || report.Unmatched[0] != "xyz" {
+		t.Errorf("want [xyz], got %q", report.Unmatched)
+	}
+	for _, r := range report.Ranges {
+		if r.Lo == 'a' && r.Count != 2 {
+			t.Errorf("want 2 runes from a-c, got %d", r.Count)
+		}
+	}
+
+	report = Coverage(g, []string{"fooa", "bar0"})
+	if report.BranchPercent() != 100 || report.RangePercent() != 100 {
+		t.Errorf("want full coverage, got %s", report.String())
+	}
+}
+
+func TestCoverageGenerateCovering(t *testing.T) {
+	g := Must(New(`(?:[a-f]|[0-9x]){1,3}-(?:ab|cd)?`, syntax.Perl, nil))
+	report := Coverage(g, g.GenerateCovering(0))
+	if report.BranchPercent() != 100 || report.RangePercent() != 100 || len(report.Unmatched) != 0 {
+		t.Errorf("want full coverage, got %s", report.String())
+	}
+}
+
+func TestCoverageNonEmpty(t *testing.T) {
+	// the pruned branches are not reported.
+	g := Must(New(`a?b?`, syntax.Perl, nil, WithNonEmpty()))
+	report := Coverage(g, []string{"a", "b", "ab"})
+	if report.BranchPercent() != 100 {
+		t.Errorf("want full coverage, got %s", report.String())
+	}
+}
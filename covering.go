@@ -0,0 +1,225 @@
+package rerand
+
+import (
+	"regexp/syntax"
+)
+
+// GenerateCovering generates random strings that collectively take every branch of alternations
+// and generate a rune from every range of character classes at least once.
+// The branches and the ranges are covered one by one, not pairwise,
+// e.g. `(a|b)(c|d)` may be covered by "ac" and "bd" without "ad" nor "bc".
+// It returns at least n strings; random strings are added if the covering strings are fewer than n.
+// The strings are transformed by WithTransform, but not filtered by WithFilter.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateCovering(n int) []string {
+	c := newCoverage(g)
+	var results []string
+	for c.remaining > 0 {
+		results = append(results, g.transform(c.walk()))
+	}
+	for len(results) < n {
+		results = append(results, g.generate(g.rand, &g.mu))
+	}
+	return results
+}
+
+// coverage tracks the branches and the ranges that are not covered yet.
+type coverage struct {
+	g *Generator
+
+	// uncovered[pc] is the set of uncovered branches of InstAlt or ranges of InstRune.
+	uncovered [][]bool
+	remaining int
+
+	// dist[pc] is the distance from pc to the nearest instruction that has uncovered targets.
+	dist []int
+
+	// live[pc] reports whether pc is reachable and can reach InstMatch.
+	live []bool
+}
+
+const infDist = int(^uint(0) >> 1)
+
+func newCoverage(g *Generator) *coverage {
+	live := g.reachable()
+	uncovered := make([][]bool, len(g.inst))
+	remaining := 0
+	for pc := range g.inst {
+		in := &g.inst[pc]
+		if !live[pc] {
+			continue
+		}
+		var n int
+		switch in.Op {
+		case syntax.InstAlt:
+			n = 2
+		case syntax.InstRune:
+			n = (len(in.runeGenerator.runes) + 1) / 2
+		}
+		if n == 0 {
+			continue
+		}
+		uncovered[pc] = make([]bool, n)
+		for i := range uncovered[pc] {
+			uncovered[pc][i] = true
+		}
+		remaining += n
+		if in.Op == syntax.InstAlt {
+			// the dead branches, e.g. pruned by WithNonEmpty, are never taken.
+			for i, next := range []uint32{in.Out, in.Arg} {
+				if !live[next] {
+					uncovered[pc][i] = false
+					remaining--
+				}
+			}
+		}
+	}
+	c := &coverage{
+		g:         g,
+		live:      live,
+		uncovered: uncovered,
+		remaining: remaining,
+		dist:      make([]int, len(g.inst)),
+	}
+	c.update()
+	return c
+}
+
+func (c *coverage) cover(pc uint32, i int) {
+	if c.uncovered[pc][i] {
+		c.uncovered[pc][i] = false
+		c.remaining--
+		c.update()
+	}
+}
+
+func (c *coverage) hasUncovered(pc int) bool {
+	for _, u := range c.uncovered[pc] {
+		if u {
+			return true
+		}
+	}
+	return false
+}
+
+// update updates the distances.
+func (c *coverage) update() {
+	dist := c.dist
+	for pc := range dist {
+		if c.hasUncovered(pc) {
+			dist[pc] = 0
+		} else {
+			dist[pc] = infDist
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for pc := range c.g.inst {
+			in := &c.g.inst[pc]
+			d := dist[pc]
+			switch in.Op {
+			case syntax.InstMatch, syntax.InstFail:
+				continue
+			case syntax.InstAlt:
+				d = min(d, dist[in.Out], dist[in.Arg])
+			default:
+				d = min(d, dist[in.Out])
+			}
+			if d != infDist && d+1 < dist[pc] && dist[pc] != 0 {
+				dist[pc] = d + 1
+				changed = true
+			}
+		}
+	}
+}
+
+// walk generates a string toward the uncovered targets.
+func (c *coverage) walk() string {
+	g := c.g
+	var result []rune
+	pc := uint32(g.prog.Start)
+	for {
+		in := &g.inst[pc]
+		switch in.Op {
+		case syntax.InstMatch:
+			return string(result)
+		case syntax.InstRune:
+			var r rune
+			if i := c.uncoveredIndex(pc); i >= 0 {
+				runes := in.runeGenerator.runes
+				if len(runes) == 1 {
+					r = runes[0]
+				} else {
+					min, max := runes[2*i], runes[2*i+1]
+					g.mu.Lock()
+					r = min + rune(g.rand.Int63n(int64(max-min)+1))
+					g.mu.Unlock()
+				}
+				c.cover(pc, i)
+			} else {
+				r = in.generateRune(g.rand, &g.mu)
+				c.coverRune(pc, r)
+			}
+			result = append(result, r)
+			pc = in.Out
+		case syntax.InstRune1:
+			result = append(result, in.Rune[0])
+			pc = in.Out
+		case syntax.InstAlt:
+			var branch int
+			switch {
+			case !c.live[in.Out]:
+				branch = 1
+			case !c.live[in.Arg]:
+				branch = 0
+			case c.uncovered[pc][0]:
+				branch = 0
+			case c.uncovered[pc][1]:
+				branch = 1
+			case c.dist[in.Out] < c.dist[in.Arg]:
+				branch = 0
+			case c.dist[in.Arg] < c.dist[in.Out]:
+				branch = 1
+			case in.alt(g.rand, &g.mu):
+				branch = 0
+			default:
+				branch = 1
+			}
+			c.cover(pc, branch)
+			if branch == 0 {
+				pc = in.Out
+			} else {
+				pc = in.Arg
+			}
+		case syntax.InstFail:
+			panic("rerand: the covering walk reached InstFail")
+		default:
+			pc = in.Out
+		}
+	}
+}
+
+// uncoveredIndex returns the index of an uncovered range of the InstRune, or -1.
+func (c *coverage) uncoveredIndex(pc uint32) int {
+	for i, u := range c.uncovered[pc] {
+		if u {
+			return i
+		}
+	}
+	return -1
+}
+
+// coverRune covers the range that contains r.
+func (c *coverage) coverRune(pc uint32, r rune) {
+	runes := c.g.inst[pc].runeGenerator.runes
+	if len(runes) == 1 {
+		c.cover(pc, 0)
+		return
+	}
+	for i := 0; i < len(runes); i += 2 {
+		if runes[i] <= r && r <= runes[i+1] {
+			c.cover(pc, i/2)
+			return
+		}
+	}
+}
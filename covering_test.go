@@ -0,0 +1,82 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCovering(t *testing.T) {
+	cases := []struct {
+		pattern string
+		want    []string // substrings that must appear
+	}{
+		{`(admin|user|guest)`, []string{"admin", "user", "guest"}},
+		{`a{1,3}`, []string{"aaa"}},
+		{`(foo|bar)(baz|qux)`, []string{"foo", "bar", "baz", "qux"}},
+	}
+	for _, c := range cases {
+		re := regexp.MustCompile(`^(?:` + c.pattern + `)$`)
+		g := Must(New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+		results := g.GenerateCovering(0)
+		for _, s := range results {
+			if !re.MatchString(s) {
+				t.Errorf("%s: %q does not match", c.pattern, s)
+			}
+		}
+		all := strings.Join(results, "\n")
+		for _, w := range c.want {
+			if !strings.Contains(all, w) {
+				t.Errorf("%s: %q is not covered in %q", c.pattern, w, results)
+			}
+		}
+	}
+}
+
+func TestGenerateCoveringRanges(t *testing.T) {
+	g := Must(New(`[x-z0-2]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	digit, alpha := false, false
+	for _, s := range g.GenerateCovering(0) {
+		if s[0] >= '0' && s[0] <= '2' {
+			digit = true
+		}
+		if s[0] >= 'x' && s[0] <= 'z' {
+			alpha = true
+		}
+	}
+	if !digit || !alpha {
+		t.Error("want both ranges are covered")
+	}
+}
+
+func TestGenerateCoveringPadding(t *testing.T) {
+	g := Must(New(`a|b`, syntax.Perl, nil))
+	if got := len(g.GenerateCovering(10)); got != 10 {
+		t.Errorf("want 10, got %d", got)
+	}
+}
+
+func TestGenerateCoveringInfinite(t *testing.T) {
+	g := Must(New(`(ab|cd|ef)*g`, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(3)))
+	all := strings.Join(g.GenerateCovering(0), "")
+	for _, w := range []string{"ab", "cd", "ef", "g"} {
+		if !strings.Contains(all, w) {
+			t.Errorf("%q is not covered", w)
+		}
+	}
+}
+
+func TestGenerateCoveringNonEmpty(t *testing.T) {
+	g := Must(New(`a?b?`, syntax.Perl, nil, WithNonEmpty()))
+	got := g.GenerateCovering(3)
+	if len(got) < 3 {
+		t.Errorf("want at least 3 strings, got %q", got)
+	}
+	for _, s := range got {
+		if s == "" {
+			t.Errorf("unexpected empty string in %q", got)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package rerand
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// QuoteMode is the quoting policy of CSVWriter.
+type QuoteMode int
+
+const (
+	// QuoteMinimal quotes only fields that contain special characters.
+	QuoteMinimal QuoteMode = iota
+
+	// QuoteAll quotes all fields.
+	QuoteAll
+
+	// QuoteNone never quotes fields.
+	// It is the responsibility of the caller to choose patterns that never generate special characters.
+	QuoteNone
+)
+
+// CSVWriter writes random records generated by Schema in CSV format.
+type CSVWriter struct {
+	Comma      rune      // Field delimiter (set to ',' by NewCSVWriter)
+	Quote      QuoteMode // Quoting policy
+	UseCRLF    bool      // True to use \r\n as the line terminator
+	OmitHeader bool      // True to omit the header row
+
+	schema       *Schema
+	w            *bufio.Writer
+	wroteHeader  bool
+	recordBuffer []string
+}
+
+// NewCSVWriter returns new CSVWriter that writes records generated by s to w.
+func NewCSVWriter(w io.Writer, s *Schema) *CSVWriter {
+	return &CSVWriter{
+		Comma:  ',',
+		schema: s,
+		w:      bufio.NewWriter(w),
+	}
+}
+
+// WriteRecords writes n random records.
+// The header row is written before the first record.
+func (w *CSVWriter) WriteRecords(n int) error {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if !w.OmitHeader {
+			if err := w.writeRecord(w.schema.names); err != nil {
+				return err
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		w.recordBuffer = w.schema.AppendRecord(w.recordBuffer[:0])
+		if err := w.writeRecord(w.recordBuffer); err != nil {
+			return err
+		}
+	}
+	return w.w.Flush()
+}
+
+func (w *CSVWriter) writeRecord(record []string) error {
+	for i, field := range record {
+		if i > 0 {
+			if _, err := w.w.WriteRune(w.Comma); err != nil {
+				return err
+			}
+		}
+		if !w.fieldNeedsQuotes(field) {
+			if _, err := w.w.WriteString(field); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := w.w.WriteByte('"'); err != nil {
+			return err
+		}
+		for len(field) > 0 {
+			i := strings.IndexByte(field, '"')
+			if i < 0 {
+				i = len(field)
+			}
+			if _, err := w.w.WriteString(field[:i]); err != nil {
+				return err
+			}
+			field = field[i:]
+			if len(field) > 0 {
+				if _, err := w.w.WriteString(`""`); err != nil {
+					return err
+				}
+				field = field[1:]
+			}
+		}
+		if err := w.w.WriteByte('"'); err != nil {
+			return err
+		}
+	}
+	var err error
+	if w.UseCRLF {
+		_, err = w.w.WriteString("\r\n")
+	} else {
+		err = w.w.WriteByte('\n')
+	}
+	return err
+}
+
+func (w *CSVWriter) fieldNeedsQuotes(field string) bool {
+	switch w.Quote {
+	case QuoteAll:
+		return true
+	case QuoteNone:
+		return false
+	}
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, w.Comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	return field[0] == ' ' || field[0] == '\t'
+}
@@ -0,0 +1,65 @@
+package rerand
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math/rand"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestCSVWriter(t *testing.T) {
+	fields := map[string]string{
+		"id":   `\d{6}`,
+		"name": `[a-z]{1,3}(,|"| )[a-z]{1,3}`,
+	}
+	s := mustSchema(t, fields)
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, s)
+	if err := w.WriteRecords(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRecords(5); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 16 {
+		t.Fatalf("want 16 records, got %d", len(records))
+	}
+	if want := []string{"id", "name"}; !reflect.DeepEqual(records[0], want) {
+		t.Errorf("want header %v, got %v", want, records[0])
+	}
+}
+
+func TestCSVWriterOptions(t *testing.T) {
+	s := mustSchema(t, map[string]string{"a": `x`, "b": `y`})
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, s)
+	w.Comma = '\t'
+	w.Quote = QuoteAll
+	w.UseCRLF = true
+	w.OmitHeader = true
+	if err := w.WriteRecords(2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), strings.Repeat("\"x\"\t\"y\"\r\n", 2); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func mustSchema(t *testing.T, fields map[string]string) *Schema {
+	t.Helper()
+	s, err := NewSchema(fields, syntax.Perl, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
@@ -0,0 +1,169 @@
+package rerand
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"slices"
+	"sync"
+)
+
+type dependencyOption struct {
+	group  string
+	fn     func(values map[string]string) string
+	pool   map[string]float64 // set by WithGroupValues
+	source GroupSource        // set by WithGroupSource
+}
+
+// dependencies is the groups of WithDependency in the pattern.
+type dependencies struct {
+	names  []string            // the names of the capture groups
+	groups map[int]*dependency // the capture index to the dependency
+}
+
+// dependency generates the group by the pattern that fn returns.
+type dependency struct {
+	names  []string
+	flags  syntax.Flags
+	fn     func(values map[string]string) string
+	cache  sync.Map    // the pattern to *Generator
+	pool   *valuePool  // the values of WithGroupValues, instead of fn
+	source GroupSource // the source of WithGroupSource, instead of fn
+
+	// run generates a string matching the pattern.
+	// It is called indirectly to cut the recursion of Generator.appendRunes,
+	// otherwise the escape analysis moves the buffer of AppendString to the heap.
+	run func(pattern string, r *rand.Rand, mu sync.Locker) string
+}
+
+// newDependencies applies the options of WithDependency.
+// It returns nil if there is no dependency.
+func newDependencies(re *syntax.Regexp, flags syntax.Flags, opts []dependencyOption) (*dependencies, error) {
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	names := re.CapNames()
+	deps := &dependencies{
+		names:  names,
+		groups: map[int]*dependency{},
+	}
+	for _, opt := range opts {
+		cap := slices.Index(names, opt.group)
+		if opt.group == "" || cap < 0 {
+			return nil, fmt.Errorf("rerand: unknown group %q", opt.group)
+		}
+		d := &dependency{
+			names:  names,
+			flags:  flags,
+			fn:     opt.fn,
+			source: opt.source,
+		}
+		if opt.pool != nil {
+			pool, err := newValuePool(opt.group, opt.pool)
+			if err != nil {
+				return nil, err
+			}
+			d.pool = pool
+		}
+		d.run = func(pattern string, r *rand.Rand, mu sync.Locker) string {
+			s, _ := d.generator(pattern).run(context.Background(), r, mu) // it never fails without options
+			return s
+		}
+		deps.groups[cap] = d
+	}
+	return deps, nil
+}
+
+// isNamed reports whether the capture slot belongs to a named group.
+// The slots beyond names are the marker groups of alternations, e.g. of WithUniformAlternation and NewMixture.
+func (deps *dependencies) isNamed(slot uint32) bool {
+	if deps == nil {
+		return false
+	}
+	i := int(slot / 2)
+	return i < len(deps.names) && deps.names[i] != ""
+}
+
+// dependency returns the dependency of the group that the capture slot begins.
+func (deps *dependencies) dependency(slot uint32) *dependency {
+	if deps == nil || slot%2 != 0 {
+		return nil
+	}
+	return deps.groups[int(slot/2)]
+}
+
+// groupEnd returns the instruction that ends the group beginning at pc.
+func groupEnd(inst []myinst, pc uint32) uint32 {
+	end := inst[pc].Arg + 1
+	visited := make([]bool, len(inst))
+	queue := []uint32{inst[pc].Out}
+	for len(queue) > 0 {
+		pc := queue[0]
+		queue = queue[1:]
+		if visited[pc] {
+			continue
+		}
+		visited[pc] = true
+		in := &inst[pc]
+		switch in.Op {
+		case syntax.InstCapture:
+			if in.Arg == end {
+				return pc
+			}
+			queue = append(queue, in.Out)
+		case syntax.InstAlt:
+			queue = append(queue, in.Out, in.Arg)
+		case syntax.InstMatch, syntax.InstFail:
+		default:
+			queue = append(queue, in.Out)
+		}
+	}
+	panic("rerand: the end of the group is not found")
+}
+
+// generate appends the string of the group to result.
+// caps is the positions of the capture groups in result.
+// mu guards r.
+func (d *dependency) generate(result []rune, caps []int, r *rand.Rand, mu sync.Locker) []rune {
+	if d.pool != nil {
+		return append(result, d.pool.choose(r, mu)...)
+	}
+	if d.source != nil {
+		mu.Lock()
+		s := d.source.GenerateGroup(r)
+		mu.Unlock()
+		for _, c := range s {
+			result = append(result, c)
+		}
+		return result
+	}
+	values := make(map[string]string)
+	for i, name := range d.names {
+		if name == "" {
+			continue
+		}
+		begin, end := caps[2*i], caps[2*i+1]
+		if begin >= 0 && begin <= end {
+			values[name] = string(result[begin:end])
+		}
+	}
+	for _, c := range d.run(d.fn(values), r, mu) {
+		result = append(result, c)
+	}
+	return result
+}
+
+// generator returns the generator of the pattern.
+// It panics if the pattern is invalid.
+func (d *dependency) generator(pattern string) *Generator {
+	if g, ok := d.cache.Load(pattern); ok {
+		return g.(*Generator)
+	}
+	g, err := newGenerator(pattern, d.flags, nil, nil)
+	if err != nil {
+		panic(fmt.Errorf("rerand: invalid pattern of the dependency: %w", err))
+	}
+	actual, _ := d.cache.LoadOrStore(pattern, g)
+	return actual.(*Generator)
+}
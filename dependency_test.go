@@ -0,0 +1,87 @@
+package rerand
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithDependency(t *testing.T) {
+	port := func(values map[string]string) string {
+		if values["proto"] == "https" {
+			return `443|8443`
+		}
+		return `80|8080`
+	}
+	g := Must(New(`(?P<proto>https?)://example\.com:(?P<port>\d{1,5})/`, syntax.Perl, nil, WithDependency("port", port)))
+	re := regexp.MustCompile(`\A(?:https://example\.com:(?:443|8443)|http://example\.com:(?:80|8080))/\z`)
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+
+	buf := make([]rune, 0, 64)
+	for i := 0; i < 100; i++ {
+		buf = g.AppendRunes(append(buf[:0], []rune("prefix:")...))
+		if s := string(buf); !strings.HasPrefix(s, "prefix:") || !re.MatchString(strings.TrimPrefix(s, "prefix:")) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+}
+
+func TestWithDependencyChain(t *testing.T) {
+	g := Must(New(`(?P<a>[xy])(?P<b>.)(?P<c>.)`, syntax.Perl, nil,
+		WithDependency("b", func(values map[string]string) string {
+			return regexp.QuoteMeta(values["a"])
+		}),
+		WithDependency("c", func(values map[string]string) string {
+			return regexp.QuoteMeta(strings.ToUpper(values["a"] + values["b"]))
+		}),
+	))
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); s != "xxXX" && s != "yyYY" {
+			t.Errorf("unexpected %q", s)
+		}
+	}
+}
+
+func TestWithDependencyUniformAlternation(t *testing.T) {
+	// the marker groups of WithUniformAlternation are beyond the capture groups of the pattern.
+	g := Must(New(`(?P<a>x|yy)(?P<b>z|w)`, syntax.Perl, nil, WithUniformAlternation(),
+		WithDependency("b", func(values map[string]string) string {
+			return strings.ToUpper(values["a"])
+		})))
+	re := regexp.MustCompile(`\A(?:xX|yyYY)\z`)
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+}
+
+func TestWithDependencyMixture(t *testing.T) {
+	g, err := NewMixture(map[string]float64{`(?P<a>x)(?P<b>z)`: 1, `k`: 1}, syntax.Perl, nil,
+		WithDependency("b", func(values map[string]string) string {
+			return strings.ToUpper(values["a"])
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); s != "xX" && s != "k" {
+			t.Errorf("unexpected %q", s)
+		}
+	}
+}
+
+func TestWithDependencyError(t *testing.T) {
+	fn := func(map[string]string) string { return "" }
+	if _, err := New(`(?P<a>x)`, syntax.Perl, nil, WithDependency("b", fn)); err == nil {
+		t.Error("want error, got nil")
+	}
+	if _, err := New(`(x)`, syntax.Perl, nil, WithDependency("", fn)); err == nil {
+		t.Error("want error, got nil")
+	}
+}
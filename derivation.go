@@ -0,0 +1,186 @@
+package rerand
+
+import (
+	"regexp/syntax"
+)
+
+// step is a choice made while generating a string.
+type step struct {
+	pc uint32
+
+	// choice is 0 (Out) or 1 (Arg) for InstAlt, and the generated rune for InstRune.
+	choice rune
+}
+
+// matchRune reports whether the instruction can generate r.
+func (in *myinst) matchRune(r rune) bool {
+	if in.Op == syntax.InstRune1 {
+		return in.Rune[0] == r
+	}
+	runes := in.runeGenerator.runes
+	if len(runes) == 1 {
+		return runes[0] == r
+	}
+	for i := 0; i < len(runes); i += 2 {
+		if runes[i] <= r && r <= runes[i+1] {
+			return true
+		}
+	}
+	return false
+}
+
+// minRune returns the smallest rune that the instruction can generate.
+func (in *myinst) minRune() rune {
+	if in.Op == syntax.InstRune1 {
+		return in.Rune[0]
+	}
+	runes := in.runeGenerator.runes
+	min := runes[0]
+	for i := 2; i < len(runes); i += 2 {
+		if runes[i] < min {
+			min = runes[i]
+		}
+	}
+	return min
+}
+
+// parse returns the choices that generate s.
+// If there are many ways to generate s, the one that prefers Out of InstAlt is returned.
+// The second return value is false if the generator never generates s.
+func (g *Generator) parse(s string) ([]step, bool) {
+	input := []rune(s)
+	failed := make(map[[2]int]bool)
+	visiting := make(map[[2]int]bool)
+	var steps []step
+	var walk func(pc uint32, pos int) bool
+	walk = func(pc uint32, pos int) bool {
+		key := [2]int{int(pc), pos}
+		if failed[key] || visiting[key] {
+			// visiting means an empty loop
+			return false
+		}
+		visiting[key] = true
+		defer delete(visiting, key)
+		in := &g.inst[pc]
+		ok := false
+		switch in.Op {
+		case syntax.InstMatch:
+			ok = pos == len(input)
+		case syntax.InstRune, syntax.InstRune1:
+			if pos < len(input) && in.matchRune(input[pos]) {
+				if in.Op == syntax.InstRune {
+					steps = append(steps, step{pc: pc, choice: input[pos]})
+				}
+				ok = walk(in.Out, pos+1)
+				if !ok && in.Op == syntax.InstRune {
+					steps = steps[:len(steps)-1]
+				}
+			}
+		case syntax.InstAlt:
+			for choice, next := range [2]uint32{in.Out, in.Arg} {
+				steps = append(steps, step{pc: pc, choice: rune(choice)})
+				if walk(next, pos) {
+					ok = true
+					break
+				}
+				steps = steps[:len(steps)-1]
+			}
+		case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+			ok = walk(in.Out, pos)
+		}
+		if !ok {
+			failed[key] = true
+		}
+		return ok
+	}
+	if !walk(uint32(g.prog.Start), 0) {
+		return nil, false
+	}
+	return steps, true
+}
+
+// replay generates the string from the choices.
+// If the choices run out or do not fit the program, it is completed by the shortest string.
+// The choices used, including the completion, are returned with the string.
+func (g *Generator) replay(choices []step) (string, []step) {
+	minLen := g.minLens()
+	var result []rune
+	var steps []step
+	pc := uint32(g.prog.Start)
+	for {
+		in := &g.inst[pc]
+		if len(choices) > 0 && choices[0].pc != pc && (in.Op == syntax.InstRune || in.Op == syntax.InstAlt) {
+			choices = nil
+		}
+		switch in.Op {
+		case syntax.InstMatch:
+			return string(result), steps
+		case syntax.InstRune1:
+			result = append(result, in.Rune[0])
+			pc = in.Out
+		case syntax.InstRune:
+			var r rune
+			if len(choices) > 0 {
+				r = choices[0].choice
+				choices = choices[1:]
+			} else {
+				r = in.minRune()
+			}
+			result = append(result, r)
+			steps = append(steps, step{pc: pc, choice: r})
+			pc = in.Out
+		case syntax.InstAlt:
+			var choice rune
+			if len(choices) > 0 {
+				choice = choices[0].choice
+				choices = choices[1:]
+			} else if minLen[in.Arg] < minLen[in.Out] {
+				choice = 1
+			}
+			steps = append(steps, step{pc: pc, choice: choice})
+			if choice == 0 {
+				pc = in.Out
+			} else {
+				pc = in.Arg
+			}
+		default:
+			pc = in.Out
+		}
+	}
+}
+
+// minLens returns the length of the shortest string generated from each instruction.
+func (g *Generator) minLens() []int {
+	const inf = int(^uint(0) >> 1)
+	minLen := make([]int, len(g.inst))
+	for i := range minLen {
+		minLen[i] = inf
+	}
+	for changed := true; changed; {
+		changed = false
+		for pc := range g.inst {
+			in := &g.inst[pc]
+			l := inf
+			switch in.Op {
+			case syntax.InstMatch:
+				l = 0
+			case syntax.InstRune, syntax.InstRune1:
+				if minLen[in.Out] != inf {
+					l = minLen[in.Out] + 1
+				}
+			case syntax.InstAlt:
+				l = minLen[in.Out]
+				if minLen[in.Arg] < l {
+					l = minLen[in.Arg]
+				}
+			case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+				l = minLen[in.Out]
+			}
+			if l < minLen[pc] {
+				minLen[pc] = l
+				changed = true
+			}
+		}
+	}
+	return minLen
+}
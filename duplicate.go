@@ -0,0 +1,101 @@
+package rerand
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// maxTopOutputs is the number of the most frequent outputs in Stats.
+const maxTopOutputs = 10
+
+// Stats is the duplication of the strings generated by a generator, returned by DuplicateStats.
+type Stats struct {
+	// N is the number of the generated strings.
+	N int
+
+	// Distinct is the number of the distinct strings.
+	Distinct int
+
+	// DuplicateRate is the ratio of the strings that duplicate an earlier one, (N-Distinct)/N.
+	DuplicateRate float64
+
+	// CollisionProbability is the estimated probability that two generated strings are equal.
+	// It is estimated from the pairs of equal strings if any,
+	// otherwise from EntropyBits, which underestimates it if the strings are not uniformly distributed.
+	// It is zero if N is less than 2.
+	CollisionProbability float64
+
+	// BirthdayBound is the estimated number of the generations at which a duplicate becomes more likely than not,
+	// about 1.1774/sqrt(CollisionProbability). It is +Inf if no duplicate is possible.
+	// It is zero if N is less than 2.
+	BirthdayBound float64
+
+	// Top is the most frequent strings, at most 10, in the order of decreasing count.
+	// The strings generated only once are omitted, unless there are at most 10 distinct strings.
+	Top []OutputCount
+}
+
+// OutputCount is the number of the times a string is generated.
+type OutputCount struct {
+	Output string
+	Count  int
+}
+
+// DuplicateStats generates n strings by g, and reports how often they collide,
+// e.g. to check that a pattern is unique enough for IDs.
+// It panics if the generation fails, in the same way as Generate, or if n is negative.
+func DuplicateStats(g *Generator, n int) Stats {
+	if n < 0 {
+		panic("rerand: DuplicateStats with negative n")
+	}
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		counts[g.Generate()]++
+	}
+
+	s := Stats{
+		N:        n,
+		Distinct: len(counts),
+	}
+	if n > 0 {
+		s.DuplicateRate = float64(n-len(counts)) / float64(n)
+	}
+
+	// the collisions need at least a pair of the strings.
+	if n >= 2 {
+		// the unbiased estimator of the sum of the squared probabilities.
+		var pairs float64
+		for _, c := range counts {
+			pairs += float64(c) * float64(c-1)
+		}
+		if pairs > 0 {
+			s.CollisionProbability = pairs / (float64(n) * float64(n-1))
+		} else {
+			s.CollisionProbability = math.Exp2(-g.EntropyBits())
+		}
+		s.BirthdayBound = math.Sqrt(2*math.Ln2) / math.Sqrt(s.CollisionProbability)
+	}
+
+	for output, count := range counts {
+		if count > 1 || len(counts) <= maxTopOutputs {
+			s.Top = append(s.Top, OutputCount{Output: output, Count: count})
+		}
+	}
+	slices.SortFunc(s.Top, func(a, b OutputCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Output, b.Output)
+	})
+	if len(s.Top) > maxTopOutputs {
+		s.Top = s.Top[:maxTopOutputs]
+	}
+	return s
+}
+
+func (s *Stats) String() string {
+	return fmt.Sprintf("n: %d, distinct: %d, duplicate rate: %.4g, collision probability: %.4g, birthday bound: %.4g",
+		s.N, s.Distinct, s.DuplicateRate, s.CollisionProbability, s.BirthdayBound)
+}
@@ -0,0 +1,77 @@
+package rerand
+
+import (
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestDuplicateStats(t *testing.T) {
+	g := Must(New(`[0-9]{2}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	s := DuplicateStats(g, 1000)
+	if s.N != 1000 || s.Distinct > 100 || s.Distinct < 90 {
+		t.Errorf("unexpected stats: %s", &s)
+	}
+	if want := float64(1000-s.Distinct) / 1000; s.DuplicateRate != want {
+		t.Errorf("want duplicate rate %f, got %f", want, s.DuplicateRate)
+	}
+	if math.Abs(s.CollisionProbability-0.01) > 0.002 {
+		t.Errorf("want collision probability about 0.01, got %f", s.CollisionProbability)
+	}
+	// sqrt(2 ln 2 * 100) ≈ 11.77
+	if math.Abs(s.BirthdayBound-11.77) > 1.5 {
+		t.Errorf("want birthday bound about 11.77, got %f", s.BirthdayBound)
+	}
+	if len(s.Top) != maxTopOutputs {
+		t.Fatalf("want %d top outputs, got %d", maxTopOutputs, len(s.Top))
+	}
+	for i := 1; i < len(s.Top); i++ {
+		if s.Top[i-1].Count < s.Top[i].Count {
+			t.Errorf("not sorted: %v", s.Top)
+		}
+	}
+}
+
+func TestDuplicateStatsUnique(t *testing.T) {
+	// no duplicates are observed, so the collision probability is estimated from the entropy.
+	g := Must(New(`[0-9a-f]{32}`, syntax.Perl, nil))
+	s := DuplicateStats(g, 100)
+	if s.Distinct != 100 || s.DuplicateRate != 0 || len(s.Top) != 0 {
+		t.Errorf("unexpected stats: %s", &s)
+	}
+	if want := math.Exp2(-128); math.Abs(s.CollisionProbability-want) > want*1e-9 {
+		t.Errorf("want collision probability %g, got %g", want, s.CollisionProbability)
+	}
+	if want := math.Sqrt(2*math.Ln2) * math.Exp2(64); math.Abs(s.BirthdayBound-want) > want*1e-9 {
+		t.Errorf("want birthday bound %g, got %g", want, s.BirthdayBound)
+	}
+}
+
+func TestDuplicateStatsConstant(t *testing.T) {
+	g := Must(New(`abc`, syntax.Perl, nil))
+	s := DuplicateStats(g, 10)
+	if s.Distinct != 1 || s.DuplicateRate != 0.9 || s.CollisionProbability != 1 {
+		t.Errorf("unexpected stats: %s", &s)
+	}
+	if len(s.Top) != 1 || s.Top[0] != (OutputCount{Output: "abc", Count: 10}) {
+		t.Errorf("unexpected top: %v", s.Top)
+	}
+}
+
+func TestDuplicateStatsFew(t *testing.T) {
+	g := Must(New(`abc`, syntax.Perl, nil))
+	for _, n := range []int{0, 1} {
+		s := DuplicateStats(g, n)
+		if s.N != n || s.CollisionProbability != 0 || s.BirthdayBound != 0 {
+			t.Errorf("%d: unexpected stats: %s", n, &s)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic, got nil")
+		}
+	}()
+	DuplicateStats(g, -1)
+}
@@ -0,0 +1,67 @@
+package rerand
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultFiller is the default alphabet of the text around the match of GenerateEmbedded.
+const DefaultFiller = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 "
+
+// WithFiller sets the alphabet of the text around the match of GenerateEmbedded.
+// If it is not given, DefaultFiller is used.
+func WithFiller(alphabet string) Option {
+	return func(o *options) {
+		o.filler = []rune(alphabet)
+	}
+}
+
+// GenerateEmbedded generates a string of totalLen runes that contains a match of the pattern at a random position.
+// The rest of the string is filled with the runes of WithFiller.
+// It panics if the generation fails, e.g. the match is longer than totalLen.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateEmbedded(totalLen int) string {
+	s, err := g.TryGenerateEmbedded(totalLen)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// TryGenerateEmbedded is same as GenerateEmbedded, but returns an error instead of panicking.
+// If no match fits in totalLen runes after DefaultMaxAttempts attempts, it returns *TooLongError.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) TryGenerateEmbedded(totalLen int) (string, error) {
+	if totalLen < 0 {
+		return "", errors.New("rerand: negative total length")
+	}
+	for attempt := 0; attempt < DefaultMaxAttempts; attempt++ {
+		s, err := g.TryGenerate()
+		if err != nil {
+			return "", err
+		}
+		match := []rune(s)
+		if len(match) > totalLen {
+			continue
+		}
+
+		filler := g.opts.filler
+		if filler == nil {
+			filler = []rune(DefaultFiller)
+		}
+		padding := make([]rune, totalLen-len(match))
+		g.mu.Lock()
+		pos := g.rand.Intn(len(padding) + 1)
+		for i := range padding {
+			padding[i] = filler[g.rand.Intn(len(filler))]
+		}
+		g.mu.Unlock()
+
+		var buf strings.Builder
+		buf.WriteString(string(padding[:pos]))
+		buf.WriteString(s)
+		buf.WriteString(string(padding[pos:]))
+		return buf.String(), nil
+	}
+	return "", &TooLongError{MaxLength: totalLen}
+}
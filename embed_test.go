@@ -0,0 +1,64 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateEmbedded(t *testing.T) {
+	g := Must(New(`[0-9]{4}`, syntax.Perl, rand.New(rand.NewSource(1)), WithFiller("xy")))
+	re := regexp.MustCompile(`^[xy]*[0-9]{4}[xy]*$`)
+	positions := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		s := g.GenerateEmbedded(10)
+		if utf8.RuneCountInString(s) != 10 {
+			t.Fatalf("want length 10, got %q", s)
+		}
+		if !re.MatchString(s) {
+			t.Fatalf("%q does not contain the match", s)
+		}
+		positions[strings.IndexAny(s, "0123456789")] = true
+	}
+	if len(positions) != 7 {
+		t.Errorf("want the match at 7 positions, got %v", positions)
+	}
+}
+
+func TestGenerateEmbeddedDefaultFiller(t *testing.T) {
+	g := Must(New(`あ`, syntax.Perl, nil))
+	s := g.GenerateEmbedded(5)
+	if utf8.RuneCountInString(s) != 5 || !strings.Contains(s, "あ") {
+		t.Errorf("unexpected %q", s)
+	}
+	filler := strings.Replace(s, "あ", "", 1)
+	if strings.Trim(filler, DefaultFiller) != "" {
+		t.Errorf("unexpected filler %q", filler)
+	}
+}
+
+func TestGenerateEmbeddedTooLong(t *testing.T) {
+	g := Must(New(`[a-z]{8}`, syntax.Perl, nil))
+	_, err := g.TryGenerateEmbedded(4)
+	var e *TooLongError
+	if !errors.As(err, &e) {
+		t.Fatalf("want *TooLongError, got %v", err)
+	}
+	if e.MaxLength != 4 {
+		t.Errorf("want 4, got %d", e.MaxLength)
+	}
+
+	if _, err := g.TryGenerateEmbedded(-1); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestWithFillerEmpty(t *testing.T) {
+	if _, err := New(`a`, syntax.Perl, nil, WithFiller("")); err == nil {
+		t.Error("want error, got nil")
+	}
+}
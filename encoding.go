@@ -0,0 +1,82 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding"
+)
+
+// ErrUnencodable is returned if the generated string has runes that are not supported by the encoding of WithEncoding.
+var ErrUnencodable = errors.New("rerand: the rune is not supported by the encoding")
+
+// UnencodablePolicy is the policy for the runes that are not supported by the encoding of WithEncoding.
+type UnencodablePolicy int
+
+const (
+	// UnencodableError fails the generation with ErrUnencodable.
+	UnencodableError UnencodablePolicy = iota
+
+	// UnencodableSkip removes the unsupported runes.
+	UnencodableSkip
+
+	// UnencodableReplace replaces the unsupported runes with the replacement of the encoding, e.g. '\x1a'.
+	UnencodableReplace
+)
+
+// WithEncoding makes GenerateBytes encode generated strings into enc, e.g. japanese.ShiftJIS.
+// The runes that are not supported by enc are handled by policy.
+// Generate and the other methods that return strings are not affected.
+func WithEncoding(enc encoding.Encoding, policy UnencodablePolicy) Option {
+	return func(o *options) {
+		o.encoding = enc
+		o.unencodable = policy
+	}
+}
+
+// GenerateBytes generates a random string, and returns it encoded by the encoding of WithEncoding.
+// Without WithEncoding, it returns the string in UTF-8.
+// It panics if the generation fails, e.g. the string has a rune that is not supported by the encoding.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateBytes() []byte {
+	b, err := g.TryGenerateBytes()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TryGenerateBytes generates a random string, and returns it encoded by the encoding of WithEncoding.
+// Without WithEncoding, it returns the string in UTF-8.
+// It returns an error if the generation fails, e.g. the string has a rune that is not supported by the encoding.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) TryGenerateBytes() ([]byte, error) {
+	s, err := g.TryGenerate()
+	if err != nil {
+		return nil, err
+	}
+	enc := g.opts.encoding
+	if enc == nil {
+		return []byte(s), nil
+	}
+
+	switch g.opts.unencodable {
+	case UnencodableSkip:
+		s = strings.Map(func(r rune) rune {
+			if _, err := enc.NewEncoder().String(string(r)); err != nil {
+				return -1
+			}
+			return r
+		}, s)
+		return enc.NewEncoder().Bytes([]byte(s))
+	case UnencodableReplace:
+		return encoding.ReplaceUnsupported(enc.NewEncoder()).Bytes([]byte(s))
+	default:
+		b, err := enc.NewEncoder().Bytes([]byte(s))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrUnencodable, s)
+		}
+		return b, nil
+	}
+}
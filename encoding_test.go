@@ -0,0 +1,42 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestWithEncoding(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opt     Option
+		want    string
+	}{
+		{`あいう`, WithEncoding(japanese.ShiftJIS, UnencodableError), "\x82\xa0\x82\xa2\x82\xa4"},
+		{`café`, WithEncoding(charmap.ISO8859_1, UnencodableError), "caf\xe9"},
+		{`aあ`, WithEncoding(unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), UnencodableError), "\x00a\x30\x42"},
+		{`aあb`, WithEncoding(charmap.ISO8859_1, UnencodableSkip), "ab"},
+		{`aあb`, WithEncoding(charmap.ISO8859_1, UnencodableReplace), "a\x1ab"},
+		{`aあb`, WithVerify(), "a\xe3\x81\x82b"},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, nil, tt.opt))
+		if got := string(g.GenerateBytes()); got != tt.want {
+			t.Errorf("%s: want %q, got %q", tt.pattern, tt.want, got)
+		}
+	}
+}
+
+func TestWithEncodingError(t *testing.T) {
+	g := Must(New(`aあb`, syntax.Perl, nil, WithEncoding(charmap.ISO8859_1, UnencodableError)))
+	if _, err := g.TryGenerateBytes(); !errors.Is(err, ErrUnencodable) {
+		t.Errorf("want ErrUnencodable, got %v", err)
+	}
+	if _, err := New(`a`, syntax.Perl, nil, WithEncoding(charmap.ISO8859_1, UnencodablePolicy(100))); err == nil {
+		t.Error("want error, got nil")
+	}
+}
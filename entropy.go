@@ -0,0 +1,66 @@
+package rerand
+
+import (
+	"math"
+	"math/big"
+	"regexp/syntax"
+)
+
+// EntropyBits returns the Shannon entropy in bits of the strings that g generates.
+// With NewDistinctRunes, it equals log2 of the number of distinct strings.
+//
+// It is the entropy of the choices that g makes while generating a string.
+// If the pattern is ambiguous, e.g. `a|a`, different choices generate the same string,
+// and the entropy of strings is less than the returned value.
+// The transforms and the filters are not taken into account.
+func (g *Generator) EntropyBits() float64 {
+	const maxIteration = 100000
+	const epsilon = 1e-12
+	h := make([]float64, len(g.inst))
+	for iter := 0; iter < maxIteration; iter++ {
+		converged := true
+		for pc := len(g.inst) - 1; pc >= 0; pc-- {
+			in := &g.inst[pc]
+			var v float64
+			switch in.Op {
+			case syntax.InstRune:
+				v = math.Log2(float64(in.runeCount())) + h[in.Out]
+			case syntax.InstAlt:
+				p := in.probability()
+				v = binaryEntropy(p)
+				if p > 0 {
+					v += p * h[in.Out]
+				}
+				if p < 1 {
+					v += (1 - p) * h[in.Arg]
+				}
+			case syntax.InstRune1, syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+				v = h[in.Out]
+			}
+			if math.Abs(v-h[pc]) > epsilon*v {
+				converged = false
+			}
+			h[pc] = v
+		}
+		if converged {
+			break
+		}
+	}
+	return h[g.prog.Start]
+}
+
+// probability returns the probability that InstAlt takes Out.
+func (in *myinst) probability() float64 {
+	if in.y > 0 {
+		return float64(in.x) / float64(in.y)
+	}
+	p, _ := new(big.Rat).SetFrac(in.bigX, in.bigY).Float64()
+	return p
+}
+
+func binaryEntropy(p float64) float64 {
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
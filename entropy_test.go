@@ -0,0 +1,28 @@
+package rerand
+
+import (
+	"math"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestEntropyBits(t *testing.T) {
+	cases := []struct {
+		g    *Generator
+		want float64
+	}{
+		{Must(New(`abc`, syntax.Perl, nil)), 0},
+		{Must(New(`[0-9a-f]{32}`, syntax.Perl, nil)), 128},
+		{Must(New(`a|b|c|d`, syntax.Perl, nil)), 2},
+		{Must(NewDistinctRunes(`[ab]{1,3}`, syntax.Perl, nil)), math.Log2(2 + 4 + 8)},
+		{Must(NewDistinctRunes(`abc|def|ghi`, syntax.Perl, nil)), math.Log2(3)},
+		{Must(NewWithProbability(`(a|b)`, syntax.Perl, nil, math.MaxInt64/2)), 1},
+		// geometric distribution with p = 1/2
+		{Must(New(`a*`, syntax.Perl, nil, WithTargetLength(1))), 2},
+	}
+	for _, c := range cases {
+		if got := c.g.EntropyBits(); math.Abs(got-c.want) > 1e-3 {
+			t.Errorf("%s: want %f, got %f", c.g, c.want, got)
+		}
+	}
+}
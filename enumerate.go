@@ -0,0 +1,63 @@
+package rerand
+
+import (
+	"iter"
+	"math/big"
+	"regexp/syntax"
+)
+
+// Enumerate yields the strings that g generates, in the order of length, and then the order of branches and runes.
+// For infinite languages, it never ends.
+// A string may be yielded more than once if the pattern is ambiguous, e.g. `(?:ab|a)b?`.
+// Like GenerateTrace, it does not take WithDependency, WithGraphemeClusters and the options of post-processing into account.
+func (g *Generator) Enumerate() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		maxLen, finite := g.MaxLen()
+		var runes []rune
+		var visit func(pc uint32, counts [][]*big.Int, l int) bool
+		visit = func(pc uint32, counts [][]*big.Int, l int) bool {
+			if counts[l][pc].Sign() == 0 {
+				return true
+			}
+			in := &g.inst[pc]
+			switch in.Op {
+			case syntax.InstMatch:
+				return yield(string(runes))
+			case syntax.InstRune1:
+				runes = append(runes, in.Rune[0])
+				ok := visit(in.Out, counts, l-1)
+				runes = runes[:len(runes)-1]
+				return ok
+			case syntax.InstRune:
+				rs := in.runeGenerator.runes
+				if len(rs) == 1 {
+					rs = []rune{rs[0], rs[0]}
+				}
+				for i := 0; i+1 < len(rs); i += 2 {
+					for r := rs[i]; r <= rs[i+1]; r++ {
+						runes = append(runes, r)
+						ok := visit(in.Out, counts, l-1)
+						runes = runes[:len(runes)-1]
+						if !ok {
+							return false
+						}
+					}
+				}
+				return true
+			case syntax.InstAlt:
+				return visit(in.Out, counts, l) && visit(in.Arg, counts, l)
+			default:
+				return visit(in.Out, counts, l)
+			}
+		}
+		for l := g.MinLen(); !finite || l <= maxLen; l++ {
+			counts, err := g.countsLen(l)
+			if err != nil {
+				return
+			}
+			if !visit(uint32(g.prog.Start), counts, l) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"slices"
+	"testing"
+)
+
+func TestEnumerate(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{`abc`, []string{"abc"}},
+		{`[a-c]`, []string{"a", "b", "c"}},
+		{`x(ab|c)?`, []string{"x", "xc", "xab"}},
+		{`[ab]{1,2}`, []string{"a", "b", "aa", "ab", "ba", "bb"}},
+		{`(?:ab|a)b?`, []string{"a", "ab", "ab", "abb"}},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, nil))
+		got := slices.Collect(g.Enumerate())
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: want %q, got %q", tt.pattern, tt.want, got)
+		}
+	}
+}
+
+func TestEnumerateInfinite(t *testing.T) {
+	g := Must(New(`(ab)*`, syntax.Perl, nil, WithTargetLength(2)))
+	var got []string
+	for s := range g.Enumerate() {
+		got = append(got, s)
+		if len(got) == 4 {
+			break
+		}
+	}
+	want := []string{"", "ab", "abab", "ababab"}
+	if !slices.Equal(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,147 @@
+package rerand
+
+import (
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCursor is returned by EnumeratePage if the cursor is not the one that EnumeratePage returned.
+var ErrInvalidCursor = errors.New("rerand: invalid cursor")
+
+// maxPageLength is the length of the strings where EnumeratePage ends the infinite languages without WithMaxLength.
+const maxPageLength = 1 << 12
+
+// EnumeratePage returns the page of at most pageSize strings of the enumeration that begins at cursor,
+// and the cursor of the next page.
+// The empty cursor is the beginning of the enumeration, and the next cursor is empty after the last page.
+// The strings are in the same order as Enumerate, so paging through all the pages yields the same strings as Enumerate,
+// and a cursor is valid as long as the pattern and the options are the same.
+// The strings of the page are found by their ranks, without enumerating the strings before the cursor.
+// For infinite languages, the enumeration ends at the strings of the maximum length of WithMaxLength,
+// or of 4096 runes without it.
+// Like Enumerate, it does not take WithDependency, WithGraphemeClusters and the options of post-processing into account.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) EnumeratePage(cursor string, pageSize int) (page []string, nextCursor string, err error) {
+	if pageSize <= 0 {
+		return nil, "", errors.New("rerand: non-positive page size")
+	}
+	l, i, err := parseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	maxLen, finite := g.MaxLen()
+	if !finite {
+		maxLen = maxPageLength
+		if g.opts.maxLength > 0 {
+			maxLen = g.opts.maxLength
+		}
+	}
+	if l > maxLen {
+		// the cursor is never returned, and building the counts up to it may take too long.
+		return nil, "", ErrInvalidCursor
+	}
+
+	// rows[l] is the counts of the strings of length l.
+	var rows [][]*big.Int
+	for len(rows) <= l {
+		var prev []*big.Int
+		if len(rows) > 0 {
+			prev = rows[len(rows)-1]
+		}
+		row, err := g.distinctCountsLen(prev, len(rows))
+		if err != nil {
+			return nil, "", err
+		}
+		rows = append(rows, row)
+	}
+
+	page = make([]string, 0, pageSize)
+	one := big.NewInt(1)
+	for {
+		if l > maxLen {
+			return page, "", nil
+		}
+		if i.Cmp(rows[l][g.prog.Start]) >= 0 {
+			// the strings of length l are exhausted.
+			row, err := g.distinctCountsLen(rows[l], l+1)
+			if err != nil {
+				return nil, "", err
+			}
+			rows = append(rows, row)
+			l++
+			i.SetInt64(0)
+			continue
+		}
+		if len(page) == pageSize {
+			return page, formatCursor(l, i), nil
+		}
+		page = append(page, g.unrankLen(rows, l, i))
+		i.Add(i, one)
+	}
+}
+
+// unrankLen returns the string of length l of the rank i, in the order of Enumerate.
+func (g *Generator) unrankLen(rows [][]*big.Int, l int, i *big.Int) string {
+	result := make([]rune, 0, l)
+	i = new(big.Int).Set(i)
+	var q big.Int
+	pc := uint32(g.prog.Start)
+	for {
+		in := &g.inst[pc]
+		switch in.Op {
+		case syntax.InstMatch:
+			return string(result)
+		case syntax.InstRune, syntax.InstRune1:
+			q.DivMod(i, rows[l-1][in.Out], i)
+			if in.Op == syntax.InstRune1 {
+				result = append(result, in.Rune[0])
+			} else {
+				result = append(result, in.nthRune(q.Int64()))
+			}
+			l--
+			pc = in.Out
+		case syntax.InstAlt:
+			if out := rows[l][in.Out]; i.Cmp(out) < 0 {
+				pc = in.Out
+			} else {
+				i.Sub(i, out)
+				pc = in.Arg
+			}
+		default:
+			pc = in.Out
+		}
+	}
+}
+
+// formatCursor returns the cursor of the string of length l of the rank i.
+func formatCursor(l int, i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(l) + ":" + i.String()))
+}
+
+// parseCursor parses the cursor of formatCursor.
+func parseCursor(cursor string) (int, *big.Int, error) {
+	if cursor == "" {
+		return 0, new(big.Int), nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, nil, ErrInvalidCursor
+	}
+	ls, is, ok := strings.Cut(string(data), ":")
+	if !ok {
+		return 0, nil, ErrInvalidCursor
+	}
+	l, err := strconv.Atoi(ls)
+	if err != nil || l < 0 {
+		return 0, nil, ErrInvalidCursor
+	}
+	i, ok := new(big.Int).SetString(is, 10)
+	if !ok || i.Sign() < 0 {
+		return 0, nil, ErrInvalidCursor
+	}
+	return l, i, nil
+}
@@ -0,0 +1,119 @@
+package rerand
+
+import (
+	"errors"
+	"math/big"
+	"regexp/syntax"
+	"slices"
+	"testing"
+)
+
+func TestEnumeratePage(t *testing.T) {
+	patterns := []string{
+		`abc`,
+		`x(ab|c)?`,
+		`[ab]{1,3}`,
+		`(?:ab|a)b?`,
+		`[0-9]{2}-[a-c]`,
+	}
+	for _, pattern := range patterns {
+		g := Must(New(pattern, syntax.Perl, nil))
+		want := slices.Collect(g.Enumerate())
+		for _, size := range []int{1, 2, 3, 7, 1000} {
+			var got []string
+			var cursor string
+			for {
+				page, next, err := g.EnumeratePage(cursor, size)
+				if err != nil {
+					t.Fatalf("%s: %v", pattern, err)
+				}
+				if len(page) > size {
+					t.Fatalf("%s: want at most %d strings, got %d", pattern, size, len(page))
+				}
+				got = append(got, page...)
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("%s, %d: want %q, got %q", pattern, size, want, got)
+			}
+		}
+	}
+}
+
+func TestEnumeratePageInfinite(t *testing.T) {
+	g := Must(New(`(ab)*`, syntax.Perl, nil, WithTargetLength(2)))
+	page, next, err := g.EnumeratePage("", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"", "ab"}; !slices.Equal(page, want) {
+		t.Errorf("want %q, got %q", want, page)
+	}
+	page, _, err = g.EnumeratePage(next, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"abab", "ababab"}; !slices.Equal(page, want) {
+		t.Errorf("want %q, got %q", want, page)
+	}
+}
+
+func TestEnumeratePageLarge(t *testing.T) {
+	// the cursor skips the strings before it.
+	g := Must(New(`[a-z]{20}`, syntax.Perl, nil))
+	cursor := formatCursor(20, mustBigInt("1000000000000000000000"))
+	page, _, err := g.EnumeratePage(cursor, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 2 || len(page[0]) != 20 || page[0] >= page[1] {
+		t.Errorf("unexpected %q", page)
+	}
+}
+
+func TestEnumeratePageError(t *testing.T) {
+	g := Must(New(`[a-z]`, syntax.Perl, nil))
+	for _, cursor := range []string{"!", "YWJj", formatCursor(-1, mustBigInt("0")), formatCursor(1, mustBigInt("-1"))} {
+		if _, _, err := g.EnumeratePage(cursor, 1); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%q: want ErrInvalidCursor, got %v", cursor, err)
+		}
+	}
+	if _, _, err := g.EnumeratePage("", 0); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestEnumeratePageForgedCursor(t *testing.T) {
+	// the cursors beyond the maximum length are rejected before counting the strings up to them.
+	cursor := formatCursor(20000000, mustBigInt("0"))
+	for _, g := range []*Generator{
+		Must(New(`a`, syntax.Perl, nil)),
+		Must(New(`a*`, syntax.Perl, nil, WithTargetLength(2))),
+		Must(New(`a*`, syntax.Perl, nil, WithTargetLength(2), WithMaxLength(10, MaxLengthError))),
+	} {
+		if _, _, err := g.EnumeratePage(cursor, 1); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: want ErrInvalidCursor, got %v", g, err)
+		}
+	}
+
+	// the enumeration of the infinite language ends at WithMaxLength.
+	g := Must(New(`a*`, syntax.Perl, nil, WithTargetLength(2), WithMaxLength(2, MaxLengthError)))
+	page, next, err := g.EnumeratePage("", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"", "a", "aa"}; !slices.Equal(page, want) || next != "" {
+		t.Errorf("want %q and the empty cursor, got %q and %q", want, page, next)
+	}
+}
+
+func mustBigInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid number: " + s)
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package rerand
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+)
+
+// Expand replaces the placeholders ${pattern} in tmpl with random strings that match the patterns in the Perl syntax,
+// e.g. Expand("order-${[0-9]{8}}-${[a-f0-9]{4}}", r).
+// The braces in the pattern must be balanced, or escaped by a backslash.
+// "$${" is replaced with literal "${".
+// If r is nil, the random number generator seeded with current time is used.
+func Expand(tmpl string, r *rand.Rand) (string, error) {
+	var buf strings.Builder
+	for {
+		i := strings.Index(tmpl, "${")
+		if i < 0 {
+			buf.WriteString(tmpl)
+			return buf.String(), nil
+		}
+		if i > 0 && tmpl[i-1] == '$' {
+			// escaped
+			buf.WriteString(tmpl[:i])
+			buf.WriteByte('{')
+			tmpl = tmpl[i+2:]
+			continue
+		}
+		buf.WriteString(tmpl[:i])
+		tmpl = tmpl[i+2:]
+
+		end := placeholderEnd(tmpl)
+		if end < 0 {
+			return "", fmt.Errorf("rerand: unterminated placeholder ${%s", tmpl)
+		}
+		g, err := New(tmpl[:end], syntax.Perl, r)
+		if err != nil {
+			return "", err
+		}
+		s, err := g.TryGenerate()
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(s)
+		tmpl = tmpl[end+1:]
+	}
+}
+
+// placeholderEnd returns the index of the brace that closes the placeholder, or -1 if not found.
+func placeholderEnd(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++ // skip the escaped character
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
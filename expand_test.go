@@ -0,0 +1,42 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{"order-${[0-9]{8}}-${[a-f0-9]{4}}", `order-[0-9]{8}-[a-f0-9]{4}`},
+		{"no placeholder", `no placeholder`},
+		{"${a\\}b}", `a\}b`},
+		{"$${literal}", `\$\{literal\}`},
+		{"$${[a-z]}-${x{2}}", `\$\{\[a-z\]\}-xx`},
+		{"", ``},
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, tt := range tests {
+		re := regexp.MustCompile(`\A(?:` + tt.want + `)\z`)
+		for i := 0; i < 10; i++ {
+			got, err := Expand(tt.tmpl, r)
+			if err != nil {
+				t.Fatalf("%q: %v", tt.tmpl, err)
+			}
+			if !re.MatchString(got) {
+				t.Errorf("%q: %q does not match %s", tt.tmpl, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestExpandError(t *testing.T) {
+	for _, tmpl := range []string{"${[a-z]{3}", "${[a-z}", "${(}"} {
+		if _, err := Expand(tmpl, nil); err == nil {
+			t.Errorf("%q: want error, got nil", tmpl)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fillCache caches Generators compiled from struct tags.
+var fillCache sync.Map // map[string]*Generator
+
+// Fill populates exported fields of the struct that v points to.
+//
+// String fields are generated from the pattern of `rerand` tag.
+// Slice fields are populated with the number of elements in `rerandcount` tag,
+// which is "n" or "min,max".
+// Nested structs, pointers to structs and slices of structs are populated recursively.
+// The pointers to the structs being populated, e.g. the next node of a linked list, are left as they are.
+//
+//	type User struct {
+//		ID     string   `rerand:"\\d{8}"`
+//		Emails []string `rerand:"[a-z]{4,8}@example\\.com" rerandcount:"1,3"`
+//	}
+//
+// The patterns are parsed with syntax.Perl.
+// If r is nil, the random number generator seeded with current time is used.
+func Fill(v interface{}, r *rand.Rand) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("rerand: Fill requires non-nil pointer to struct")
+	}
+	if r == nil {
+		r = rand.New(newPCGSource(time.Now().UnixNano()))
+	}
+	return fillStruct(rv.Elem(), r, map[reflect.Type]bool{})
+}
+
+// fillStruct populates the fields of v.
+// filling is the types of the structs being populated, to stop at the recursive types.
+func fillStruct(v reflect.Value, r *rand.Rand, filling map[reflect.Type]bool) error {
+	t := v.Type()
+	filling[t] = true
+	defer delete(filling, t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		pattern, hasPattern := f.Tag.Lookup("rerand")
+		if pattern == "-" {
+			continue
+		}
+		count, hasCount := f.Tag.Lookup("rerandcount")
+		if err := fillValue(v.Field(i), pattern, hasPattern, count, hasCount, r, filling); err != nil {
+			return fmt.Errorf("rerand: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func fillValue(v reflect.Value, pattern string, hasPattern bool, count string, hasCount bool, r *rand.Rand, filling map[reflect.Type]bool) error {
+	switch v.Kind() {
+	case reflect.String:
+		if !hasPattern {
+			return nil
+		}
+		g, err := fillGenerator(pattern)
+		if err != nil {
+			return err
+		}
+		v.SetString(g.generate(r, nopLocker{}))
+	case reflect.Struct:
+		return fillStruct(v, r, filling)
+	case reflect.Ptr:
+		elem := v.Type().Elem()
+		if (elem.Kind() != reflect.Struct && !hasPattern) || filling[elem] {
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(elem))
+		}
+		return fillValue(v.Elem(), pattern, hasPattern, count, hasCount, r, filling)
+	case reflect.Slice:
+		if !hasCount {
+			return nil
+		}
+		n, err := parseCount(count, r)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if err := fillValue(s.Index(i), pattern, hasPattern, "", false, r, filling); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	}
+	return nil
+}
+
+func fillGenerator(pattern string) (*Generator, error) {
+	if g, ok := fillCache.Load(pattern); ok {
+		return g.(*Generator), nil
+	}
+	g, err := New(pattern, syntax.Perl, nil)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := fillCache.LoadOrStore(pattern, g)
+	return actual.(*Generator), nil
+}
+
+// parseCount parses "n" or "min,max".
+func parseCount(count string, r *rand.Rand) (int, error) {
+	if i := strings.IndexByte(count, ','); i >= 0 {
+		min, err := strconv.Atoi(strings.TrimSpace(count[:i]))
+		if err != nil {
+			return 0, err
+		}
+		max, err := strconv.Atoi(strings.TrimSpace(count[i+1:]))
+		if err != nil {
+			return 0, err
+		}
+		if min < 0 || max < min {
+			return 0, fmt.Errorf("invalid count: %q", count)
+		}
+		return min + r.Intn(max-min+1), nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("invalid count: %q", count)
+	}
+	return n, nil
+}
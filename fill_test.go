@@ -0,0 +1,96 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+)
+
+func TestFill(t *testing.T) {
+	type Address struct {
+		Zip string `rerand:"\\d{3}-\\d{4}"`
+	}
+	type User struct {
+		ID        string   `rerand:"\\d{8}"`
+		Emails    []string `rerand:"[a-z]{4,8}@example\\.com" rerandcount:"1,3"`
+		Address   Address
+		Addresses []Address `rerandcount:"2"`
+		Home      *Address
+		Note      string
+		Skip      string `rerand:"-"`
+		private   string `rerand:"x"`
+	}
+
+	var u User
+	if err := Fill(&u, rand.New(rand.NewSource(1))); err != nil {
+		t.Fatal(err)
+	}
+
+	reID := regexp.MustCompile(`^\d{8}$`)
+	reEmail := regexp.MustCompile(`^[a-z]{4,8}@example\.com$`)
+	reZip := regexp.MustCompile(`^\d{3}-\d{4}$`)
+	if !reID.MatchString(u.ID) {
+		t.Errorf("unexpected ID: %q", u.ID)
+	}
+	if len(u.Emails) < 1 || len(u.Emails) > 3 {
+		t.Errorf("unexpected number of emails: %d", len(u.Emails))
+	}
+	for _, e := range u.Emails {
+		if !reEmail.MatchString(e) {
+			t.Errorf("unexpected email: %q", e)
+		}
+	}
+	if !reZip.MatchString(u.Address.Zip) {
+		t.Errorf("unexpected zip: %q", u.Address.Zip)
+	}
+	if len(u.Addresses) != 2 {
+		t.Errorf("want 2 addresses, got %d", len(u.Addresses))
+	}
+	for _, a := range u.Addresses {
+		if !reZip.MatchString(a.Zip) {
+			t.Errorf("unexpected zip: %q", a.Zip)
+		}
+	}
+	if u.Home == nil || !reZip.MatchString(u.Home.Zip) {
+		t.Errorf("unexpected home: %v", u.Home)
+	}
+	if u.Note != "" || u.Skip != "" || u.private != "" {
+		t.Errorf("unexpected fields are populated: %+v", u)
+	}
+}
+
+func TestFillError(t *testing.T) {
+	var s string
+	if err := Fill(&s, nil); err == nil {
+		t.Error("want error, got nil")
+	}
+
+	var v struct {
+		A string `rerand:"[a-z"`
+	}
+	if err := Fill(&v, nil); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+type fillNode struct {
+	Name     string `rerand:"[a-z]{4}"`
+	Next     *fillNode
+	Children []*fillNode `rerandcount:"2"`
+}
+
+func TestFillRecursive(t *testing.T) {
+	var n fillNode
+	if err := Fill(&n, rand.New(rand.NewSource(1))); err != nil {
+		t.Fatal(err)
+	}
+	if len(n.Name) != 4 {
+		t.Errorf("unexpected name %q", n.Name)
+	}
+	if n.Next != nil {
+		t.Errorf("want nil, got %+v", n.Next)
+	}
+	if len(n.Children) != 2 || n.Children[0] != nil {
+		t.Errorf("want 2 nil children, got %v", n.Children)
+	}
+}
@@ -0,0 +1,63 @@
+package rerand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// ErrFilterExhausted is the error wrapped by *FilterError.
+var ErrFilterExhausted = errors.New("rerand: no string passes the filter")
+
+// FilterError is returned if no generated string passes the filter of WithFilter.
+type FilterError struct {
+	// Attempts is the number of generated strings.
+	Attempts int
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("rerand: no string passes the filter after %d attempts", e.Attempts)
+}
+
+// Unwrap returns ErrFilterExhausted.
+func (e *FilterError) Unwrap() error {
+	return ErrFilterExhausted
+}
+
+// filter calls gen until the generated string passes the filters, or ctx is done.
+func (g *Generator) filter(ctx context.Context, gen func() (string, error)) (string, error) {
+	filters := g.opts.filters
+	if len(filters) == 0 {
+		return gen()
+	}
+	maxAttempts := g.opts.maxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	for i := 0; i < maxAttempts; i++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		s, err := gen()
+		if err != nil {
+			return "", err
+		}
+		if accept(filters, s) {
+			return s, nil
+		}
+		g.opts.onRetry(g.pattern, i+1)
+		g.log(slog.LevelDebug, "rerand: the string is rejected by the filter", slog.Int("attempt", i+1))
+	}
+	g.log(slog.LevelWarn, "rerand: no string passes the filter", slog.Int("attempts", maxAttempts))
+	return "", &FilterError{Attempts: maxAttempts}
+}
+
+func accept(filters []func(string) bool, s string) bool {
+	for _, f := range filters {
+		if !f(s) {
+			return false
+		}
+	}
+	return true
+}
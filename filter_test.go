@@ -0,0 +1,55 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithFilter(t *testing.T) {
+	even := func(s string) bool {
+		sum := 0
+		for _, r := range s {
+			sum += int(r - '0')
+		}
+		return sum%2 == 0
+	}
+	notBlocked := func(s string) bool {
+		return !strings.HasPrefix(s, "0")
+	}
+	g := Must(New(`\d{4}`, syntax.Perl, rand.New(rand.NewSource(1)), WithFilter(even, 0), WithFilter(notBlocked, 10)))
+	for i := 0; i < 1000; i++ {
+		s, err := g.TryGenerate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !even(s) || !notBlocked(s) {
+			t.Errorf("%q does not pass the filters", s)
+		}
+	}
+}
+
+func TestWithFilterExhausted(t *testing.T) {
+	never := func(s string) bool { return false }
+	g := Must(New(`\d{4}`, syntax.Perl, nil, WithFilter(never, 5)))
+	_, err := g.TryGenerate()
+	var ferr *FilterError
+	if !errors.As(err, &ferr) {
+		t.Fatalf("want *FilterError, got %v", err)
+	}
+	if ferr.Attempts != 5 {
+		t.Errorf("want 5 attempts, got %d", ferr.Attempts)
+	}
+	if !errors.Is(err, ErrFilterExhausted) {
+		t.Errorf("want ErrFilterExhausted, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic")
+		}
+	}()
+	g.Generate()
+}
@@ -0,0 +1,83 @@
+package rerand
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// Framing is the framing of the strings written by Reader and WriteStrings.
+type Framing int
+
+const (
+	// FramingSeparator follows each string with the separator of Reader, or a newline for WriteStrings. It is the default.
+	FramingSeparator Framing = iota
+
+	// FramingNUL terminates each string with a NUL byte, e.g. for C programs and `xargs -0`.
+	FramingNUL
+
+	// FramingCRLF terminates each string with CR LF, e.g. for line-based network protocols.
+	FramingCRLF
+
+	// FramingLengthPrefix prefixes each string with its length in bytes as a 4-byte big-endian integer,
+	// so that the strings may contain any bytes, e.g. for network fuzzers.
+	FramingLengthPrefix
+)
+
+// ErrFrameTooLong is returned if a string is too long for FramingLengthPrefix.
+var ErrFrameTooLong = errors.New("rerand: frame too long")
+
+// WithFraming sets the framing of the strings written by Reader and WriteStrings.
+// The separator passed to Reader is ignored unless the framing is FramingSeparator.
+func WithFraming(f Framing) Option {
+	return func(o *options) {
+		o.framing = f
+	}
+}
+
+// WriteStrings writes n random strings to w, framed by WithFraming.
+func (g *Generator) WriteStrings(w io.Writer, n int) error {
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	var err error
+	for i := 0; i < n; i++ {
+		buf, err = g.appendFrame(buf[:0], []byte{'\n'})
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// appendFrame appends a random string framed by WithFraming to dst.
+// sep is the separator for FramingSeparator.
+func (g *Generator) appendFrame(dst, sep []byte) ([]byte, error) {
+	switch g.opts.framing {
+	case FramingNUL:
+		sep = []byte{0}
+	case FramingCRLF:
+		sep = []byte{'\r', '\n'}
+	case FramingLengthPrefix:
+		start := len(dst)
+		dst, err := g.tryAppendString(append(dst, 0, 0, 0, 0))
+		if err != nil {
+			return dst[:start], err
+		}
+		l := len(dst) - start - 4
+		if uint64(l) > math.MaxUint32 {
+			return dst[:start], ErrFrameTooLong
+		}
+		binary.BigEndian.PutUint32(dst[start:], uint32(l))
+		return dst, nil
+	}
+	dst, err := g.tryAppendString(dst)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, sep...), nil
+}
@@ -0,0 +1,68 @@
+package rerand
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWriteStrings(t *testing.T) {
+	tests := []struct {
+		framing Framing
+		want    string
+	}{
+		{FramingSeparator, "abc\nabc\n"},
+		{FramingNUL, "abc\x00abc\x00"},
+		{FramingCRLF, "abc\r\nabc\r\n"},
+		{FramingLengthPrefix, "\x00\x00\x00\x03abc\x00\x00\x00\x03abc"},
+	}
+	for _, tt := range tests {
+		g := Must(New(`abc`, syntax.Perl, nil, WithFraming(tt.framing)))
+		var buf bytes.Buffer
+		if err := g.WriteStrings(&buf, 2); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("framing %d: want %q, got %q", tt.framing, tt.want, buf.String())
+		}
+	}
+}
+
+func TestReaderFraming(t *testing.T) {
+	g := Must(New(`[a-z]{0,20}`, syntax.Perl, nil, WithFraming(FramingLengthPrefix)))
+	r := g.Reader([]byte("ignored"))
+	for i := 0; i < 100; i++ {
+		var l uint32
+		if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+			t.Fatal(err)
+		}
+		if l > 20 {
+			t.Fatalf("too long frame %d", l)
+		}
+		buf := make([]byte, l)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Trim(string(buf), "abcdefghijklmnopqrstuvwxyz") != "" {
+			t.Errorf("unexpected frame %q", buf)
+		}
+	}
+
+	g = Must(New(`a`, syntax.Perl, nil, WithFraming(FramingCRLF)))
+	buf := make([]byte, 6)
+	if _, err := io.ReadFull(g.Reader([]byte(",")), buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "a\r\na\r\n" {
+		t.Errorf("unexpected %q", buf)
+	}
+}
+
+func TestWithFramingInvalid(t *testing.T) {
+	if _, err := New(`a`, syntax.Perl, nil, WithFraming(FramingLengthPrefix+1)); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,76 @@
+package rerand
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// SeedFuzz adds n random strings generated by g to the seed corpus of f.
+// The fuzz target must take one string argument.
+func SeedFuzz(f *testing.F, g *Generator, n int) {
+	f.Helper()
+	for i := 0; i < n; i++ {
+		f.Add(g.Generate())
+	}
+}
+
+// CorpusFormat is the format of corpus files.
+type CorpusFormat int
+
+const (
+	// CorpusGoTest is the format of testing.F, e.g. testdata/fuzz/FuzzXxx.
+	// Each file contains one string argument.
+	CorpusGoTest CorpusFormat = iota
+
+	// CorpusRaw is the format of go-fuzz.
+	// Each file contains raw bytes of the generated string.
+	CorpusRaw
+)
+
+// CorpusWriter writes random strings into corpus files.
+type CorpusWriter struct {
+	// Dir is the directory of corpus files. It is created if it does not exist.
+	Dir string
+
+	// Format is the format of corpus files.
+	Format CorpusFormat
+
+	// Bytes makes CorpusGoTest files contain []byte argument instead of string.
+	Bytes bool
+}
+
+// WriteCorpus writes n random strings generated by g.
+// The name of each file is derived from its content, so duplicated strings are written only once.
+func (w *CorpusWriter) WriteCorpus(g *Generator, n int) error {
+	if err := os.MkdirAll(w.Dir, 0o755); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		data := w.encode(g.Generate())
+		sum := sha256.Sum256(data)
+		name := hex.EncodeToString(sum[:])[:16]
+		if err := os.WriteFile(filepath.Join(w.Dir, name), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *CorpusWriter) encode(s string) []byte {
+	if w.Format == CorpusRaw {
+		return []byte(s)
+	}
+	buf := []byte("go test fuzz v1\n")
+	if w.Bytes {
+		buf = append(buf, "[]byte("...)
+	} else {
+		buf = append(buf, "string("...)
+	}
+	buf = strconv.AppendQuote(buf, s)
+	buf = append(buf, ")\n"...)
+	return buf
+}
@@ -0,0 +1,74 @@
+package rerand
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func FuzzSeedFuzz(f *testing.F) {
+	re := regexp.MustCompile(`^[a-z]{3}-\d{3}$`)
+	SeedFuzz(f, Must(New(`[a-z]{3}-\d{3}`, syntax.Perl, nil)), 10)
+	f.Fuzz(func(t *testing.T, s string) {
+		re.MatchString(s)
+	})
+}
+
+func TestCorpusWriter(t *testing.T) {
+	g := Must(New(`[a-z]{3}-\d{3}`, syntax.Perl, nil))
+	re := regexp.MustCompile(`^[a-z]{3}-\d{3}$`)
+
+	dir := filepath.Join(t.TempDir(), "corpus")
+	w := &CorpusWriter{Dir: dir}
+	if err := w.WriteCorpus(g, 10); err != nil {
+		t.Fatal(err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no corpus files")
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(string(data), "\n")
+		if lines[0] != "go test fuzz v1" {
+			t.Errorf("unexpected header: %q", lines[0])
+		}
+		arg := strings.TrimSuffix(strings.TrimPrefix(lines[1], "string("), ")")
+		s, err := strconv.Unquote(arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+
+	dir = filepath.Join(t.TempDir(), "raw")
+	w = &CorpusWriter{Dir: dir, Format: CorpusRaw}
+	if err := w.WriteCorpus(g, 10); err != nil {
+		t.Fatal(err)
+	}
+	files, err = os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !re.Match(data) {
+			t.Errorf("%q does not match", data)
+		}
+	}
+}
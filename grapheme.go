@@ -0,0 +1,118 @@
+package rerand
+
+import (
+	"math/rand"
+	"sync"
+	"unicode"
+)
+
+const (
+	variation16    = '\uFE0F'
+	regionalA      = 0x1F1E6
+	skinToneLight  = 0x1F3FB
+	skinToneDark   = 0x1F3FF
+	combiningFirst = 0x0300
+	combiningLast  = 0x036F
+)
+
+// zwjSequences are the emoji ZWJ sequences.
+var zwjSequences = [][]rune{
+	[]rune("\U0001F468\u200D\U0001F4BB"),                                 // man technologist
+	[]rune("\U0001F469\u200D\U0001F52C"),                                 // woman scientist
+	[]rune("\U0001F468\u200D\U0001F469\u200D\U0001F467"),                 // family: man, woman, girl
+	[]rune("\U0001F469\u200D\u2764\uFE0F\u200D\U0001F468"),               // couple with heart
+	[]rune("\U0001F3F3\uFE0F\u200D\U0001F308"),                           // rainbow flag
+	[]rune("\U0001F415\u200D\U0001F9BA"),                                 // service dog
+	[]rune("\U0001F9D1\u200D\U0001F91D\u200D\U0001F9D1"),                 // people holding hands
+	[]rune("\U0001F441\uFE0F\u200D\U0001F5E8\uFE0F"),                     // eye in speech bubble
+	[]rune("\U0001F3C3\u200D\u2640\uFE0F"),                               // woman running
+	[]rune("\U0001F468\u200D\U0001F469\u200D\U0001F467\u200D\U0001F466"), // family: man, woman, girl, boy
+}
+
+// modifierBases are the emoji that accept skin tone modifiers.
+var modifierBases = []rune{
+	0x1F44B, 0x1F44C, 0x1F44D, 0x1F44E, 0x1F44F, 0x1F450, // hands
+	0x1F466, 0x1F467, 0x1F468, 0x1F469, // people
+}
+
+// flags are the regions of the flag emoji.
+var flags = []string{"JP", "US", "GB", "FR", "DE", "BR", "IN", "CN", "KR", "CA", "AU", "IT", "ES", "MX"}
+
+// appendGrapheme appends a random grapheme cluster to dst.
+// The base characters are chosen from gen.
+// mu guards r.
+func appendGrapheme(dst []rune, gen *RuneGenerator, r *rand.Rand, mu sync.Locker) []rune {
+	mu.Lock()
+	kind := r.Intn(100)
+	mu.Unlock()
+
+	switch {
+	case kind < 50:
+		// a single graphic character
+		return append(dst, graphemeBase(gen, r, mu))
+	case kind < 70:
+		// a letter with combining marks
+		mu.Lock()
+		defer mu.Unlock()
+		base := rune('a' + r.Intn(26))
+		if r.Intn(2) == 0 {
+			base = unicode.ToUpper(base)
+		}
+		dst = append(dst, base)
+		for n := 1 + r.Intn(2); n > 0; n-- {
+			dst = append(dst, rune(combiningFirst+r.Intn(combiningLast-combiningFirst+1)))
+		}
+		return dst
+	case kind < 85:
+		// an emoji with or without a skin tone modifier
+		mu.Lock()
+		defer mu.Unlock()
+		dst = append(dst, modifierBases[r.Intn(len(modifierBases))])
+		if r.Intn(2) == 0 {
+			dst = append(dst, rune(skinToneLight+r.Intn(skinToneDark-skinToneLight+1)))
+		}
+		return dst
+	case kind < 95:
+		// an emoji ZWJ sequence
+		mu.Lock()
+		seq := zwjSequences[r.Intn(len(zwjSequences))]
+		mu.Unlock()
+		return append(dst, seq...)
+	default:
+		// a flag
+		mu.Lock()
+		region := flags[r.Intn(len(flags))]
+		mu.Unlock()
+		return append(dst, regionalA+rune(region[0]-'A'), regionalA+rune(region[1]-'A'))
+	}
+}
+
+// graphemeBase returns a graphic character that forms a grapheme cluster by itself.
+func graphemeBase(gen *RuneGenerator, r *rand.Rand, mu sync.Locker) rune {
+	for i := 0; i < 1000; i++ {
+		c := gen.generate(r, mu)
+		if isGraphemeBase(c) {
+			return c
+		}
+	}
+	return 'a'
+}
+
+func isGraphemeBase(c rune) bool {
+	if !unicode.IsGraphic(c) || unicode.IsMark(c) {
+		return false
+	}
+	if 0xD800 <= c && c <= 0xDFFF {
+		return false // surrogates
+	}
+	if regionalA <= c && c < regionalA+26 {
+		return false // regional indicators are paired
+	}
+	if skinToneLight <= c && c <= skinToneDark {
+		return false
+	}
+	if unicode.Is(unicode.Hangul, c) && !(0xAC00 <= c && c <= 0xD7A3) {
+		return false // conjoining jamo
+	}
+	return c != variation16
+}
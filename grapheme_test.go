@@ -0,0 +1,74 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// checkGrapheme reports whether s is a well-formed grapheme cluster generated by appendGrapheme.
+func checkGrapheme(s []rune) bool {
+	if len(s) == 0 {
+		return false
+	}
+	if regionalA <= s[0] && s[0] < regionalA+26 {
+		return len(s) == 2 && regionalA <= s[1] && s[1] < regionalA+26
+	}
+	if !isGraphemeBase(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case unicode.IsMark(c), c == variation16, skinToneLight <= c && c <= skinToneDark:
+		case c == '\u200D':
+			if i+1 >= len(s) || !isGraphemeBase(s[i+1]) {
+				return false
+			}
+			i++
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func TestWithGraphemeClusters(t *testing.T) {
+	g := Must(New(`.`, syntax.Perl, nil, WithGraphemeClusters()))
+	var multi, zwj, flag bool
+	for i := 0; i < 1000; i++ {
+		s := []rune(g.Generate())
+		if !checkGrapheme(s) {
+			t.Errorf("%+q is not a grapheme cluster", string(s))
+		}
+		multi = multi || len(s) > 1
+		zwj = zwj || strings.ContainsRune(string(s), '\u200D')
+		flag = flag || regionalA <= s[0] && s[0] < regionalA+26
+	}
+	if !multi || !zwj || !flag {
+		t.Errorf("want various clusters: multi %v, zwj %v, flag %v", multi, zwj, flag)
+	}
+}
+
+func TestWithGraphemeClustersCount(t *testing.T) {
+	// canSplit reports whether s is split into n grapheme clusters.
+	var canSplit func(s []rune, n int) bool
+	canSplit = func(s []rune, n int) bool {
+		if n == 0 {
+			return len(s) == 0
+		}
+		for i := 1; i <= len(s); i++ {
+			if checkGrapheme(s[:i]) && canSplit(s[i:], n-1) {
+				return true
+			}
+		}
+		return false
+	}
+
+	g := Must(New(`.{3}`, syntax.Perl, nil, WithGraphemeClusters()))
+	for i := 0; i < 100; i++ {
+		if s := []rune(g.Generate()); !canSplit(s, 3) {
+			t.Errorf("%+q is not three grapheme clusters", string(s))
+		}
+	}
+}
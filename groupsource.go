@@ -0,0 +1,20 @@
+package rerand
+
+import "math/rand"
+
+// GroupSource is a source of the values of named groups, e.g. the timestamps of rerandtime.
+type GroupSource interface {
+	// GenerateGroup returns a random value using r.
+	GenerateGroup(r *rand.Rand) string
+}
+
+// WithGroupSource generates the named group by src, instead of the pattern of the group,
+// for the values that a regular expression cannot describe precisely, e.g. valid dates.
+// The values are visible to WithDependency of the groups generated after it.
+// The values need not match the pattern of the group, but then the strings may not pass WithVerify.
+// It takes effect in Generate and its variants, e.g. TryGenerate and AppendString.
+func WithGroupSource(group string, src GroupSource) Option {
+	return func(o *options) {
+		o.dependencies = append(o.dependencies, dependencyOption{group: group, source: src})
+	}
+}
@@ -0,0 +1,30 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"strconv"
+	"testing"
+)
+
+// evenSource generates even numbers.
+type evenSource struct{}
+
+func (evenSource) GenerateGroup(r *rand.Rand) string {
+	return strconv.Itoa(2 * r.Intn(50))
+}
+
+func TestWithGroupSource(t *testing.T) {
+	g := Must(New(`id-(?P<n>\d+)`, syntax.Perl, nil, WithTargetLength(5), WithGroupSource("n", evenSource{})))
+	for i := 0; i < 100; i++ {
+		s := g.Generate()
+		n, err := strconv.Atoi(s[len("id-"):])
+		if err != nil || n%2 != 0 || n >= 100 {
+			t.Errorf("unexpected %q", s)
+		}
+	}
+
+	if _, err := New(`(?P<n>\d)`, syntax.Perl, nil, WithGroupSource("unknown", evenSource{})); err == nil {
+		t.Error("want error, got nil")
+	}
+}
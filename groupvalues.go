@@ -0,0 +1,72 @@
+package rerand
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// WithGroupValues generates the named group by choosing one of the values with the probability proportional to its weight,
+// instead of the pattern of the group, e.g. WithGroupValues("country", map[string]float64{"US": 0.6, "DE": 0.3, "JP": 0.1}).
+// The weights need not sum to one, but they must be non-negative and finite, and at least one of them must be positive.
+// The values are visible to WithDependency of the groups generated after it.
+// The values need not match the pattern of the group, but then the strings may not pass WithVerify.
+// It takes effect in Generate and its variants, e.g. TryGenerate and AppendString.
+func WithGroupValues(group string, values map[string]float64) Option {
+	pool := make(map[string]float64, len(values))
+	for v, w := range values {
+		pool[v] = w
+	}
+	return func(o *options) {
+		o.dependencies = append(o.dependencies, dependencyOption{group: group, pool: pool})
+	}
+}
+
+// valuePool is the weighted values of a group.
+type valuePool struct {
+	values [][]rune
+	cum    []float64 // the cumulative weights
+}
+
+func newValuePool(group string, values map[string]float64) (*valuePool, error) {
+	// sort the values so that the strings generated from a seed are reproducible.
+	keys := make([]string, 0, len(values))
+	for v := range values {
+		keys = append(keys, v)
+	}
+	slices.Sort(keys)
+
+	p := &valuePool{}
+	var sum float64
+	for _, v := range keys {
+		w := values[v]
+		if !(w >= 0) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("rerand: invalid weight %v of %q in group %q", w, v, group)
+		}
+		if w == 0 {
+			continue
+		}
+		sum += w
+		p.values = append(p.values, []rune(v))
+		p.cum = append(p.cum, sum)
+	}
+	if sum == 0 || math.IsInf(sum, 0) {
+		return nil, fmt.Errorf("rerand: no value to generate in group %q", group)
+	}
+	return p, nil
+}
+
+// choose returns one of the values. mu guards r.
+func (p *valuePool) choose(r *rand.Rand, mu sync.Locker) []rune {
+	mu.Lock()
+	x := r.Float64() * p.cum[len(p.cum)-1]
+	mu.Unlock()
+	i := sort.SearchFloat64s(p.cum, x)
+	if i < len(p.cum) && p.cum[i] == x {
+		i++ // x is in [cum[i-1], cum[i])
+	}
+	return p.values[min(i, len(p.values)-1)]
+}
@@ -0,0 +1,76 @@
+package rerand
+
+import (
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithGroupValues(t *testing.T) {
+	weights := map[string]float64{"US": 0.6, "DE": 0.3, "JP": 0.1, "FR": 0}
+	g := Must(New(`(?P<country>[A-Z]{2})-\d{4}`, syntax.Perl, rand.New(rand.NewSource(1)), WithGroupValues("country", weights)))
+	const n = 10000
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		s := g.Generate()
+		country, digits, ok := strings.Cut(s, "-")
+		if !ok || len(digits) != 4 {
+			t.Fatalf("unexpected %q", s)
+		}
+		counts[country]++
+	}
+	for country, w := range weights {
+		if got := float64(counts[country]) / n; math.Abs(got-w) > 0.03 {
+			t.Errorf("%s: want %f, got %f", country, w, got)
+		}
+	}
+}
+
+func TestWithGroupValuesDependency(t *testing.T) {
+	g := Must(New(`(?P<country>..)(?P<code>\d{1,2})`, syntax.Perl, nil,
+		WithGroupValues("country", map[string]float64{"US": 1, "JP": 1}),
+		WithDependency("code", func(values map[string]string) string {
+			if values["country"] == "US" {
+				return `1`
+			}
+			return `81`
+		}),
+	))
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); s != "US1" && s != "JP81" {
+			t.Fatalf("unexpected %q", s)
+		}
+	}
+}
+
+func TestWithGroupValuesSeed(t *testing.T) {
+	weights := map[string]float64{"a": 1, "b": 1, "c": 1, "d": 1}
+	g1 := Must(New(`(?P<x>.)`, syntax.Perl, rand.New(rand.NewSource(1)), WithGroupValues("x", weights)))
+	g2 := Must(New(`(?P<x>.)`, syntax.Perl, rand.New(rand.NewSource(1)), WithGroupValues("x", weights)))
+	for i := 0; i < 100; i++ {
+		if s1, s2 := g1.Generate(), g2.Generate(); s1 != s2 {
+			t.Fatalf("want same strings, got %q and %q", s1, s2)
+		}
+	}
+}
+
+func TestWithGroupValuesError(t *testing.T) {
+	tests := []struct {
+		group  string
+		values map[string]float64
+	}{
+		{"unknown", map[string]float64{"a": 1}},
+		{"x", map[string]float64{}},
+		{"x", map[string]float64{"a": 0}},
+		{"x", map[string]float64{"a": -1}},
+		{"x", map[string]float64{"a": math.NaN()}},
+		{"x", map[string]float64{"a": math.Inf(1)}},
+	}
+	for _, tt := range tests {
+		if _, err := New(`(?P<x>.)`, syntax.Perl, nil, WithGroupValues(tt.group, tt.values)); err == nil {
+			t.Errorf("%s, %v: want error, got nil", tt.group, tt.values)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package rerand
+
+import (
+	"time"
+)
+
+// Hooks are the callbacks for instrumenting Generator, e.g. for metrics.
+// Nil callbacks are ignored.
+// The callbacks must be safe for concurrent use, because generators are.
+type Hooks struct {
+	// OnGenerate is called after a string s is generated successfully.
+	// elapsed is the time taken to generate s, including the retries.
+	OnGenerate func(pattern string, s string, elapsed time.Duration)
+
+	// OnRetry is called when a generated string is rejected by the filter of WithFilter, and generated again.
+	// attempt is the number of the rejected attempts so far.
+	OnRetry func(pattern string, attempt int)
+
+	// OnError is called when the generation fails.
+	OnError func(pattern string, err error)
+}
+
+// WithHooks calls the callbacks of h on generation.
+// If WithHooks is given more than once, all the hooks are called in the order.
+// It takes effect in Generate and its variants, e.g. TryGenerate and AppendString,
+// and AppendRunes and AppendString may allocate with it.
+func WithHooks(h Hooks) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, h)
+	}
+}
+
+// onGenerate calls the hooks after generation.
+func (o *options) onGenerate(pattern string, s string, elapsed time.Duration, err error) {
+	for _, h := range o.hooks {
+		if err != nil {
+			if h.OnError != nil {
+				h.OnError(pattern, err)
+			}
+		} else if h.OnGenerate != nil {
+			h.OnGenerate(pattern, s, elapsed)
+		}
+	}
+}
+
+// onRetry calls the hooks when the generated string is rejected.
+func (o *options) onRetry(pattern string, attempt int) {
+	for _, h := range o.hooks {
+		if h.OnRetry != nil {
+			h.OnRetry(pattern, attempt)
+		}
+	}
+}
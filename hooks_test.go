@@ -0,0 +1,63 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithHooks(t *testing.T) {
+	var mu sync.Mutex
+	var generated []string
+	var retries, errs int
+	hooks := Hooks{
+		OnGenerate: func(pattern, s string, elapsed time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			if pattern != `[ab]{3}` || elapsed < 0 {
+				t.Errorf("unexpected pattern %q, elapsed %v", pattern, elapsed)
+			}
+			generated = append(generated, s)
+		},
+		OnRetry: func(pattern string, attempt int) {
+			mu.Lock()
+			defer mu.Unlock()
+			retries++
+		},
+		OnError: func(pattern string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs++
+		},
+	}
+	g := Must(New(`[ab]{3}`, syntax.Perl, nil, WithHooks(hooks), WithFilter(func(s string) bool {
+		return strings.HasPrefix(s, "a")
+	}, 1000)))
+	var want []string
+	for i := 0; i < 100; i++ {
+		want = append(want, g.Generate())
+	}
+	want = append(want, string(g.AppendString(nil)))
+	if len(generated) != len(want) {
+		t.Fatalf("want %d calls, got %d", len(want), len(generated))
+	}
+	for i := range want {
+		if generated[i] != want[i] {
+			t.Errorf("want %q, got %q", want[i], generated[i])
+		}
+	}
+	if retries == 0 || errs != 0 {
+		t.Errorf("unexpected retries %d, errors %d", retries, errs)
+	}
+
+	g = Must(New(`a{3}`, syntax.Perl, nil, WithHooks(hooks), WithMaxLength(2, MaxLengthError)))
+	if _, err := g.TryGenerate(); !errors.Is(err, ErrTooLong) {
+		t.Fatalf("want ErrTooLong, got %v", err)
+	}
+	if errs != 1 {
+		t.Errorf("want 1 error, got %d", errs)
+	}
+}
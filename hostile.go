@@ -0,0 +1,75 @@
+package rerand
+
+import (
+	"math/rand"
+	"sync"
+	"unicode/utf8"
+)
+
+// hostileSequences are the byte sequences injected by GenerateHostile.
+var hostileSequences = [][]byte{
+	// NUL
+	{0x00},
+
+	// bidi controls: LRE, RLE, PDF, LRO, RLO, LRI, RLI, FSI, PDI
+	[]byte("\u202A"), []byte("\u202B"), []byte("\u202C"), []byte("\u202D"), []byte("\u202E"),
+	[]byte("\u2066"), []byte("\u2067"), []byte("\u2068"), []byte("\u2069"),
+
+	// zero width characters: ZWSP, ZWNJ, ZWJ, BOM
+	[]byte("\u200B"), []byte("\u200C"), []byte("\u200D"), []byte("\uFEFF"),
+
+	// unpaired surrogates encoded as WTF-8: U+D800, U+DBFF, U+DC00, U+DFFF
+	{0xED, 0xA0, 0x80}, {0xED, 0xAF, 0xBF}, {0xED, 0xB0, 0x80}, {0xED, 0xBF, 0xBF},
+
+	// overlong encodings of '/', NUL and '.'
+	{0xC0, 0xAF}, {0xE0, 0x80, 0xAF}, {0xF0, 0x80, 0x80, 0xAF},
+	{0xC0, 0x80}, {0xC0, 0xAE},
+
+	// truncated and stray bytes
+	{0xE3, 0x81}, {0x80}, {0xFF},
+}
+
+// GenerateHostile generates a string matching the pattern, and injects one to three hostile sequences into it:
+// NUL, bidi control characters, zero width characters, unpaired surrogates encoded as WTF-8, overlong encodings and stray bytes.
+// The result is "almost valid" input for robustness testing, and may not be valid UTF-8.
+// It usually does not match the pattern, but it may still match, e.g. `.*` accepts the injected NUL.
+// It panics if the generation fails, in the same way as Generate.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateHostile() string {
+	return g.generateHostile(g.rand, &g.mu)
+}
+
+// generateHostile generates a hostile string using r.
+// mu guards r.
+func (g *Generator) generateHostile(r *rand.Rand, mu sync.Locker) string {
+	s := g.generate(r, mu)
+
+	// the boundaries of the runes where sequences are injected
+	mu.Lock()
+	n := 1 + r.Intn(3)
+	positions := make([]int, n)
+	seqs := make([][]byte, n)
+	count := utf8.RuneCountInString(s)
+	for i := range positions {
+		positions[i] = r.Intn(count + 1)
+		seqs[i] = hostileSequences[r.Intn(len(hostileSequences))]
+	}
+	mu.Unlock()
+
+	buf := make([]byte, 0, len(s)+4*n)
+	inject := func(pos int) {
+		for i, p := range positions {
+			if p == pos {
+				buf = append(buf, seqs[i]...)
+			}
+		}
+	}
+	var pos int
+	for _, c := range s {
+		inject(pos)
+		buf = utf8.AppendRune(buf, c)
+		pos++
+	}
+	inject(pos)
+	return string(buf)
+}
@@ -0,0 +1,44 @@
+package rerand
+
+import (
+	"bytes"
+	"regexp/syntax"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateHostile(t *testing.T) {
+	g := Must(New(`[a-z]{3,5}`, syntax.Perl, nil))
+	var invalid bool
+	for i := 0; i < 1000; i++ {
+		s := g.GenerateHostile()
+
+		// removing the hostile sequences recovers the matching string.
+		var injected int
+		rest := s
+		for _, seq := range hostileSequences {
+			for bytes.Contains([]byte(rest), seq) {
+				rest = strings.Replace(rest, string(seq), "", 1)
+				injected++
+			}
+		}
+		if injected < 1 || injected > 3 {
+			t.Errorf("%+q: want 1 to 3 hostile sequences, got %d", s, injected)
+		}
+		if len(rest) < 3 || len(rest) > 5 || strings.Trim(rest, "abcdefghijklmnopqrstuvwxyz") != "" {
+			t.Errorf("%+q: unexpected rest %+q", s, rest)
+		}
+		invalid = invalid || !utf8.ValidString(s)
+	}
+	if !invalid {
+		t.Error("want invalid UTF-8 strings")
+	}
+}
+
+func TestGenerateHostileEmpty(t *testing.T) {
+	g := Must(New(``, syntax.Perl, nil))
+	if s := g.GenerateHostile(); s == "" {
+		t.Error("want hostile sequences, got empty")
+	}
+}
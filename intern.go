@@ -0,0 +1,59 @@
+package rerand
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"unicode/utf8"
+)
+
+// WithInterning materializes all strings of the language in advance if there are at most maxStrings,
+// and returns the shared strings instead of allocating new ones, e.g. for enum-like patterns such as `(red|green|blue)`.
+// The probabilities and the strings generated from a seed are same as without this option.
+// If the language is infinite or has more strings, it has no effect.
+// Generate does not allocate if the strings are short and no option of post-processing is given, e.g. WithFilter.
+func WithInterning(maxStrings int) Option {
+	return func(o *options) {
+		o.internLimit = maxStrings
+	}
+}
+
+// maxInternedBytes is the maximum length of the interned strings in bytes,
+// so that they are looked up without allocation.
+const maxInternedBytes = 256
+
+// newInternTable returns the strings that g generates, or nil if there are more than limit strings.
+func (g *Generator) newInternTable(limit int) map[string]string {
+	maxLen, finite := g.MaxLen()
+	if !finite || maxLen*utf8.UTFMax > maxInternedBytes {
+		return nil
+	}
+	table := make(map[string]string)
+	var n int
+	for s := range g.Enumerate() {
+		if n++; n > limit {
+			return nil
+		}
+		table[s] = s
+	}
+	return table
+}
+
+// runInterned is same as run, but returns the interned string.
+func (g *Generator) runInterned(ctx context.Context, r *rand.Rand, mu sync.Locker) (string, error) {
+	var buf [64]rune
+	result, err := g.appendRunes(ctx, buf[:0], r, mu)
+	if err != nil {
+		return "", err
+	}
+	var b [maxInternedBytes]byte
+	key := b[:0]
+	for _, r := range result {
+		key = utf8.AppendRune(key, r)
+	}
+	if s, ok := g.interned[string(key)]; ok {
+		return s, nil
+	}
+	// e.g. the values of WithDependency that the enumeration does not take into account.
+	return string(result), nil
+}
@@ -0,0 +1,61 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+	"unsafe"
+)
+
+func TestWithInterning(t *testing.T) {
+	g1 := Must(New(`(red|green|blue)-[0-9]`, syntax.Perl, rand.New(rand.NewSource(1)), WithInterning(100)))
+	g2 := Must(New(`(red|green|blue)-[0-9]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	if len(g1.interned) != 30 {
+		t.Fatalf("want 30 interned strings, got %d", len(g1.interned))
+	}
+	for i := 0; i < 100; i++ {
+		s1, s2 := g1.Generate(), g2.Generate()
+		if s1 != s2 {
+			t.Errorf("want same strings, got %q and %q", s1, s2)
+		}
+		if interned := g1.interned[s1]; unsafe.StringData(interned) != unsafe.StringData(s1) {
+			t.Errorf("%q is not interned", s1)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		g1.Generate()
+	})
+	if allocs != 0 {
+		t.Errorf("want no allocation, got %f", allocs)
+	}
+}
+
+func TestWithInterningNoEffect(t *testing.T) {
+	tests := []string{
+		`[a-z]{1,5}`, // too many strings
+		`a+`,         // infinite
+	}
+	for _, pattern := range tests {
+		g := Must(New(pattern, syntax.Perl, nil, WithInterning(100), WithTargetLength(3)))
+		if g.interned != nil {
+			t.Errorf("%s: want no interning", pattern)
+		}
+		g.Generate()
+	}
+
+	if _, err := New(`a`, syntax.Perl, nil, WithInterning(-1)); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestWithInterningClone(t *testing.T) {
+	g := Must(New(`yes|no`, syntax.Perl, nil, WithInterning(2)))
+	c := g.Clone(rand.New(rand.NewSource(1)))
+	for i := 0; i < 10; i++ {
+		s := c.Generate()
+		if unsafe.StringData(g.interned[s]) != unsafe.StringData(s) {
+			t.Errorf("%q is not interned", s)
+		}
+	}
+}
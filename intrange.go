@@ -0,0 +1,61 @@
+package rerand
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// intRange is a GroupSource of the decimal integers in a range.
+type intRange struct {
+	min, max int64
+	width    int
+}
+
+// IntRange returns a GroupSource that generates the decimal integers between min and max inclusive uniformly,
+// e.g. WithGroupSource("n", IntRange(1, 999, 0)) for `(?P<n>[1-9]\d{0,2})`, which generates the short numbers too often.
+// The digits are padded with zeros to width, and the sign of negative integers is not counted in width,
+// e.g. IntRange(-5, 5, 2) generates -05 and 05.
+// It panics if min is greater than max or width is negative.
+func IntRange(min, max int64, width int) GroupSource {
+	if min > max {
+		panic("rerand: invalid range")
+	}
+	if width < 0 {
+		panic("rerand: negative width")
+	}
+	return &intRange{min: min, max: max, width: width}
+}
+
+// GenerateGroup implements GroupSource.
+func (ir *intRange) GenerateGroup(r *rand.Rand) string {
+	n := ir.min + int64(ir.uint64n(r, uint64(ir.max)-uint64(ir.min)))
+	neg := n < 0
+	s := strconv.FormatUint(uint64(n), 10)
+	if neg {
+		s = strconv.FormatUint(-uint64(n), 10)
+	}
+	if len(s) < ir.width {
+		s = strings.Repeat("0", ir.width-len(s)) + s
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// uint64n returns a uniform random number in [0, n].
+func (ir *intRange) uint64n(r *rand.Rand, n uint64) uint64 {
+	if n < 1<<63-1 {
+		return uint64(r.Int63n(int64(n) + 1))
+	}
+	if n == 1<<64-1 {
+		return r.Uint64()
+	}
+	// rejection sampling for the range wider than int64.
+	for {
+		if v := r.Uint64(); v <= n {
+			return v
+		}
+	}
+}
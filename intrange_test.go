@@ -0,0 +1,89 @@
+package rerand
+
+import (
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strconv"
+	"testing"
+)
+
+func TestIntRange(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	src := IntRange(1, 999, 0)
+	var short int
+	for i := 0; i < 10000; i++ {
+		s := src.GenerateGroup(r)
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < 1 || n > 999 || s != strconv.FormatInt(n, 10) {
+			t.Fatalf("unexpected %q", s)
+		}
+		if n < 10 {
+			short++
+		}
+	}
+	// 9 of 999 numbers have one digit.
+	if short > 200 {
+		t.Errorf("too many one-digit numbers: %d", short)
+	}
+}
+
+func TestIntRangeWidth(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	src := IntRange(-5, 5, 2)
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		seen[src.GenerateGroup(r)] = true
+	}
+	for _, want := range []string{"-05", "-01", "00", "03", "05"} {
+		if !seen[want] {
+			t.Errorf("%q is not generated", want)
+		}
+	}
+	if len(seen) != 11 {
+		t.Errorf("want 11 values, got %d: %v", len(seen), seen)
+	}
+}
+
+func TestIntRangeWide(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, src := range []GroupSource{
+		IntRange(math.MinInt64, math.MaxInt64, 0),
+		IntRange(-1, math.MaxInt64, 0),
+		IntRange(math.MinInt64, math.MinInt64, 25),
+	} {
+		for i := 0; i < 100; i++ {
+			s := src.GenerateGroup(r)
+			if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+				t.Fatalf("unexpected %q: %v", s, err)
+			}
+		}
+	}
+}
+
+func TestIntRangeGroup(t *testing.T) {
+	g := Must(New(`port=(?P<port>\d+)`, syntax.Perl, nil, WithTargetLength(5), WithGroupSource("port", IntRange(1024, 65535, 0))))
+	for i := 0; i < 100; i++ {
+		s := g.Generate()
+		n, err := strconv.Atoi(s[len("port="):])
+		if err != nil || n < 1024 || n > 65535 {
+			t.Errorf("unexpected %q", s)
+		}
+	}
+}
+
+func TestIntRangePanic(t *testing.T) {
+	for _, f := range []func(){
+		func() { IntRange(2, 1, 0) },
+		func() { IntRange(1, 2, -1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("want panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
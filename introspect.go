@@ -0,0 +1,66 @@
+package rerand
+
+import (
+	"regexp/syntax"
+)
+
+// IsFinite reports whether g generates finitely many strings.
+// It is false only if the pattern has unbounded repetitions allowed by WithTargetLength.
+func (g *Generator) IsFinite() bool {
+	_, ok := g.MaxLen()
+	return ok
+}
+
+// MinLen returns the length in runes of the shortest string that g generates.
+func (g *Generator) MinLen() int {
+	return g.minLens()[g.prog.Start]
+}
+
+// MaxLen returns the length in runes of the longest string that g generates.
+// If the length is unbounded, it returns false.
+func (g *Generator) MaxLen() (int, bool) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(g.inst))
+	maxLen := make([]int, len(g.inst))
+	minLen := g.minLens()
+	infinite := false
+	var visit func(pc uint32) int
+	visit = func(pc uint32) int {
+		switch state[pc] {
+		case visiting:
+			infinite = true
+			return 0
+		case done:
+			return maxLen[pc]
+		}
+		state[pc] = visiting
+		in := &g.inst[pc]
+		l := 0
+		switch in.Op {
+		case syntax.InstRune, syntax.InstRune1:
+			l = visit(in.Out) + 1
+		case syntax.InstAlt:
+			// skip the branches that never match
+			if minLen[in.Out] != infDist {
+				l = visit(in.Out)
+			}
+			if minLen[in.Arg] != infDist {
+				l = max(l, visit(in.Arg))
+			}
+		case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+			l = visit(in.Out)
+		}
+		state[pc] = done
+		maxLen[pc] = l
+		return l
+	}
+	l := visit(uint32(g.prog.Start))
+	if infinite {
+		return 0, false
+	}
+	return l, true
+}
@@ -0,0 +1,36 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"testing"
+)
+
+func TestIntrospection(t *testing.T) {
+	cases := []struct {
+		pattern string
+		opts    []Option
+		min     int
+		max     int
+		finite  bool
+	}{
+		{`abc`, nil, 3, 3, true},
+		{`[a-z]{2,8}`, nil, 2, 8, true},
+		{`abc|de(f|gh)?`, nil, 2, 4, true},
+		{`(ab)?`, nil, 0, 2, true},
+		{`a+b`, []Option{WithTargetLength(5)}, 2, 0, false},
+		{`a*`, []Option{WithTargetLength(5)}, 0, 0, false},
+	}
+	for _, c := range cases {
+		g := Must(New(c.pattern, syntax.Perl, nil, c.opts...))
+		if got := g.MinLen(); got != c.min {
+			t.Errorf("%s: want MinLen %d, got %d", c.pattern, c.min, got)
+		}
+		got, ok := g.MaxLen()
+		if ok != c.finite || got != c.max {
+			t.Errorf("%s: want MaxLen %d, %v, got %d, %v", c.pattern, c.max, c.finite, got, ok)
+		}
+		if g.IsFinite() != c.finite {
+			t.Errorf("%s: want IsFinite %v", c.pattern, c.finite)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+)
+
+// ErrLazyQuantifier is returned by New with WithStrictQuantifiers if the pattern has lazy quantifiers.
+var ErrLazyQuantifier = errors.New("rerand: lazy quantifier")
+
+// WithStrictQuantifiers rejects the patterns with lazy quantifiers such as `*?`, `+?`, `??` and `{n,m}?`.
+// Without this option, lazy quantifiers are accepted, and generate the same strings as their greedy forms,
+// because the matching strategy does not change which strings match.
+// Possessive quantifiers such as `*+` are not supported by regexp/syntax, and always rejected.
+func WithStrictQuantifiers() Option {
+	return func(o *options) {
+		o.strictQuantifiers = true
+	}
+}
+
+// findLazy returns the first lazy quantifier in re, or nil.
+func findLazy(re *syntax.Regexp) *syntax.Regexp {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		if re.Flags&syntax.NonGreedy != 0 {
+			return re
+		}
+	}
+	for _, sub := range re.Sub {
+		if lazy := findLazy(sub); lazy != nil {
+			return lazy
+		}
+	}
+	return nil
+}
+
+// greedy returns re with the lazy quantifiers replaced by the greedy ones,
+// so that the program and the strings generated from a seed do not depend on the laziness.
+// re is not modified, and it is returned as is if it has no lazy quantifier.
+func greedy(re *syntax.Regexp) *syntax.Regexp {
+	if findLazy(re) == nil {
+		return re
+	}
+	ret := *re
+	ret.Flags &^= syntax.NonGreedy
+	ret.Sub = make([]*syntax.Regexp, len(re.Sub))
+	for i, sub := range re.Sub {
+		ret.Sub[i] = greedy(sub)
+	}
+	ret.Sub0 = [1]*syntax.Regexp{}
+	return &ret
+}
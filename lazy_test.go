@@ -0,0 +1,65 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestLazyQuantifiers(t *testing.T) {
+	tests := []struct {
+		lazy, greedy string
+		opts         []Option
+	}{
+		{`a??b`, `a?b`, nil},
+		{`[a-z]{2,5}?`, `[a-z]{2,5}`, nil},
+		{`(?:ab|c){1,3}?x`, `(?:ab|c){1,3}x`, []Option{WithDistinctRunes()}},
+		{`x*?y+?`, `x*y+`, []Option{WithTargetLength(5)}},
+		{`(?U)a*b?`, `a*b?`, []Option{WithTargetLength(5)}},
+	}
+	for _, tt := range tests {
+		g1 := Must(New(tt.lazy, syntax.Perl, rand.New(rand.NewSource(1)), tt.opts...))
+		g2 := Must(New(tt.greedy, syntax.Perl, rand.New(rand.NewSource(1)), tt.opts...))
+		re := regexp.MustCompile(`^(?:` + tt.lazy + `)$`)
+		for i := 0; i < 100; i++ {
+			s1, s2 := g1.Generate(), g2.Generate()
+			if s1 != s2 {
+				t.Fatalf("%s: want %q, got %q", tt.lazy, s2, s1)
+			}
+			if !re.MatchString(s1) {
+				t.Fatalf("%s: %q does not match", tt.lazy, s1)
+			}
+		}
+	}
+}
+
+func TestLazyQuantifiersNotModified(t *testing.T) {
+	re, err := syntax.Parse(`a+?`, syntax.Perl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Must(NewFromSyntax(re, nil, WithTargetLength(3)))
+	if re.Flags&syntax.NonGreedy == 0 {
+		t.Error("re is modified")
+	}
+}
+
+func TestWithStrictQuantifiers(t *testing.T) {
+	for _, pattern := range []string{`a??`, `(?:b[a-z]{2,5}?)`, `(?U)a?`} {
+		_, err := New(pattern, syntax.Perl, nil, WithStrictQuantifiers())
+		if !errors.Is(err, ErrLazyQuantifier) {
+			t.Errorf("%s: want ErrLazyQuantifier, got %v", pattern, err)
+		}
+	}
+	if _, err := New(`a?[a-z]{2,5}`, syntax.Perl, nil, WithStrictQuantifiers()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPossessiveQuantifiers(t *testing.T) {
+	if _, err := New(`a*+`, syntax.Perl, nil); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,168 @@
+package rerand
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"regexp/syntax"
+	"sync"
+)
+
+// ErrImpossibleLength is returned by GenerateLen if the generator never generates strings of the length.
+var ErrImpossibleLength = errors.New("rerand: no string of the length")
+
+// lengthCounts is the number of strings of each length, counting the runes of character classes as distinct strings.
+// counts[l][pc] is the number of strings of length l generated from pc.
+type lengthCounts struct {
+	mu     sync.Mutex
+	counts [][]*big.Int
+}
+
+// GenerateLen generates a random string of exactly n runes.
+// The strings of length n are equally likely, e.g. `ab|[a-z]{2}` generates each of the 676 strings with the same probability,
+// unless the pattern is ambiguous and a string is generated in more than one way, e.g. "ab" is twice as likely as the others.
+// The probabilities of Generate, e.g. of NewWithProbability and WithRuneSource, are ignored.
+// If n exceeds the maximum length of a finite language, it fails with ErrImpossibleLength without counting the strings.
+// Note that WithTransform may change the length.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateLen(n int) (string, error) {
+	return g.filter(context.Background(), func() (string, error) {
+		s, err := g.generateLen(n)
+		if err != nil {
+			return "", err
+		}
+		if err := g.verify(s); err != nil {
+			return "", err
+		}
+		return g.transform(s), nil
+	})
+}
+
+func (g *Generator) generateLen(n int) (string, error) {
+	if n < g.MinLen() {
+		return "", ErrImpossibleLength
+	}
+	if maxLen, finite := g.MaxLen(); finite && n > maxLen {
+		return "", ErrImpossibleLength
+	}
+	counts, err := g.countsLen(n)
+	if err != nil {
+		return "", err
+	}
+	pc := uint32(g.prog.Start)
+	if counts[n][pc].Sign() == 0 {
+		return "", ErrImpossibleLength
+	}
+
+	result := make([]rune, 0, n)
+	l := n
+	var a big.Int
+	for {
+		in := &g.inst[pc]
+		switch in.Op {
+		case syntax.InstMatch:
+			return string(result), nil
+		case syntax.InstRune:
+			// every rune is equally likely, because they are counted as distinct strings.
+			g.mu.Lock()
+			i := g.rand.Int63n(in.runeCount())
+			g.mu.Unlock()
+			result = append(result, in.nthRune(i))
+			pc = in.Out
+			l--
+		case syntax.InstRune1:
+			result = append(result, in.Rune[0])
+			pc = in.Out
+			l--
+		case syntax.InstAlt:
+			g.mu.Lock()
+			a.Rand(g.rand, counts[l][pc])
+			g.mu.Unlock()
+			if a.Cmp(counts[l][in.Out]) < 0 {
+				pc = in.Out
+			} else {
+				pc = in.Arg
+			}
+		default:
+			pc = in.Out
+		}
+	}
+}
+
+// countsLen returns the length-indexed counts up to n.
+func (g *Generator) countsLen(n int) ([][]*big.Int, error) {
+	lc := g.lengthCounts
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for l := len(lc.counts); l <= n; l++ {
+		cnt := make([]*big.Int, len(g.inst))
+		visited := make([]bool, len(g.inst))
+		var loop uint32 // the instruction where the repetition is detected
+		var count func(pc uint32) *big.Int
+		count = func(pc uint32) *big.Int {
+			if cnt[pc] != nil {
+				return cnt[pc]
+			}
+			if visited[pc] {
+				loop = pc
+				panic(ErrTooManyRepeat)
+			}
+			visited[pc] = true
+			in := &g.inst[pc]
+			ret := new(big.Int)
+			switch in.Op {
+			case syntax.InstMatch:
+				if l == 0 {
+					ret.SetInt64(1)
+				}
+			case syntax.InstRune, syntax.InstRune1:
+				if l > 0 {
+					ret.Mul(lc.counts[l-1][in.Out], big.NewInt(in.runeCount()))
+				}
+			case syntax.InstAlt:
+				ret.Add(count(in.Out), count(in.Arg))
+			case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+				ret.Set(count(in.Out))
+			}
+			cnt[pc] = ret
+			return ret
+		}
+
+		err := func() (err error) {
+			defer func() {
+				if e := recover(); e != nil {
+					if e != ErrTooManyRepeat {
+						panic(e)
+					}
+					err = newRepeatError(g.pattern, g.flags, loop, true)
+				}
+			}()
+			for pc := range g.inst {
+				count(uint32(pc))
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		lc.counts = append(lc.counts, cnt)
+	}
+	return lc.counts, nil
+}
+
+// runeCount returns the number of runes that the instruction can generate.
+func (in *myinst) runeCount() int64 {
+	if in.Op == syntax.InstRune1 {
+		return 1
+	}
+	runes := in.runeGenerator.runes
+	if len(runes) == 1 {
+		return 1
+	}
+	var sum int64
+	for i := 0; i < len(runes); i += 2 {
+		sum += int64(runes[i+1] - runes[i] + 1)
+	}
+	return sum
+}
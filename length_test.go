@@ -0,0 +1,107 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestGenerateLen(t *testing.T) {
+	cases := []struct {
+		pattern string
+		n       int
+	}{
+		{`[a-z]{1,16}`, 8},
+		{`\d{2,3}-\d{3,4}-\d{3,4}`, 12},
+		{`abc|de(f|gh)`, 3},
+		{`abc|de(f|gh)`, 4},
+		{`.{0,5}`, 0},
+	}
+	for _, c := range cases {
+		re := regexp.MustCompile(`^(?:` + c.pattern + `)$`)
+		g := Must(New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+		for i := 0; i < 1000; i++ {
+			s, err := g.GenerateLen(c.n)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", c.pattern, err)
+			}
+			if l := utf8.RuneCountInString(s); l != c.n {
+				t.Errorf("%s: want length %d, got %q", c.pattern, c.n, s)
+			}
+			if !re.MatchString(s) {
+				t.Errorf("%s: %q does not match", c.pattern, s)
+			}
+		}
+	}
+}
+
+func TestGenerateLenImpossible(t *testing.T) {
+	g := Must(New(`[a-z]{2,4}`, syntax.Perl, nil))
+	for _, n := range []int{-1, 0, 1, 5} {
+		if _, err := g.GenerateLen(n); err != ErrImpossibleLength {
+			t.Errorf("%d: want ErrImpossibleLength, got %v", n, err)
+		}
+	}
+}
+
+func TestGenerateLenDistribution(t *testing.T) {
+	const N = 100000
+	const AllowError = 2000
+	// strings of length 2 are "aa", "ab", "ba", "bb" and "cc"
+	g := Must(NewDistinctRunes(`[ab]{1,2}|c{2,3}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	count := map[string]int{}
+	for i := 0; i < 5*N; i++ {
+		s, err := g.GenerateLen(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		count[s]++
+	}
+	if len(count) != 5 {
+		t.Errorf("want 5 strings, got %v", count)
+	}
+	for s, c := range count {
+		if c < N-AllowError || c > N+AllowError {
+			t.Errorf("incorrect count of %q(%d)", s, c)
+		}
+	}
+}
+
+func TestGenerateLenUniform(t *testing.T) {
+	const N = 100000
+	// "ab" is one of 676 strings of length 2, and is generated in two ways.
+	g := Must(New(`ab|[a-z]{2}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	var ab int
+	for i := 0; i < N; i++ {
+		s, err := g.GenerateLen(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s == "ab" {
+			ab++
+		}
+	}
+	if want := N * 2 / 677; ab < want/2 || ab > want*2 {
+		t.Errorf("want about %d of %q, got %d", want, "ab", ab)
+	}
+}
+
+func TestGenerateLenTooLong(t *testing.T) {
+	g := Must(New(`[a-z]{2,4}`, syntax.Perl, nil))
+	done := make(chan error, 1)
+	go func() {
+		_, err := g.GenerateLen(2_000_000)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrImpossibleLength {
+			t.Errorf("want ErrImpossibleLength, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("GenerateLen counts the strings beyond the maximum length")
+	}
+}
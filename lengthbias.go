@@ -0,0 +1,97 @@
+package rerand
+
+import (
+	"errors"
+	"math"
+	"regexp/syntax"
+)
+
+// ErrLengthBiasDiverged is returned by New if WithLengthBias favors infinitely long strings.
+var ErrLengthBiasDiverged = errors.New("rerand: length bias diverged")
+
+// WithLengthBias skews the choices of repetitions and alternations toward short or long strings.
+// Each string is weighted by exp(bias*length), so a positive bias favors long strings, and a negative bias favors short strings.
+// For example, with bias 1, a string one rune longer is e times more likely.
+// Large |bias| generates mostly the shortest or the longest strings, which find more bugs than the strings of mid-length.
+// The probability of WithProbability is ignored, and it cannot be used with WithTargetLength.
+// It allows unbounded repetitions such as `*` and `+` with a negative bias,
+// and New fails with ErrLengthBiasDiverged if the bias is too large for them.
+func WithLengthBias(bias float64) Option {
+	return func(o *options) {
+		o.lengthBias = bias
+	}
+}
+
+// lengthBias sets the probabilities of alternations for the length bias.
+// Let F(pc) be the sum of exp(bias*length) of the strings from pc, which is computed in log scale by fixed-point iteration.
+// Each branch is taken with the probability proportional to F(branch).
+func lengthBias(inst []myinst, distinctRunes bool, bias float64) error {
+	const maxIteration = 100000
+	const epsilon = 1e-12
+	const limit = 1e15 // F(pc) > exp(limit) means divergence
+
+	lf := make([]float64, len(inst))
+	for i := range lf {
+		lf[i] = math.Inf(-1)
+	}
+	converged := false
+	for iter := 0; iter < maxIteration && !converged; iter++ {
+		converged = true
+		for i := len(inst) - 1; i >= 0; i-- {
+			in := &inst[i]
+			v := math.Inf(-1)
+			switch in.Op {
+			case syntax.InstMatch:
+				v = 0
+			case syntax.InstRune, syntax.InstRune1:
+				v = lf[in.Out] + bias
+				if distinctRunes {
+					v += math.Log(float64(in.runeCount()))
+				}
+			case syntax.InstAlt:
+				v = logAddExp(lf[in.Out], lf[in.Arg])
+			case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+				v = lf[in.Out]
+			}
+			if v > limit || math.IsNaN(v) {
+				return ErrLengthBiasDiverged
+			}
+			if v != lf[i] && !(math.Abs(v-lf[i]) <= epsilon*math.Max(1, math.Abs(v))) {
+				converged = false
+			}
+			lf[i] = v
+		}
+	}
+	if !converged {
+		return ErrLengthBiasDiverged
+	}
+
+	for i := range inst {
+		in := &inst[i]
+		if in.Op != syntax.InstAlt {
+			continue
+		}
+		in.y = math.MaxInt64
+		in.bigX, in.bigY = nil, nil
+		switch {
+		case math.IsInf(lf[in.Arg], -1):
+			in.x = math.MaxInt64
+		case math.IsInf(lf[in.Out], -1):
+			in.x = 0
+		default:
+			in.x = probability(math.Exp(lf[in.Out] - logAddExp(lf[in.Out], lf[in.Arg])))
+		}
+	}
+	return nil
+}
+
+// logAddExp returns log(exp(a) + exp(b)) without overflow.
+func logAddExp(a, b float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	if math.IsInf(b, -1) {
+		return a
+	}
+	return a + math.Log1p(math.Exp(b-a))
+}
@@ -0,0 +1,79 @@
+package rerand
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestWithLengthBias(t *testing.T) {
+	tests := []struct {
+		bias float64
+		want int // the length generated the most
+	}{
+		{-10, 1},
+		{10, 8},
+	}
+	for _, tt := range tests {
+		g := Must(New(`[a-z]{1,8}`, syntax.Perl, rand.New(rand.NewSource(1)), WithLengthBias(tt.bias)))
+		counts := map[int]int{}
+		for i := 0; i < 1000; i++ {
+			counts[len(g.Generate())]++
+		}
+		if counts[tt.want] < 990 {
+			t.Errorf("%g: want mostly length %d, got %v", tt.bias, tt.want, counts)
+		}
+	}
+}
+
+func TestWithLengthBiasRatio(t *testing.T) {
+	// "ab" is e times more likely than "a".
+	g := Must(New(`ab?`, syntax.Perl, rand.New(rand.NewSource(1)), WithLengthBias(1)))
+	counts := map[string]int{}
+	const n = 10000
+	for i := 0; i < n; i++ {
+		counts[g.Generate()]++
+	}
+	want := n * math.E / (1 + math.E)
+	if math.Abs(float64(counts["ab"])-want) > 200 {
+		t.Errorf("want about %.0f, got %d", want, counts["ab"])
+	}
+}
+
+func TestWithLengthBiasUnbounded(t *testing.T) {
+	g := Must(New(`x[a-c]*`, syntax.Perl, nil, WithLengthBias(-2), WithDistinctRunes()))
+	re := regexp.MustCompile(`^x[a-c]*$`)
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Fatalf("%q does not match", s)
+		}
+	}
+
+	if _, err := New(`x[a-c]*`, syntax.Perl, nil, WithLengthBias(2)); !errors.Is(err, ErrLengthBiasDiverged) {
+		t.Errorf("want ErrLengthBiasDiverged, got %v", err)
+	}
+}
+
+func TestWithLengthBiasLong(t *testing.T) {
+	// exp(bias*length) overflows float64, but the probabilities are computed in log scale.
+	g := Must(New(`.{0,1000}`, syntax.Perl, nil, WithLengthBias(5), WithDistinctRunes()))
+	if n := len([]rune(g.Generate())); n < 990 {
+		t.Errorf("want about 1000 runes, got %d", n)
+	}
+}
+
+func TestWithLengthBiasInvalid(t *testing.T) {
+	opts := [][]Option{
+		{WithLengthBias(math.NaN())},
+		{WithLengthBias(math.Inf(1))},
+		{WithLengthBias(1), WithTargetLength(3)},
+	}
+	for _, opt := range opts {
+		if _, err := New(`a+`, syntax.Perl, nil, opt...); err == nil {
+			t.Error("want error, got nil")
+		}
+	}
+}
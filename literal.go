@@ -0,0 +1,36 @@
+package rerand
+
+import "regexp/syntax"
+
+// setLiterals merges the runs of InstRune1 into literals,
+// so that the generator appends them at once.
+// The literal of an instruction shares the backing array with the literal of the head of its run.
+func setLiterals(inst []myinst) {
+	done := make([]bool, len(inst))
+	var pcs []uint32
+	var runes []rune
+	for pc := range inst {
+		if inst[pc].Op != syntax.InstRune1 || done[pc] {
+			continue
+		}
+		pcs, runes = pcs[:0], runes[:0]
+		next := uint32(pc)
+		for inst[next].Op == syntax.InstRune1 && !done[next] {
+			done[next] = true
+			pcs = append(pcs, next)
+			runes = append(runes, inst[next].Rune[0])
+			next = inst[next].Out
+		}
+		if inst[next].Op == syntax.InstRune1 {
+			// the rest of the run is already merged.
+			runes = append(runes, inst[next].literal...)
+			next = inst[next].literalOut
+		}
+		literal := make([]rune, len(runes))
+		copy(literal, runes)
+		for i, pc := range pcs {
+			inst[pc].literal = literal[i:]
+			inst[pc].literalOut = next
+		}
+	}
+}
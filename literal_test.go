@@ -0,0 +1,55 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestLiteral(t *testing.T) {
+	patterns := []string{
+		`https://example\.com/[a-z]{3}`,
+		`(abc|abd){1,3}xyz`,
+		`a(bc)?d(ef){0,3}g`,
+		`(?i)abc`,
+		`literal`,
+	}
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+		g := Must(New(pattern, syntax.Perl, rand.New(rand.NewSource(1))))
+		for i := 0; i < 100; i++ {
+			if s := g.Generate(); !re.MatchString(s) {
+				t.Errorf("%s: %q does not match", pattern, s)
+			}
+		}
+	}
+}
+
+func TestSetLiterals(t *testing.T) {
+	g := Must(New(`abc(d|e)fg`, syntax.Perl, nil))
+	var literals []string
+	for pc, in := range g.inst {
+		if in.Op != syntax.InstRune1 {
+			continue
+		}
+		if g.inst[in.literalOut].Op == syntax.InstRune1 {
+			t.Errorf("%d: the run does not end at %d", pc, in.literalOut)
+		}
+		literals = append(literals, string(in.literal))
+	}
+	want := map[string]bool{"abc": true, "bc": true, "c": true, "fg": true, "g": true}
+	for _, l := range literals {
+		if !want[l] {
+			t.Errorf("unexpected literal %q", l)
+		}
+	}
+}
+
+func BenchmarkLiteral(b *testing.B) {
+	g := Must(New(`https://www\.example\.com/path/to/[a-z]{8}\.html`, syntax.Perl, rand.New(rand.NewSource(1))))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
@@ -0,0 +1,27 @@
+package rerand
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger reports the diagnostics of Generator to l, e.g. the failures of verification and filters.
+// Without WithLogger, nothing is logged.
+func WithLogger(l *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
+
+// log reports the diagnostic to the logger of WithLogger.
+func (g *Generator) log(level slog.Level, msg string, args ...any) {
+	l := g.opts.logger
+	if l == nil {
+		return
+	}
+	ctx := context.Background()
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	l.Log(ctx, level, msg, append([]any{slog.String("pattern", g.pattern)}, args...)...)
+}
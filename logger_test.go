@@ -0,0 +1,50 @@
+package rerand
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	g := Must(New(`[ab]{3}`, syntax.Perl, nil, WithLogger(logger), WithFilter(func(s string) bool {
+		return false
+	}, 2)))
+	if _, err := g.TryGenerate(); err == nil {
+		t.Fatal("want error, got nil")
+	}
+	out := buf.String()
+	if strings.Count(out, "level=DEBUG") != 2 || strings.Count(out, "level=WARN") != 1 {
+		t.Errorf("unexpected log: %s", out)
+	}
+	if !strings.Contains(out, `pattern=[ab]{3}`) || !strings.Contains(out, "attempts=2") {
+		t.Errorf("unexpected log: %s", out)
+	}
+
+	buf.Reset()
+	g = Must(New(`abc`, syntax.Perl, nil, WithLogger(logger), WithVerify()))
+	g.code[0].literal = []rune("xbc") // break the generator on purpose
+	if _, err := g.TryGenerate(); err == nil {
+		t.Fatal("want error, got nil")
+	}
+	if out := buf.String(); !strings.Contains(out, "level=ERROR") || !strings.Contains(out, "output=xbc") {
+		t.Errorf("unexpected log: %s", out)
+	}
+}
+
+func TestWithLoggerSuccess(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	g := Must(New(`[ab]{3}`, syntax.Perl, nil, WithLogger(logger), WithVerify()))
+	for i := 0; i < 100; i++ {
+		g.Generate()
+	}
+	if buf.Len() != 0 {
+		t.Errorf("want no log, got %s", buf.String())
+	}
+}
@@ -0,0 +1,289 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp/syntax"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// textPrefix begins the options in the text form of Generator.
+// It is never a valid pattern, so the text form is not ambiguous.
+const textPrefix = "(?rerand:"
+
+// MarshalText implements encoding.TextMarshaler.
+// The text is the pattern if the flags are syntax.Perl and no option is given.
+// Otherwise, the flags and options are prepended in the form of `(?rerand:key=value&...)pattern`,
+// e.g. `(?rerand:distinct=true&target=10)[a-z]+`.
+// It fails if g has options that cannot be represented in text, e.g. WithFilter, or if g is created by Union.
+func (g *Generator) MarshalText() ([]byte, error) {
+	if g.weighted {
+		return nil, errors.New("rerand: the generator created by Union cannot be marshaled")
+	}
+	text, err := g.opts.text(g.pattern, g.flags)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(text), nil
+}
+
+// text returns the text form of the pattern compiled with the flags and o.
+func (o *options) text(pattern string, flags syntax.Flags) (string, error) {
+	if len(o.filters) > 0 || len(o.transforms) > 0 || len(o.runeSources) > 0 || len(o.dependencies) > 0 ||
+		len(o.hooks) > 0 || o.logger != nil || o.encoding != nil || o.bytes {
+		return "", errors.New("rerand: the options cannot be marshaled")
+	}
+
+	v := url.Values{}
+	if flags != syntax.Perl {
+		v.Set("flags", strconv.Itoa(int(flags)))
+	}
+	setBool := func(key string, b bool) {
+		if b {
+			v.Set(key, "true")
+		}
+	}
+	setInt := func(key string, n int64) {
+		if n != 0 {
+			v.Set(key, strconv.FormatInt(n, 10))
+		}
+	}
+	setBool("distinct", o.distinctRunes)
+	setInt("prob", o.prob)
+	setInt("stream", int64(o.streamVersion))
+	if o.targetLength != 0 {
+		v.Set("target", strconv.FormatFloat(o.targetLength, 'g', -1, 64))
+	}
+	if o.lengthBias != 0 {
+		v.Set("lengthbias", strconv.FormatFloat(o.lengthBias, 'g', -1, 64))
+	}
+	setBool("verify", o.verify)
+	setBool("uniform", o.uniformAlternation)
+	setBool("graphemes", o.graphemeClusters)
+	if len(o.excludedRunes) > 0 {
+		v.Set("exclude", string(o.excludedRunes))
+	}
+	for _, class := range o.requiredClasses {
+		v.Add("require", string(class))
+	}
+	setInt("maxlen", int64(o.maxLength))
+	if o.maxLengthPolicy == MaxLengthTruncate {
+		v.Set("maxlenpolicy", "truncate")
+	}
+	setInt("expected", int64(o.expectedLength))
+	setBool("nopool", o.withoutPool)
+	setBool("nonempty", o.nonEmpty)
+	setBool("strictquantifiers", o.strictQuantifiers)
+	setInt("ceiling", int64(o.runeCeiling))
+	setBool("pua", o.privateUseArea)
+	setInt("budget", o.compileBudget)
+	setInt("intern", int64(o.internLimit))
+	for _, opt := range o.continueProbs {
+		v.Add("continue", strconv.Itoa(opt.group)+":"+strconv.FormatFloat(opt.prob, 'g', -1, 64))
+	}
+	if o.caseMode != CaseAsPattern {
+		v.Set("case", caseModeNames[o.caseMode])
+	}
+	if o.framing != FramingSeparator {
+		v.Set("framing", framingNames[o.framing])
+	}
+	if o.filler != nil {
+		v.Set("filler", string(o.filler))
+	}
+
+	if len(v) == 0 {
+		return pattern, nil
+	}
+	return textPrefix + v.Encode() + ")" + pattern, nil
+}
+
+// caseModeNames are the names of CaseMode in the text form.
+var caseModeNames = []string{"pattern", "lower", "upper", "mixed"}
+
+// framingNames are the names of Framing in the text form.
+var framingNames = []string{"separator", "nul", "crlf", "length"}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts the text form of MarshalText, and compiles it into g with the random number generator seeded with current time.
+// It must not be called while g is used.
+func (g *Generator) UnmarshalText(text []byte) error {
+	pattern, flags, opts, err := parseText(string(text))
+	if err != nil {
+		return err
+	}
+	ng, err := newGenerator(pattern, flags, nil, opts)
+	if err != nil {
+		return err
+	}
+	g.pattern = ng.pattern
+	g.flags = ng.flags
+	g.opts = ng.opts
+	g.re = ng.re
+	g.prog = ng.prog
+	g.inst = ng.inst
+	g.code = ng.code
+	g.min, g.max = ng.min, ng.max
+	g.runes = ng.runes
+	g.weighted = ng.weighted
+	g.rand = ng.rand
+	g.src = ng.src
+	g.lengthCounts = ng.lengthCounts
+	g.verifier = ng.verifier
+	g.interned = ng.interned
+	g.matcherOnce = sync.Once{}
+	g.matcherRe = nil
+	return nil
+}
+
+// parseText parses the text form of Generator.
+func parseText(text string) (pattern string, flags syntax.Flags, opts []Option, err error) {
+	if !strings.HasPrefix(text, textPrefix) {
+		return text, syntax.Perl, nil, nil
+	}
+	query, pattern, ok := strings.Cut(text[len(textPrefix):], ")")
+	if !ok {
+		return "", 0, nil, errors.New("rerand: missing ) of the options")
+	}
+	v, err := url.ParseQuery(query)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("rerand: invalid options: %w", err)
+	}
+
+	flags = syntax.Perl
+	for key, values := range v {
+		value := values[len(values)-1]
+		var opt Option
+		switch key {
+		case "flags":
+			var n int
+			n, err = strconv.Atoi(value)
+			flags = syntax.Flags(n)
+		case "distinct":
+			opt, err = boolOption(value, WithDistinctRunes)
+		case "prob":
+			var n int64
+			n, err = strconv.ParseInt(value, 10, 64)
+			opt = WithProbability(n)
+		case "stream":
+			var n int
+			n, err = strconv.Atoi(value)
+			opt = WithStreamVersion(StreamVersion(n))
+		case "target":
+			var n float64
+			n, err = strconv.ParseFloat(value, 64)
+			opt = WithTargetLength(n)
+		case "lengthbias":
+			var n float64
+			n, err = strconv.ParseFloat(value, 64)
+			opt = WithLengthBias(n)
+		case "verify":
+			opt, err = boolOption(value, WithVerify)
+		case "uniform":
+			opt, err = boolOption(value, WithUniformAlternation)
+		case "graphemes":
+			opt, err = boolOption(value, WithGraphemeClusters)
+		case "exclude":
+			excluded := []rune(value)
+			opt = func(o *options) {
+				o.excludedRunes = append(o.excludedRunes, excluded...)
+			}
+		case "require":
+			classes := make([][]rune, len(values))
+			for i, class := range values {
+				classes[i] = []rune(class)
+			}
+			opt = WithRequireClasses(classes...)
+		case "maxlen":
+			var n int
+			n, err = strconv.Atoi(value)
+			opt = func(o *options) {
+				o.maxLength = n
+			}
+		case "maxlenpolicy":
+			if value != "truncate" {
+				err = fmt.Errorf("unknown policy %q", value)
+			}
+			opt = func(o *options) {
+				o.maxLengthPolicy = MaxLengthTruncate
+			}
+		case "expected":
+			var n int
+			n, err = strconv.Atoi(value)
+			opt = WithExpectedLength(n)
+		case "nopool":
+			opt, err = boolOption(value, WithoutPool)
+		case "strictquantifiers":
+			opt, err = boolOption(value, WithStrictQuantifiers)
+		case "nonempty":
+			opt, err = boolOption(value, WithNonEmpty)
+		case "ceiling":
+			var n int64
+			n, err = strconv.ParseInt(value, 10, 32)
+			opt = WithRuneCeiling(rune(n))
+		case "budget":
+			var n int64
+			n, err = strconv.ParseInt(value, 10, 64)
+			opt = WithCompileBudget(n)
+		case "intern":
+			var n int
+			n, err = strconv.Atoi(value)
+			opt = WithInterning(n)
+		case "pua":
+			opt, err = boolOption(value, func() Option { return WithPrivateUseArea(true) })
+		case "continue":
+			probs := make([]continueProbOption, len(values))
+			for i, value := range values {
+				group, prob, ok := strings.Cut(value, ":")
+				if !ok {
+					err = errors.New("missing :")
+					break
+				}
+				if probs[i].group, err = strconv.Atoi(group); err != nil {
+					break
+				}
+				if probs[i].prob, err = strconv.ParseFloat(prob, 64); err != nil {
+					break
+				}
+			}
+			opt = func(o *options) {
+				o.continueProbs = append(o.continueProbs, probs...)
+			}
+		case "case":
+			mode := CaseMode(slices.Index(caseModeNames, value))
+			if mode < 0 {
+				err = fmt.Errorf("unknown case mode %q", value)
+			}
+			opt = WithCase(mode)
+		case "framing":
+			f := Framing(slices.Index(framingNames, value))
+			if f < 0 {
+				err = fmt.Errorf("unknown framing %q", value)
+			}
+			opt = WithFraming(f)
+		case "filler":
+			opt = WithFiller(value)
+		default:
+			err = errors.New("unknown option")
+		}
+		if err != nil {
+			return "", 0, nil, fmt.Errorf("rerand: invalid option %q: %w", key, err)
+		}
+		if opt != nil {
+			opts = append(opts, opt)
+		}
+	}
+	return pattern, flags, opts, nil
+}
+
+// boolOption returns the option if value is true.
+func boolOption(value string, option func() Option) (Option, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil || !b {
+		return nil, err
+	}
+	return option(), nil
+}
@@ -0,0 +1,117 @@
+package rerand
+
+import (
+	"encoding/json"
+	"regexp/syntax"
+	"slices"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		g    *Generator
+		want string
+	}{
+		{Must(New(`[a-z]{8}`, syntax.Perl, nil)), `[a-z]{8}`},
+		{Must(New(`a{3}`, syntax.POSIX, nil)), `(?rerand:flags=0)a{3}`},
+		{Must(NewDistinctRunes(`[a-z]+`, syntax.Perl, nil, WithTargetLength(10))), `(?rerand:distinct=true&target=10)[a-z]+`},
+		{
+			Must(New(`\w{8}`, syntax.Perl, nil, WithoutAmbiguousRunes(), WithRequireClasses([]rune("0123456789")), WithMaxLength(5, MaxLengthTruncate))),
+			`(?rerand:exclude=01IOl%7Co&maxlen=5&maxlenpolicy=truncate&require=0123456789)\w{8}`,
+		},
+		{Must(New(`\d{3}`, syntax.Perl, nil, WithFiller("xy"), WithNonEmpty())), `(?rerand:filler=xy&nonempty=true)\d{3}`},
+		{Must(New(`.`, syntax.Perl, nil, WithRuneCeiling(0xFFFF), WithPrivateUseArea(true))), `(?rerand:ceiling=65535&pua=true).`},
+		{Must(New(`(?i)abc`, syntax.Perl, nil, WithCase(CaseLower))), `(?rerand:case=lower)(?i)abc`},
+		{Must(New(`abc`, syntax.Perl, nil, WithFraming(FramingNUL))), `(?rerand:framing=nul)abc`},
+		{Must(New(`abc`, syntax.Perl, nil, WithCompileBudget(1<<20))), `(?rerand:budget=1048576)abc`},
+		{Must(New(`a|b`, syntax.Perl, nil, WithInterning(10))), `(?rerand:intern=10)a|b`},
+		{
+			Must(New(`(a)?(b){0,3}`, syntax.Perl, nil, WithQuantifierContinueProbability(1, 0.1), WithQuantifierContinueProbability(2, 0.5))),
+			`(?rerand:continue=1%3A0.1&continue=2%3A0.5)(a)?(b){0,3}`,
+		},
+	}
+	for _, tt := range tests {
+		text, err := tt.g.MarshalText()
+		if err != nil {
+			t.Errorf("%s: %v", tt.g, err)
+			continue
+		}
+		if string(text) != tt.want {
+			t.Errorf("want %s, got %s", tt.want, text)
+		}
+
+		var g Generator
+		if err := g.UnmarshalText(text); err != nil {
+			t.Errorf("%s: %v", text, err)
+			continue
+		}
+		if g.pattern != tt.g.pattern || g.flags != tt.g.flags {
+			t.Errorf("%s: want %s, %d, got %s, %d", text, tt.g.pattern, tt.g.flags, g.pattern, g.flags)
+		}
+		if g.opts.distinctRunes != tt.g.opts.distinctRunes || g.opts.targetLength != tt.g.opts.targetLength ||
+			!slices.Equal(g.opts.excludedRunes, tt.g.opts.excludedRunes) || len(g.opts.requiredClasses) != len(tt.g.opts.requiredClasses) ||
+			g.opts.maxLength != tt.g.opts.maxLength || g.opts.maxLengthPolicy != tt.g.opts.maxLengthPolicy ||
+			!slices.Equal(g.opts.filler, tt.g.opts.filler) || g.opts.nonEmpty != tt.g.opts.nonEmpty ||
+			g.opts.runeCeiling != tt.g.opts.runeCeiling || g.opts.privateUseArea != tt.g.opts.privateUseArea ||
+			g.opts.caseMode != tt.g.opts.caseMode || !slices.Equal(g.opts.continueProbs, tt.g.opts.continueProbs) {
+			t.Errorf("%s: the options differ", text)
+		}
+		g.Generate()
+	}
+}
+
+func TestMarshalTextError(t *testing.T) {
+	gens := []*Generator{
+		Must(New(`a`, syntax.Perl, nil, WithFilter(func(string) bool { return true }, 0))),
+		Must(Union([]float64{1, 2}, Must(New(`a`, syntax.Perl, nil)), Must(New(`b`, syntax.Perl, nil)))),
+	}
+	for _, g := range gens {
+		if _, err := g.MarshalText(); err == nil {
+			t.Errorf("%s: want error, got nil", g)
+		}
+	}
+}
+
+func TestUnmarshalTextError(t *testing.T) {
+	texts := []string{
+		`[a-z`,
+		`(?rerand:distinct=true[a-z]`,
+		`(?rerand:unknown=1)a`,
+		`(?rerand:target=x)a`,
+		`(?rerand:maxlenpolicy=x)a`,
+		`(?rerand:maxlen=-1)a`,
+		`(?rerand:case=title)a`,
+		`(?rerand:framing=tlv)a`,
+		`(?rerand:continue=1)(a)?`,
+		`(?rerand:continue=x:1)(a)?`,
+	}
+	for _, text := range texts {
+		var g Generator
+		if err := g.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("%s: want error, got nil", text)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var config struct {
+		ID   *Generator `json:"id"`
+		Name Generator  `json:"name"`
+	}
+	err := json.Unmarshal([]byte(`{"id": "\\d{8}", "name": "(?rerand:uniform=true)alice|bob"}`), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ID.String() != `\d{8}` || config.Name.String() != `alice|bob` || !config.Name.opts.uniformAlternation {
+		t.Errorf("unexpected config: %s, %s", config.ID, &config.Name)
+	}
+	config.ID.Generate()
+
+	data, err := json.Marshal(map[string]*Generator{"id": config.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":"\\d{8}"}`; string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+}
@@ -0,0 +1,66 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+)
+
+// ErrTooLong is the error wrapped by *TooLongError.
+var ErrTooLong = errors.New("rerand: the generated string is too long")
+
+// TooLongError is returned if the generated string exceeds the maximum length of WithMaxLength.
+type TooLongError struct {
+	// MaxLength is the maximum length in runes.
+	MaxLength int
+}
+
+func (e *TooLongError) Error() string {
+	return fmt.Sprintf("rerand: the generated string exceeds %d runes", e.MaxLength)
+}
+
+// Unwrap returns ErrTooLong.
+func (e *TooLongError) Unwrap() error {
+	return ErrTooLong
+}
+
+// MaxLengthPolicy is the policy for the strings that exceed the maximum length of WithMaxLength.
+type MaxLengthPolicy int
+
+const (
+	// MaxLengthError fails the generation with *TooLongError.
+	MaxLengthError MaxLengthPolicy = iota
+
+	// MaxLengthTruncate truncates the string to the maximum length.
+	// The truncated strings may not match the pattern.
+	MaxLengthTruncate
+)
+
+// WithMaxLength stops the generation as soon as the string exceeds n runes, and handles it by policy.
+// It guards against the patterns that may generate huge strings, e.g. with WithTargetLength.
+// It takes effect in Generate and its variants, e.g. TryGenerate and AppendString.
+func WithMaxLength(n int, policy MaxLengthPolicy) Option {
+	return func(o *options) {
+		o.maxLength = n
+		o.maxLengthPolicy = policy
+	}
+}
+
+// lengthLimit returns the length of the buffer that the generation must not exceed,
+// if it begins with the buffer of length start.
+func (g *Generator) lengthLimit(start int) int {
+	if g.opts.maxLength == 0 || start > math.MaxInt-g.opts.maxLength {
+		return math.MaxInt
+	}
+	return start + g.opts.maxLength
+}
+
+// tooLong handles the buffer that exceeds limit by the policy of WithMaxLength.
+func (g *Generator) tooLong(result []rune, limit int) ([]rune, error) {
+	if g.opts.maxLengthPolicy == MaxLengthTruncate {
+		return result[:limit], nil
+	}
+	g.log(slog.LevelWarn, "rerand: the generated string is too long", slog.Int("max_length", g.opts.maxLength))
+	return result, &TooLongError{MaxLength: g.opts.maxLength}
+}
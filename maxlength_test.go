@@ -0,0 +1,68 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWithMaxLength(t *testing.T) {
+	g := Must(New(`a{20}`, syntax.Perl, nil, WithMaxLength(10, MaxLengthError)))
+	_, err := g.TryGenerate()
+	var lerr *TooLongError
+	if !errors.As(err, &lerr) || !errors.Is(err, ErrTooLong) {
+		t.Fatalf("want *TooLongError, got %v", err)
+	}
+	if lerr.MaxLength != 10 {
+		t.Errorf("want 10, got %d", lerr.MaxLength)
+	}
+	if _, err := g.tryAppendString(nil); !errors.Is(err, ErrTooLong) {
+		t.Errorf("want ErrTooLong, got %v", err)
+	}
+
+	g = Must(New(`a{10}`, syntax.Perl, nil, WithMaxLength(10, MaxLengthError)))
+	if s, err := g.TryGenerate(); err != nil || s != "aaaaaaaaaa" {
+		t.Errorf("want aaaaaaaaaa, got %q, %v", s, err)
+	}
+}
+
+func TestWithMaxLengthTruncate(t *testing.T) {
+	g := Must(New(`[a-z]{5}[0-9]{10}`, syntax.Perl, nil, WithMaxLength(8, MaxLengthTruncate)))
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); len(s) != 8 {
+			t.Errorf("want 8 runes, got %q", s)
+		}
+		if s := g.AppendString([]byte("prefix")); len(s) != 14 {
+			t.Errorf("want 14 bytes, got %q", s)
+		}
+		if s := g.AppendRunes([]rune("xy")); len(s) != 10 {
+			t.Errorf("want 10 runes, got %q", string(s))
+		}
+	}
+}
+
+func TestWithMaxLengthUnbounded(t *testing.T) {
+	g := Must(New(`[a-z]*`, syntax.Perl, nil, WithTargetLength(100), WithMaxLength(50, MaxLengthTruncate)))
+	for i := 0; i < 1000; i++ {
+		if s := g.Generate(); utf8.RuneCountInString(s) > 50 {
+			t.Fatalf("want at most 50 runes, got %d", utf8.RuneCountInString(s))
+		}
+	}
+}
+
+func TestWithMaxLengthRequireClasses(t *testing.T) {
+	g := Must(New(`[a-z0-9]{20}`, syntax.Perl, nil, WithRequireClasses([]rune("0123456789")), WithMaxLength(10, MaxLengthError)))
+	if _, err := g.TryGenerate(); !errors.Is(err, ErrTooLong) {
+		t.Errorf("want ErrTooLong, got %v", err)
+	}
+}
+
+func TestWithMaxLengthInvalid(t *testing.T) {
+	if _, err := New(`a`, syntax.Perl, nil, WithMaxLength(-1, MaxLengthError)); err == nil {
+		t.Error("want error, got nil")
+	}
+	if _, err := New(`a`, syntax.Perl, nil, WithMaxLength(1, MaxLengthPolicy(-1))); err == nil {
+		t.Error("want error, got nil")
+	}
+}
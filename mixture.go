@@ -0,0 +1,60 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+	"slices"
+	"strings"
+)
+
+// NewMixture returns new Generator that chooses one of the patterns with the probability proportional to its weight,
+// and generates the string matching the pattern.
+// The options are applied to the mixture, e.g. WithFilter filters the strings of all patterns.
+func NewMixture(weights map[string]float64, flags syntax.Flags, r *rand.Rand, opts ...Option) (*Generator, error) {
+	if len(weights) == 0 {
+		return nil, errors.New("rerand: no patterns")
+	}
+
+	// sort the patterns, so that the result does not depend on the order of map iteration.
+	patterns := make([]string, 0, len(weights))
+	for p := range weights {
+		patterns = append(patterns, p)
+	}
+	slices.Sort(patterns)
+	ws := make([]float64, len(patterns))
+	for i, p := range patterns {
+		ws[i] = weights[p]
+	}
+	intWeights, err := scaleWeights(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	var subs []*syntax.Regexp
+	var chosen []string
+	var chosenWeights []int64
+	var offset int
+	for i, p := range patterns {
+		re, err := syntax.Parse(p, flags)
+		if err != nil {
+			return nil, err
+		}
+		if intWeights[i] == 0 {
+			continue
+		}
+		subs = append(subs, renumberCaps(re, offset))
+		offset += re.MaxCap()
+		chosen = append(chosen, p)
+		chosenWeights = append(chosenWeights, intWeights[i])
+	}
+	if len(subs) == 1 {
+		return compileGenerator(subs[0], chosen[0], flags, r, opts, nil)
+	}
+	re := &syntax.Regexp{
+		Op:  syntax.OpAlternate,
+		Sub: subs,
+	}
+	pattern := "(?:" + strings.Join(chosen, ")|(?:") + ")"
+	return compileGenerator(re, pattern, flags, r, opts, chosenWeights)
+}
@@ -0,0 +1,75 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewMixture(t *testing.T) {
+	weights := map[string]float64{
+		`ID[0-9]{6}`:   90,
+		`LEGACY-\d{4}`: 9,
+		`[a-z]{1,3}`:   1,
+		`never`:        0,
+	}
+	g := Must(NewMixture(weights, syntax.Perl, rand.New(rand.NewSource(1))))
+	counts := map[string]int{}
+	const n = 10000
+	for i := 0; i < n; i++ {
+		s := g.Generate()
+		switch {
+		case strings.HasPrefix(s, "ID"):
+			counts["id"]++
+		case strings.HasPrefix(s, "LEGACY-"):
+			counts["legacy"]++
+		case s == "never":
+			t.Fatal("the pattern of weight 0 is chosen")
+		default:
+			counts["junk"]++
+		}
+	}
+	if counts["id"] < n*87/100 || counts["id"] > n*93/100 {
+		t.Errorf("want about 90%% ids, got %v", counts)
+	}
+	if counts["legacy"] < n*7/100 || counts["legacy"] > n*11/100 {
+		t.Errorf("want about 9%% legacy ids, got %v", counts)
+	}
+	if counts["junk"] == 0 {
+		t.Errorf("want some junk, got %v", counts)
+	}
+}
+
+func TestNewMixtureReproducible(t *testing.T) {
+	weights := map[string]float64{`a[0-9]`: 1, `b[0-9]`: 1, `c[0-9]`: 1}
+	generate := func() []string {
+		g := Must(NewMixture(weights, syntax.Perl, rand.New(rand.NewSource(1))))
+		ret := make([]string, 20)
+		for i := range ret {
+			ret[i] = g.Generate()
+		}
+		return ret
+	}
+	if a, b := generate(), generate(); !slices.Equal(a, b) {
+		t.Errorf("want same strings, got %v and %v", a, b)
+	}
+}
+
+func TestNewMixtureError(t *testing.T) {
+	for _, weights := range []map[string]float64{
+		{},
+		{`a`: 0},
+		{`a`: -1},
+		{`(`: 1},
+	} {
+		if _, err := NewMixture(weights, syntax.Perl, nil); err == nil {
+			t.Errorf("%v: want error, got nil", weights)
+		}
+	}
+	g := Must(NewMixture(map[string]float64{`a`: 0, `b+?c`: 1}, syntax.Perl, nil, WithTargetLength(3)))
+	if s := g.Generate(); !strings.HasPrefix(s, "b") {
+		t.Errorf("want b+c, got %q", s)
+	}
+}
@@ -0,0 +1,108 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+)
+
+// ErrNotGenerated is returned if the string is never generated by the Generator.
+var ErrNotGenerated = errors.New("rerand: the string is not generated by the pattern")
+
+// Mutate applies one to budget mutations to s, and returns the mutated string that is still generated by g.
+// A mutation re-rolls a rune of a character class, or flips a choice of InstAlt,
+// which changes the count of a repetition or the branch of an alternation.
+// After flipping, the rest of the string is kept as much as possible, and the missing parts are generated randomly.
+// It returns ErrNotGenerated if g never generates s.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) Mutate(s string, budget int) (string, error) {
+	if budget < 0 {
+		return "", errors.New("rerand: negative budget")
+	}
+	steps, ok := g.parse(s)
+	if !ok {
+		return "", ErrNotGenerated
+	}
+	if budget == 0 || len(steps) == 0 {
+		return s, nil
+	}
+
+	g.mu.Lock()
+	n := 1 + g.rand.Intn(budget)
+	g.mu.Unlock()
+	live := g.reachable()
+	for i := 0; i < n && len(steps) > 0; i++ {
+		g.mu.Lock()
+		k := g.rand.Intn(len(steps))
+		g.mu.Unlock()
+		in := &g.inst[steps[k].pc]
+		switch in.Op {
+		case syntax.InstRune:
+			steps[k].choice = in.generateRune(g.rand, &g.mu)
+		case syntax.InstAlt:
+			steps[k].choice = 1 - steps[k].choice
+		}
+		s, steps = g.mutateReplay(steps, live)
+	}
+	return s, nil
+}
+
+// mutateReplay generates the string from the choices.
+// If a choice does not fit the program, the choices are skipped to the next one that fits.
+// If the choices run out, the rest is generated randomly.
+// live is the instructions that may be executed, and the branches to the others are never taken.
+func (g *Generator) mutateReplay(choices []step, live []bool) (string, []step) {
+	var result []rune
+	var steps []step
+	pc := uint32(g.prog.Start)
+	for {
+		in := &g.inst[pc]
+		if in.Op == syntax.InstRune || in.Op == syntax.InstAlt {
+			// resynchronize the choices
+			for len(choices) > 0 && choices[0].pc != pc {
+				choices = choices[1:]
+			}
+		}
+		switch in.Op {
+		case syntax.InstMatch:
+			return string(result), steps
+		case syntax.InstRune1:
+			result = append(result, in.Rune[0])
+			pc = in.Out
+		case syntax.InstRune:
+			var r rune
+			if len(choices) > 0 {
+				r = choices[0].choice
+				choices = choices[1:]
+			} else {
+				r = in.generateRune(g.rand, &g.mu)
+			}
+			result = append(result, r)
+			steps = append(steps, step{pc: pc, choice: r})
+			pc = in.Out
+		case syntax.InstAlt:
+			var choice rune
+			if len(choices) > 0 {
+				choice = choices[0].choice
+				choices = choices[1:]
+			} else if !in.alt(g.rand, &g.mu) {
+				choice = 1
+			}
+			// never take the dead branches, e.g. pruned by WithNonEmpty.
+			if choice == 0 && !live[in.Out] {
+				choice = 1
+			} else if choice == 1 && !live[in.Arg] {
+				choice = 0
+			}
+			steps = append(steps, step{pc: pc, choice: choice})
+			if choice == 0 {
+				pc = in.Out
+			} else {
+				pc = in.Arg
+			}
+		case syntax.InstFail:
+			panic("rerand: the replay of Mutate reached InstFail")
+		default:
+			pc = in.Out
+		}
+	}
+}
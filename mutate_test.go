@@ -0,0 +1,64 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestMutate(t *testing.T) {
+	patterns := []string{
+		`\d{3}-\d{4}`,
+		`(foo|bar|baz)-[a-z]{1,5}`,
+		`(?:[a-z]{2,4}\.){1,3}com`,
+	}
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+		g := Must(New(pattern, syntax.Perl, nil))
+		s := g.Generate()
+		var changed int
+		for i := 0; i < 100; i++ {
+			m, err := g.Mutate(s, 3)
+			if err != nil {
+				t.Fatalf("%s: %v", pattern, err)
+			}
+			if !re.MatchString(m) {
+				t.Errorf("%s: %q does not match", pattern, m)
+			}
+			if m != s {
+				changed++
+			}
+			s = m
+		}
+		if changed < 50 {
+			t.Errorf("%s: want mutations, got %d changes", pattern, changed)
+		}
+	}
+}
+
+func TestMutateError(t *testing.T) {
+	g := Must(New(`\d{3}`, syntax.Perl, nil))
+	if _, err := g.Mutate("abc", 1); !errors.Is(err, ErrNotGenerated) {
+		t.Errorf("want ErrNotGenerated, got %v", err)
+	}
+	if _, err := g.Mutate("123", -1); err == nil {
+		t.Error("want error, got nil")
+	}
+	if s, err := g.Mutate("123", 0); err != nil || s != "123" {
+		t.Errorf("want 123, got %q, %v", s, err)
+	}
+}
+
+func TestMutateNonEmpty(t *testing.T) {
+	g := Must(New(`a?`, syntax.Perl, nil, WithNonEmpty()))
+	for i := 0; i < 100; i++ {
+		s, err := g.Mutate("a", 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != "a" {
+			t.Errorf("want %q, got %q", "a", s)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+)
+
+// ErrNoNearMiss is returned by GenerateNearMiss if no near miss is found,
+// e.g. the pattern matches any strings.
+var ErrNoNearMiss = errors.New("rerand: no near miss is found")
+
+// nearMissRunes are the candidates of the runes that replace a rune.
+var nearMissRunes = []rune{'a', 'Z', '0', '9', ' ', '-', '_', '.', '@', '/', '!', '\n', 'あ', 'é'}
+
+// GenerateNearMiss generates a string that does not match the pattern,
+// but is at edit distance 1 from a matching string.
+// It replaces a rune with the one out of the character class, deletes a rune,
+// or duplicates a rune, e.g. one repetition too few or too many, or a missing literal.
+// It returns ErrNoNearMiss if no near miss is found after DefaultMaxAttempts attempts.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateNearMiss() (string, error) {
+	re := g.matcher()
+	for attempt := 0; attempt < DefaultMaxAttempts; attempt++ {
+		s, trace := g.GenerateTrace()
+		runes := []rune(s)
+
+		// the instructions that generated the runes
+		var insts []*myinst
+		for _, step := range trace {
+			if step.Op == syntax.InstRune || step.Op == syntax.InstRune1 {
+				insts = append(insts, &g.inst[step.PC])
+			}
+		}
+
+		g.mu.Lock()
+		kind := g.rand.Intn(3)
+		pos := g.rand.Intn(len(runes) + 1)
+		perm := g.rand.Perm(len(nearMissRunes))
+		g.mu.Unlock()
+
+		var candidate []rune
+		switch {
+		case kind == 0 && pos < len(runes):
+			// replace the rune with the one out of the class
+			for _, i := range perm {
+				r := nearMissRunes[i]
+				if !insts[pos].matchRune(r) {
+					candidate = slices.Clone(runes)
+					candidate[pos] = r
+					break
+				}
+			}
+		case kind == 1 && pos < len(runes):
+			// delete the rune
+			candidate = slices.Delete(slices.Clone(runes), pos, pos+1)
+		case len(runes) > 0:
+			// duplicate the rune
+			if pos == len(runes) {
+				pos--
+			}
+			candidate = slices.Insert(slices.Clone(runes), pos, runes[pos])
+		default:
+			// the empty string; insert a rune
+			candidate = []rune{nearMissRunes[perm[0]]}
+		}
+		if candidate == nil {
+			continue
+		}
+		if s := string(candidate); !re.MatchString(s) {
+			return s, nil
+		}
+	}
+	return "", ErrNoNearMiss
+}
+
+// matcher returns the regular expression that matches the whole string of the pattern.
+func (g *Generator) matcher() *regexp.Regexp {
+	if g.verifier != nil {
+		return g.verifier
+	}
+	g.matcherOnce.Do(func() {
+		g.matcherRe = regexp.MustCompile(`\A(?:` + g.re.String() + `)\z`)
+	})
+	return g.matcherRe
+}
@@ -0,0 +1,43 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerateNearMiss(t *testing.T) {
+	patterns := []string{
+		`\d{3}-\d{4}`,
+		`[a-z]{2,4}@example\.com`,
+		`(foo|bar)baz`,
+		``,
+	}
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+		g := Must(New(pattern, syntax.Perl, nil))
+		min := g.MinLen()
+		max, _ := g.MaxLen()
+		for i := 0; i < 100; i++ {
+			s, err := g.GenerateNearMiss()
+			if err != nil {
+				t.Fatalf("%s: %v", pattern, err)
+			}
+			if re.MatchString(s) {
+				t.Errorf("%s: %q matches", pattern, s)
+			}
+			if n := utf8.RuneCountInString(s); n < min-1 || n > max+1 {
+				t.Errorf("%s: %q is far from matching strings", pattern, s)
+			}
+		}
+	}
+}
+
+func TestGenerateNearMissNotFound(t *testing.T) {
+	g := Must(New(`(?s:.*)`, syntax.Perl, nil, WithTargetLength(3)))
+	if _, err := g.GenerateNearMiss(); !errors.Is(err, ErrNoNearMiss) {
+		t.Errorf("want ErrNoNearMiss, got %v", err)
+	}
+}
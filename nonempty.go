@@ -0,0 +1,134 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+)
+
+// ErrOnlyEmpty is returned by New with WithNonEmpty if the pattern generates only the empty string.
+var ErrOnlyEmpty = errors.New("rerand: the pattern generates only the empty string")
+
+// WithNonEmpty never generates the empty string, e.g. `(\w+)?` generates `\w+`.
+// The branches that lead to the empty string are pruned when the pattern is compiled, instead of retrying,
+// so the other strings are generated with the same relative probabilities as without this option.
+// New fails with ErrOnlyEmpty if the pattern generates only the empty string.
+func WithNonEmpty() Option {
+	return func(o *options) {
+		o.nonEmpty = true
+	}
+}
+
+// nonEmptyProg returns the program that generates the strings of prog except the empty string.
+// The instructions of prog are followed by their copies that run before any rune is generated,
+// and the copy of InstMatch fails.
+func nonEmptyProg(prog *syntax.Prog) (*syntax.Prog, error) {
+	n := uint32(len(prog.Inst))
+	insts := make([]syntax.Inst, 2*n)
+	copy(insts, prog.Inst)
+	for i, in := range prog.Inst {
+		in.Rune = append([]rune(nil), in.Rune...)
+		switch in.Op {
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			// a rune is generated, so continue in the original instructions.
+		case syntax.InstAlt:
+			in.Out += n
+			in.Arg += n
+		case syntax.InstMatch:
+			in.Op = syntax.InstFail
+		case syntax.InstFail:
+		default:
+			in.Out += n
+		}
+		insts[n+uint32(i)] = in
+	}
+	ret := &syntax.Prog{
+		Inst:   insts,
+		Start:  prog.Start + int(n),
+		NumCap: prog.NumCap,
+	}
+	if !liveInsts(ret.Inst)[ret.Start] {
+		return nil, ErrOnlyEmpty
+	}
+	return ret, nil
+}
+
+// liveInsts reports whether each instruction can reach InstMatch.
+func liveInsts(insts []syntax.Inst) []bool {
+	live := make([]bool, len(insts))
+	for changed := true; changed; {
+		changed = false
+		for pc, in := range insts {
+			if live[pc] {
+				continue
+			}
+			var l bool
+			switch in.Op {
+			case syntax.InstMatch:
+				l = true
+			case syntax.InstFail:
+			case syntax.InstAlt:
+				l = live[in.Out] || live[in.Arg]
+			default:
+				l = live[in.Out]
+			}
+			if l {
+				live[pc] = true
+				changed = true
+			}
+		}
+	}
+	return live
+}
+
+// reachable reports whether each instruction of g is reachable from the start through the live instructions,
+// i.e. it may be executed in generating a string.
+func (g *Generator) reachable() []bool {
+	insts := make([]syntax.Inst, len(g.inst))
+	for i := range g.inst {
+		insts[i] = g.inst[i].Inst
+	}
+	live := liveInsts(insts)
+
+	reachable := make([]bool, len(g.inst))
+	var visit func(pc uint32)
+	visit = func(pc uint32) {
+		if reachable[pc] || !live[pc] {
+			return
+		}
+		reachable[pc] = true
+		in := &g.inst[pc]
+		switch in.Op {
+		case syntax.InstMatch, syntax.InstFail:
+		case syntax.InstAlt:
+			visit(in.Out)
+			visit(in.Arg)
+		default:
+			visit(in.Out)
+		}
+	}
+	visit(uint32(g.prog.Start))
+	return reachable
+}
+
+// pruneDeadBranches makes InstAlt never take the branches that cannot reach InstMatch,
+// whatever the probabilities are set by the options.
+func pruneDeadBranches(inst []myinst) {
+	insts := make([]syntax.Inst, len(inst))
+	for i := range inst {
+		insts[i] = inst[i].Inst
+	}
+	live := liveInsts(insts)
+	for i := range inst {
+		in := &inst[i]
+		if in.Op != syntax.InstAlt || !live[i] {
+			continue
+		}
+		if !live[in.Out] {
+			in.x, in.y = 0, 1
+			in.bigX, in.bigY = nil, nil
+		} else if !live[in.Arg] {
+			in.x, in.y = 1, 1
+			in.bigX, in.bigY = nil, nil
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestWithNonEmpty(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    []Option
+	}{
+		{`(\w+)?`, []Option{WithTargetLength(3)}},
+		{`a*`, []Option{WithTargetLength(1)}},
+		{`a?b?`, nil},
+		{`a?b?`, []Option{WithDistinctRunes()}},
+		{`a?b?`, []Option{WithUniformAlternation()}},
+		{`(?:x|)(?:y|)`, []Option{WithProbability(1 << 62)}},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, rand.New(rand.NewSource(1)), append(tt.opts, WithNonEmpty())...))
+		re := regexp.MustCompile(`^(?:` + tt.pattern + `)$`)
+		for i := 0; i < 1000; i++ {
+			s := g.Generate()
+			if s == "" {
+				t.Fatalf("%s: the empty string is generated", tt.pattern)
+			}
+			if !re.MatchString(s) {
+				t.Fatalf("%s: %q does not match", tt.pattern, s)
+			}
+		}
+	}
+}
+
+func TestWithNonEmptyDistribution(t *testing.T) {
+	// a?b? generates "", "a", "b" and "ab" equally, so the rest are equally likely.
+	g := Must(New(`a?b?`, syntax.Perl, rand.New(rand.NewSource(1)), WithNonEmpty()))
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		counts[g.Generate()]++
+	}
+	for _, s := range []string{"a", "b", "ab"} {
+		if counts[s] < 900 || counts[s] > 1100 {
+			t.Errorf("%q is generated %d times, want about 1000", s, counts[s])
+		}
+	}
+}
+
+func TestWithNonEmptyOnlyEmpty(t *testing.T) {
+	for _, pattern := range []string{``, `(?:)`, `a{0}`} {
+		if _, err := New(pattern, syntax.Perl, nil, WithNonEmpty()); !errors.Is(err, ErrOnlyEmpty) {
+			t.Errorf("%s: want ErrOnlyEmpty, got %v", pattern, err)
+		}
+	}
+}
+
+func TestWithNonEmptyEnumerate(t *testing.T) {
+	g := Must(New(`a?b?`, syntax.Perl, nil, WithNonEmpty()))
+	var got []string
+	for s := range g.Enumerate() {
+		got = append(got, s)
+	}
+	if len(got) != 3 {
+		t.Errorf("want 3 strings, got %q", got)
+	}
+	if g.MinLen() != 1 {
+		t.Errorf("want 1, got %d", g.MinLen())
+	}
+}
@@ -0,0 +1,9 @@
+package rerand
+
+import "golang.org/x/text/unicode/norm"
+
+// WithNormalization normalizes generated strings into the form, e.g. norm.NFC.
+// It is a transform, so it is applied in the order with WithTransform, and before the filters of WithFilter.
+func WithNormalization(form norm.Form) Option {
+	return WithTransform(form.String)
+}
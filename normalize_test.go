@@ -0,0 +1,25 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestWithNormalization(t *testing.T) {
+	forms := []norm.Form{norm.NFC, norm.NFD, norm.NFKC, norm.NFKD}
+	for _, form := range forms {
+		g := Must(New(`[a-e][\x{0300}-\x{0304}]|[À-ÿ]|[ｱ-ﾝ]`, syntax.Perl, nil, WithNormalization(form)))
+		for i := 0; i < 100; i++ {
+			if s := g.Generate(); !form.IsNormalString(s) {
+				t.Errorf("%v: %+q is not normalized", form, s)
+			}
+		}
+	}
+
+	g := Must(New(`e\x{0301}`, syntax.Perl, nil, WithNormalization(norm.NFC)))
+	if got := g.Generate(); got != "é" {
+		t.Errorf("want %+q, got %+q", "é", got)
+	}
+}
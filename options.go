@@ -0,0 +1,282 @@
+package rerand
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"unicode"
+
+	"golang.org/x/text/encoding"
+)
+
+// Option configures Generator.
+type Option func(*options)
+
+type options struct {
+	distinctRunes bool
+	prob          int64
+	streamVersion StreamVersion
+	targetLength  float64
+	filters       []func(string) bool
+	maxAttempts   int
+	transforms    []func(string) string
+	verify        bool
+
+	uniformAlternation bool
+	runeSources        []runeSourceOption
+	dependencies       []dependencyOption
+	graphemeClusters   bool
+	encoding           encoding.Encoding
+	unencodable        UnencodablePolicy
+	excludedRunes      []rune
+	requiredClasses    [][]rune
+	maxLength          int
+	maxLengthPolicy    MaxLengthPolicy
+	hooks              []Hooks
+	logger             *slog.Logger
+	expectedLength     int
+	withoutPool        bool
+	filler             []rune
+	uniqueN            int
+	uniqueFPRate       float64
+	unique             *bloomFilter // set by WithProbabilisticUnique
+	nonEmpty           bool
+	lengthBias         float64
+	strictQuantifiers  bool
+	weightMode         WeightMode
+	runeCeiling        rune
+	privateUseArea     bool
+	caseMode           CaseMode
+	continueProbs      []continueProbOption
+	framing            Framing
+	compileBudget      int64
+	internLimit        int
+
+	bytes bool // set by NewBytes
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// hasPostprocess reports whether generated strings are verified, transformed, filtered, constrained or observed.
+func (o *options) hasPostprocess() bool {
+	return o.verify || len(o.transforms) > 0 || len(o.filters) > 0 || len(o.requiredClasses) > 0 || len(o.hooks) > 0
+}
+
+func (o *options) validate() error {
+	if o.prob < 0 {
+		return errors.New("rerand: negative probability")
+	}
+	if o.targetLength < 0 || math.IsNaN(o.targetLength) || math.IsInf(o.targetLength, 0) {
+		return errors.New("rerand: invalid target length")
+	}
+	if math.IsNaN(o.lengthBias) || math.IsInf(o.lengthBias, 0) {
+		return errors.New("rerand: invalid length bias")
+	}
+	if o.lengthBias != 0 && o.targetLength != 0 {
+		return errors.New("rerand: WithLengthBias and WithTargetLength cannot be used together")
+	}
+	if o.maxAttempts < 0 {
+		return errors.New("rerand: negative max attempts")
+	}
+	for _, class := range o.requiredClasses {
+		if len(class) == 0 {
+			return errors.New("rerand: empty required class")
+		}
+	}
+	if o.expectedLength < 0 {
+		return errors.New("rerand: negative expected length")
+	}
+	if o.filler != nil && len(o.filler) == 0 {
+		return errors.New("rerand: empty filler")
+	}
+	if (o.uniqueN != 0 || o.uniqueFPRate != 0) && (o.uniqueN <= 0 || !(o.uniqueFPRate > 0 && o.uniqueFPRate < 1)) {
+		return errors.New("rerand: invalid parameters of probabilistic unique")
+	}
+	if o.maxLength < 0 {
+		return errors.New("rerand: negative max length")
+	}
+	if o.maxLengthPolicy < MaxLengthError || o.maxLengthPolicy > MaxLengthTruncate {
+		return errors.New("rerand: unknown max length policy")
+	}
+	if o.runeCeiling != 0 && (o.runeCeiling <= '\n' || o.runeCeiling > unicode.MaxRune) {
+		return errors.New("rerand: invalid rune ceiling")
+	}
+	if o.caseMode < CaseAsPattern || o.caseMode > CaseMixed {
+		return errors.New("rerand: unknown case mode")
+	}
+	if o.framing < FramingSeparator || o.framing > FramingLengthPrefix {
+		return errors.New("rerand: unknown framing")
+	}
+	if o.compileBudget < 0 {
+		return errors.New("rerand: negative compile budget")
+	}
+	if o.internLimit < 0 {
+		return errors.New("rerand: negative interning limit")
+	}
+	if o.weightMode < WeightBranchCount || o.weightMode > WeightUniform {
+		return errors.New("rerand: unknown weight mode")
+	}
+	if o.unencodable < UnencodableError || o.unencodable > UnencodableReplace {
+		return errors.New("rerand: unknown unencodable policy")
+	}
+	if o.streamVersion < StreamLatest || o.streamVersion > StreamV4 {
+		return errors.New("rerand: unknown stream version")
+	}
+	return nil
+}
+
+// WithDistinctRunes makes each distinct string equally likely, same as NewDistinctRunes.
+func WithDistinctRunes() Option {
+	return func(o *options) {
+		o.distinctRunes = true
+	}
+}
+
+// WithProbability sets the probability of taking the first branch of alternations, same as NewWithProbability.
+// The probability is prob/math.MaxInt64.
+func WithProbability(prob int64) Option {
+	return func(o *options) {
+		o.prob = prob
+	}
+}
+
+// StreamVersion is the version of the algorithm that converts random numbers into strings.
+type StreamVersion int
+
+const (
+	// StreamLatest is the latest algorithm.
+	// It may change in future versions of this package, e.g. for better performance.
+	StreamLatest StreamVersion = iota
+
+	// StreamV1 is the first version of the algorithm.
+	StreamV1
+
+	// StreamV2 approximates the probabilities of alternations by 62-bit fixed point numbers,
+	// if they are too precise for int64, e.g. long repetitions with WithDistinctRunes.
+	// The other probabilities are same as StreamV1.
+	StreamV2
+
+	// StreamV3 chooses a branch of a long chain of alternations, e.g. a word list `alpha|bravo|charlie|...`,
+	// by one lookup of an alias table instead of walking the chain, so that it takes constant time.
+	// The probabilities of the branches are approximated by float64.
+	// And it limits character classes to runes up to U+EFFFF, in the same way as `.`.
+	// The other probabilities are same as StreamV2.
+	StreamV3
+
+	// StreamV4 chooses every branch of WithUniformAlternation with equal probability,
+	// even if the parser merges the branches, e.g. `x|y|zz` into `[xy]|zz`.
+	// The other probabilities are same as StreamV3.
+	StreamV4
+)
+
+// WithStreamVersion pins the algorithm that converts random numbers into strings.
+// Generators with the same pattern, flags, options and seed generate the same strings
+// across versions of this package, as long as the stream version is pinned.
+func WithStreamVersion(v StreamVersion) Option {
+	return func(o *options) {
+		o.streamVersion = v
+	}
+}
+
+// WithTargetLength makes the expected length of generated strings about n runes,
+// by choosing the branches of alternations with the probabilities of Boltzmann sampler.
+// It allows unbounded repetitions such as `*` and `+`, which are rejected with ErrTooManyRepeat by default.
+// The probability of WithProbability is ignored.
+// If the pattern never generates strings of length n, the expected length is as close as possible.
+func WithTargetLength(n float64) Option {
+	return func(o *options) {
+		o.targetLength = n
+	}
+}
+
+// DefaultMaxAttempts is the default maximum number of attempts of WithFilter.
+const DefaultMaxAttempts = 100
+
+// WithFilter rejects generated strings that f returns false, and generates again.
+// If no string passes f after maxAttempts attempts, the generation fails with *FilterError.
+// If maxAttempts is zero, DefaultMaxAttempts is used.
+// If WithFilter is given more than once, strings must pass all filters,
+// and the maximum of maxAttempts is used.
+func WithFilter(f func(s string) bool, maxAttempts int) Option {
+	return func(o *options) {
+		o.filters = append(o.filters, f)
+		if maxAttempts > o.maxAttempts {
+			o.maxAttempts = maxAttempts
+		}
+	}
+}
+
+// WithTransform applies f to generated strings.
+// If WithTransform is given more than once, the functions are applied in the order.
+// Transforms are applied before the filters of WithFilter.
+func WithTransform(f func(s string) string) Option {
+	return func(o *options) {
+		o.transforms = append(o.transforms, f)
+	}
+}
+
+// WithVerify checks that generated strings match the pattern by regexp package.
+// It is for debugging the differences between generating and matching.
+// If a string does not match, the generation fails with *VerifyError.
+// The check is done before the transforms of WithTransform.
+func WithVerify() Option {
+	return func(o *options) {
+		o.verify = true
+	}
+}
+
+// WithUniformAlternation chooses every branch of alternations `|` with equal probability,
+// regardless of the number of strings that each branch generates.
+// For example, `(admin|user|guest)` generates each of them with the probability 1/3.
+// The other choices, e.g. the repetitions, are made in the same way as without this option.
+func WithUniformAlternation() Option {
+	return func(o *options) {
+		o.uniformAlternation = true
+	}
+}
+
+// WithRuneSource generates the runes of the character class by src, instead of choosing uniformly.
+// class is a pattern of a character class such as `\d` and `[a-z]`,
+// and it is applied to the character classes in the pattern that have exactly the same runes.
+func WithRuneSource(class string, src RuneSource) Option {
+	return func(o *options) {
+		o.runeSources = append(o.runeSources, runeSourceOption{class: class, src: src})
+	}
+}
+
+// WithGroupRuneSource generates the runes of every character class in the named group by src.
+func WithGroupRuneSource(group string, src RuneSource) Option {
+	return func(o *options) {
+		o.runeSources = append(o.runeSources, runeSourceOption{group: group, src: src})
+	}
+}
+
+// WithDependency generates the named group by the pattern that fn returns,
+// so that the group depends on the values of the named groups generated before it.
+// values maps the names of the generated groups to their values.
+// For example, WithDependency("port", f) where f returns `443|8443` if values["proto"] is "https".
+// The patterns are compiled with the same flags as the Generator, and cached,
+// so fn should return a limited number of patterns. It panics on generation if the pattern is invalid.
+// It takes effect in Generate and its variants, e.g. TryGenerate and AppendString.
+func WithDependency(group string, fn func(values map[string]string) string) Option {
+	return func(o *options) {
+		o.dependencies = append(o.dependencies, dependencyOption{group: group, fn: fn})
+	}
+}
+
+// WithGraphemeClusters makes `.` generate a well-formed grapheme cluster instead of a code point,
+// e.g. a letter with combining marks, an emoji with a skin tone modifier, an emoji ZWJ sequence or a flag.
+// A grapheme cluster counts as one character, e.g. `.{3}` generates three grapheme clusters.
+// It is ignored by the character classes that have RuneSource.
+// It takes effect in Generate and its variants, and the strings may not pass WithVerify.
+func WithGraphemeClusters() Option {
+	return func(o *options) {
+		o.graphemeClusters = true
+	}
+}
@@ -0,0 +1,59 @@
+package rerand
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// GenerateParallel generates n random strings using workers goroutines.
+// Each worker uses a clone of g with the random number generator seeded from g,
+// so the result is reproducible if g is seeded and workers is fixed.
+// If workers is not positive, runtime.GOMAXPROCS(0) is used.
+// It panics if the generation fails, in the same way as Generate.
+func GenerateParallel(g *Generator, n int, workers int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	// seed the workers in order, so that the result does not depend on the scheduling.
+	seeds := make([]int64, workers)
+	g.mu.Lock()
+	for i := range seeds {
+		seeds[i] = g.rand.Int63()
+	}
+	g.mu.Unlock()
+
+	result := make([]string, n)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var perr any
+	for i := 0; i < workers; i++ {
+		// the i-th worker fills result[begin:end]
+		begin, end := n*i/workers, n*(i+1)/workers
+		wg.Add(1)
+		go func(seed int64, result []string) {
+			defer wg.Done()
+			defer func() {
+				if e := recover(); e != nil {
+					once.Do(func() { perr = e })
+				}
+			}()
+			r := rand.New(newPCGSource(seed))
+			for j := range result {
+				result[j] = g.generate(r, nopLocker{})
+			}
+		}(seeds[i], result[begin:end])
+	}
+	wg.Wait()
+	if perr != nil {
+		panic(perr)
+	}
+	return result
+}
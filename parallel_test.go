@@ -0,0 +1,52 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"testing"
+)
+
+func TestGenerateParallel(t *testing.T) {
+	pattern := `\d{2,3}-\d{3,4}-\d{3,4}`
+	re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+	for _, workers := range []int{0, 1, 3, 100} {
+		g := Must(NewSeeded(pattern, syntax.Perl, 1))
+		got := GenerateParallel(g, 50, workers)
+		if len(got) != 50 {
+			t.Fatalf("want 50 strings, got %d", len(got))
+		}
+		for _, s := range got {
+			if !re.MatchString(s) {
+				t.Errorf("%q does not match", s)
+			}
+		}
+		again := GenerateParallel(Must(NewSeeded(pattern, syntax.Perl, 1)), 50, workers)
+		if workers > 0 && !slices.Equal(got, again) {
+			t.Errorf("workers %d: want reproducible result", workers)
+		}
+	}
+	if got := GenerateParallel(Must(New(pattern, syntax.Perl, nil)), 0, 4); len(got) != 0 {
+		t.Errorf("want empty, got %v", got)
+	}
+}
+
+func TestGenerateParallelPanic(t *testing.T) {
+	g := Must(New(`[a-z]`, syntax.Perl, nil, WithFilter(func(string) bool { return false }, 1)))
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrFilterExhausted) {
+			t.Errorf("want ErrFilterExhausted, got %v", err)
+		}
+	}()
+	GenerateParallel(g, 10, 2)
+}
+
+func BenchmarkGenerateParallel(b *testing.B) {
+	g := Must(NewSeeded(`\d{2,3}-\d{3,4}-\d{3,4}`, syntax.Perl, 1))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GenerateParallel(g, 1000, 0)
+	}
+}
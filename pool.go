@@ -0,0 +1,36 @@
+package rerand
+
+import (
+	"sync"
+)
+
+// WithExpectedLength pre-sizes the buffers for generation to n runes,
+// so that the buffers don't grow while generating strings of about n runes.
+func WithExpectedLength(n int) Option {
+	return func(o *options) {
+		o.expectedLength = n
+	}
+}
+
+// WithoutPool makes Generator allocate a buffer for each generation, instead of reusing the pooled buffers.
+// Generator doesn't retain the buffers, so it is suitable for memory-constrained or short-lived uses.
+// The buffers up to 64 runes are allocated on the stack.
+func WithoutPool() Option {
+	return func(o *options) {
+		o.withoutPool = true
+	}
+}
+
+// newRunePool returns the pool of the buffers for generation, or nil with WithoutPool.
+func newRunePool(o *options) *sync.Pool {
+	if o.withoutPool {
+		return nil
+	}
+	n := o.expectedLength
+	return &sync.Pool{
+		New: func() any {
+			buf := make([]rune, 0, n)
+			return &buf
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestWithoutPool(t *testing.T) {
+	patterns := []string{`[a-z]{10}`, `[a-z]{100}`}
+	for _, p := range patterns {
+		g1 := Must(New(p, syntax.Perl, rand.New(rand.NewSource(1))))
+		g2 := Must(New(p, syntax.Perl, rand.New(rand.NewSource(1)), WithoutPool(), WithExpectedLength(100)))
+		for i := 0; i < 100; i++ {
+			if s1, s2 := g1.Generate(), g2.Generate(); s1 != s2 {
+				t.Errorf("%s: want %q, got %q", p, s1, s2)
+			}
+		}
+	}
+}
+
+func TestWithExpectedLength(t *testing.T) {
+	g := Must(New(`[a-z]{100}`, syntax.Perl, rand.New(rand.NewSource(1)), WithExpectedLength(100)))
+	g.Generate()
+	allocs := testing.AllocsPerRun(100, func() {
+		g.Generate()
+	})
+	// only the string
+	if allocs > 1 {
+		t.Errorf("want at most 1 allocation, got %v", allocs)
+	}
+
+	if _, err := New(`a`, syntax.Perl, nil, WithExpectedLength(-1)); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func BenchmarkPool(b *testing.B) {
+	cases := []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"expected", []Option{WithExpectedLength(200)}},
+		{"nopool", []Option{WithoutPool()}},
+		{"nopool-expected", []Option{WithoutPool(), WithExpectedLength(200)}},
+	}
+	for _, c := range cases {
+		g := Must(New(`[a-z]{100,200}`, syntax.Perl, rand.New(rand.NewSource(1)), c.opts...))
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				g.Generate()
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package presets
+
+import (
+	"math/rand"
+	"regexp/syntax"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// The patterns of the identifiers that have check digits.
+// They do not describe the check digits, and the strings that they match are valid only after the fixes,
+// e.g. FixLuhn for CreditCard.
+const (
+	// CreditCard is the pattern of the primary account numbers of Visa, Mastercard and American Express.
+	CreditCard = `4\d{15}|5[1-5]\d{14}|3[47]\d{13}`
+
+	// ISBN13 is the pattern of ISBN-13 without hyphens.
+	ISBN13 = `97[89]\d{10}`
+
+	// IBAN is the pattern of the International Bank Account Numbers of Germany, the United Kingdom and France.
+	IBAN = `DE\d{20}|GB\d{2}[A-Z]{4}\d{14}|FR\d{12}[A-Z0-9]{11}\d{2}`
+)
+
+// NewCreditCard returns new Generator of CreditCard with the valid check digits.
+// If r is nil, the random number generator seeded with current time is used.
+func NewCreditCard(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(CreditCard, syntax.Perl, r, rerand.WithTransform(FixLuhn)))
+}
+
+// NewISBN13 returns new Generator of ISBN13 with the valid check digits.
+// If r is nil, the random number generator seeded with current time is used.
+func NewISBN13(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(ISBN13, syntax.Perl, r, rerand.WithTransform(FixISBN13)))
+}
+
+// NewIBAN returns new Generator of IBAN with the valid check digits.
+// If r is nil, the random number generator seeded with current time is used.
+func NewIBAN(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(IBAN, syntax.Perl, r, rerand.WithTransform(FixIBAN)))
+}
+
+// FixLuhn replaces the last digit of s with the check digit of the Luhn algorithm.
+// The other runes than the digits, e.g. spaces, are ignored.
+// It is for rerand.WithTransform.
+func FixLuhn(s string) string {
+	b := []byte(s)
+	check := -1 // the index of the check digit
+	sum := 0
+	double := true
+	for i := len(b) - 1; i >= 0; i-- {
+		if !isDigit(b[i]) {
+			continue
+		}
+		if check < 0 {
+			check = i
+			continue
+		}
+		d := int(b[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	if check < 0 {
+		return s
+	}
+	b[check] = byte('0' + (10-sum%10)%10)
+	return string(b)
+}
+
+// ValidLuhn reports whether the digits of s pass the Luhn algorithm.
+func ValidLuhn(s string) bool {
+	return hasDigit(s) && FixLuhn(s) == s
+}
+
+// FixISBN13 replaces the last digit of s with the check digit of ISBN-13.
+// The other runes than the digits, e.g. hyphens, are ignored.
+// It is for rerand.WithTransform.
+func FixISBN13(s string) string {
+	b := []byte(s)
+	check := -1
+	var digits []int
+	for i := range b {
+		if isDigit(b[i]) {
+			check = i
+			digits = append(digits, int(b[i]-'0'))
+		}
+	}
+	if check < 0 {
+		return s
+	}
+	sum := 0
+	for i, d := range digits[:len(digits)-1] {
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	b[check] = byte('0' + (10-sum%10)%10)
+	return string(b)
+}
+
+// ValidISBN13 reports whether s is 13 digits with the valid check digit of ISBN-13, ignoring hyphens.
+func ValidISBN13(s string) bool {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if isDigit(s[i]) {
+			n++
+		} else if s[i] != '-' {
+			return false
+		}
+	}
+	return n == 13 && FixISBN13(s) == s
+}
+
+// FixIBAN replaces the third and the fourth characters of s with the check digits of ISO 13616 (mod 97).
+// s must consist of the digits and the upper case letters,
+// otherwise or if s is shorter than 5 bytes, FixIBAN returns s.
+// It is for rerand.WithTransform.
+func FixIBAN(s string) string {
+	if len(s) < 5 {
+		return s
+	}
+	m, ok := ibanMod97(s[4:] + s[:2] + "00")
+	if !ok {
+		return s
+	}
+	check := 98 - m
+	return s[:2] + string([]byte{byte('0' + check/10), byte('0' + check%10)}) + s[4:]
+}
+
+// ValidIBAN reports whether s passes the check of ISO 13616 (mod 97).
+// It does not check the length of the country.
+func ValidIBAN(s string) bool {
+	if len(s) < 5 {
+		return false
+	}
+	m, ok := ibanMod97(s[4:] + s[:4])
+	return ok && m == 1
+}
+
+// ibanMod97 returns the remainder of s divided by 97, replacing the letters A to Z with 10 to 35.
+func ibanMod97(s string) (int, bool) {
+	m := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case isDigit(c):
+			m = (m*10 + int(c-'0')) % 97
+		case 'A' <= c && c <= 'Z':
+			m = (m*100 + int(c-'A') + 10) % 97
+		default:
+			return 0, false
+		}
+	}
+	return m, true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func hasDigit(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if isDigit(s[i]) {
+			return true
+		}
+	}
+	return false
+}
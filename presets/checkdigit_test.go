@@ -0,0 +1,82 @@
+package presets
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		g       *rerand.Generator
+		valid   func(string) bool
+	}{
+		{"CreditCard", CreditCard, NewCreditCard(rand.New(rand.NewSource(1))), ValidLuhn},
+		{"ISBN13", ISBN13, NewISBN13(rand.New(rand.NewSource(1))), ValidISBN13},
+		{"IBAN", IBAN, NewIBAN(rand.New(rand.NewSource(1))), ValidIBAN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile(`^(?:` + tt.pattern + `)$`)
+			for i := 0; i < 1000; i++ {
+				s := tt.g.Generate()
+				if !re.MatchString(s) {
+					t.Errorf("%q does not match %s", s, tt.pattern)
+				}
+				if !tt.valid(s) {
+					t.Errorf("%q has invalid check digits", s)
+				}
+			}
+		})
+	}
+}
+
+func TestValidCheckDigit(t *testing.T) {
+	tests := []struct {
+		valid func(string) bool
+		in    string
+		want  bool
+	}{
+		{ValidLuhn, "4111111111111111", true},
+		{ValidLuhn, "4111 1111 1111 1111", true},
+		{ValidLuhn, "4111111111111112", false},
+		{ValidLuhn, "79927398713", true},
+		{ValidLuhn, "", false},
+		{ValidISBN13, "9780306406157", true},
+		{ValidISBN13, "978-0-306-40615-7", true},
+		{ValidISBN13, "9780306406158", false},
+		{ValidISBN13, "978030640615", false},
+		{ValidIBAN, "DE89370400440532013000", true},
+		{ValidIBAN, "GB82WEST12345698765432", true},
+		{ValidIBAN, "GB83WEST12345698765432", false},
+		{ValidIBAN, "gb82west12345698765432", false},
+	}
+	for _, tt := range tests {
+		if got := tt.valid(tt.in); got != tt.want {
+			t.Errorf("%q: want %t, got %t", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestFixCheckDigit(t *testing.T) {
+	tests := []struct {
+		fix  func(string) string
+		in   string
+		want string
+	}{
+		{FixLuhn, "4111111111111110", "4111111111111111"},
+		{FixLuhn, "abc", "abc"},
+		{FixISBN13, "978-0-306-40615-0", "978-0-306-40615-7"},
+		{FixIBAN, "DE00370400440532013000", "DE89370400440532013000"},
+		{FixIBAN, "GB", "GB"},
+	}
+	for _, tt := range tests {
+		if got := tt.fix(tt.in); got != tt.want {
+			t.Errorf("%q: want %q, got %q", tt.in, tt.want, got)
+		}
+	}
+}
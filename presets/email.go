@@ -0,0 +1,53 @@
+package presets
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// TLDs is the top-level domains that EmailStrict chooses from.
+var TLDs = []string{"com", "net", "org", "io", "dev", "app", "info", "jp", "uk", "de", "fr", "us"}
+
+// ReservedDomains is the domains that EmailReserved chooses from.
+// They are reserved for testing by RFC 2606 and RFC 6761, and no mail is delivered to them.
+var ReservedDomains = []string{"example.com", "example.net", "example.org"}
+
+// ReservedTLDs is the top-level domains that EmailReserved chooses from.
+var ReservedTLDs = []string{"test", "example", "invalid"}
+
+const (
+	// emailLocal is the local part of the addresses, a dot-atom of at most 32 characters.
+	emailLocal = `[a-z0-9]{1,10}(?:[._+-][a-z0-9]{1,10}){0,2}`
+
+	// domainLabel is a label of the domain names, at most 16 characters.
+	domainLabel = `[a-z0-9](?:[a-z0-9-]{0,14}[a-z0-9])?`
+)
+
+// EmailStrict returns new Generator of email addresses within the limits of RFC 5321,
+// e.g. the labels of the domains are at most 63 characters, and the top-level domains are chosen from TLDs with equal probability.
+// If r is nil, the random number generator seeded with current time is used.
+func EmailStrict(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(emailLocal+`@`+domainLabel+`(?:\.`+domainLabel+`)?\.`+alternate(TLDs), syntax.Perl, r, rerand.WithUniformAlternation()))
+}
+
+// EmailReserved returns new Generator of email addresses in the domains reserved for testing,
+// i.e. ReservedDomains and the subdomains of ReservedTLDs, so that the test traffic never reaches real mail servers.
+// About half of them are in ReservedDomains.
+// If r is nil, the random number generator seeded with current time is used.
+func EmailReserved(r *rand.Rand) *rerand.Generator {
+	domain := alternate(ReservedDomains) + `|` + domainLabel + `\.` + alternate(ReservedTLDs)
+	return rerand.Must(rerand.New(emailLocal+`@(?:`+domain+`)`, syntax.Perl, r, rerand.WithUniformAlternation()))
+}
+
+// alternate returns the pattern that matches one of the literals.
+func alternate(literals []string) string {
+	quoted := make([]string, len(literals))
+	for i, s := range literals {
+		quoted[i] = regexp.QuoteMeta(s)
+	}
+	return `(?:` + strings.Join(quoted, `|`) + `)`
+}
@@ -0,0 +1,62 @@
+package presets
+
+import (
+	"math/rand"
+	"net/mail"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func checkEmail(t *testing.T, s string) (local, domain string) {
+	t.Helper()
+	if _, err := mail.ParseAddress(s); err != nil {
+		t.Errorf("%q is invalid: %v", s, err)
+	}
+	if len(s) > 254 {
+		t.Errorf("%q is too long", s)
+	}
+	local, domain, _ = strings.Cut(s, "@")
+	if len(local) > 64 {
+		t.Errorf("the local part of %q is too long", s)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			t.Errorf("%q has invalid label %q", s, label)
+		}
+	}
+	return local, domain
+}
+
+func TestEmailStrict(t *testing.T) {
+	g := EmailStrict(rand.New(rand.NewSource(1)))
+	for i := 0; i < 1000; i++ {
+		s := g.Generate()
+		_, domain := checkEmail(t, s)
+		tld := domain[strings.LastIndexByte(domain, '.')+1:]
+		if !slices.Contains(TLDs, tld) {
+			t.Errorf("%q has unknown TLD", s)
+		}
+	}
+}
+
+func TestEmailReserved(t *testing.T) {
+	g := EmailReserved(rand.New(rand.NewSource(1)))
+	var sub, reserved int
+	for i := 0; i < 1000; i++ {
+		s := g.Generate()
+		_, domain := checkEmail(t, s)
+		if slices.Contains(ReservedDomains, domain) {
+			reserved++
+			continue
+		}
+		sub++
+		tld := domain[strings.LastIndexByte(domain, '.')+1:]
+		if !slices.Contains(ReservedTLDs, tld) {
+			t.Errorf("%q is not in the reserved domains", s)
+		}
+	}
+	if reserved < 300 || sub < 300 {
+		t.Errorf("unexpected distribution: %d reserved domains, %d subdomains", reserved, sub)
+	}
+}
@@ -0,0 +1,63 @@
+// Package presets provides the patterns and the generators for common test data.
+package presets
+
+import (
+	"math/rand"
+	"regexp/syntax"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// The character classes of the writing systems.
+const (
+	// Japanese is the class of Japanese characters, hiragana, katakana, kanji and the prolonged sound mark.
+	Japanese = `[\p{Hiragana}\p{Katakana}\p{Han}ー]`
+
+	// Cyrillic is the class of the letters of the Russian alphabet.
+	Cyrillic = `[А-яЁё]`
+
+	// Arabic is the class of the basic letters of the Arabic alphabet.
+	Arabic = `[\x{0621}-\x{063A}\x{0641}-\x{064A}]`
+
+	// Greek is the class of the letters of the modern Greek alphabet.
+	Greek = `[Α-ΡΣ-Ωα-ω]`
+)
+
+// The patterns of test text in the writing systems.
+const (
+	// JapaneseText is the pattern of Japanese sentences.
+	JapaneseText = `(?:` + Japanese + `{1,8}、?){1,5}` + Japanese + `{1,8}。`
+
+	// CyrillicText is the pattern of Russian sentences.
+	CyrillicText = `[А-ЯЁ][а-яё]{0,9}(?: [а-яё]{1,10}){0,8}\.`
+
+	// ArabicText is the pattern of Arabic sentences.
+	ArabicText = Arabic + `{2,8}(?: ` + Arabic + `{2,8}){0,8}\.`
+
+	// GreekText is the pattern of Greek sentences.
+	GreekText = `[Α-ΡΣ-Ω][α-ω]{0,9}(?: [α-ω]{1,10}){0,8}\.`
+)
+
+// NewJapaneseText returns new Generator of JapaneseText.
+// If r is nil, the random number generator seeded with current time is used.
+func NewJapaneseText(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(JapaneseText, syntax.Perl, r))
+}
+
+// NewCyrillicText returns new Generator of CyrillicText.
+// If r is nil, the random number generator seeded with current time is used.
+func NewCyrillicText(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(CyrillicText, syntax.Perl, r))
+}
+
+// NewArabicText returns new Generator of ArabicText.
+// If r is nil, the random number generator seeded with current time is used.
+func NewArabicText(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(ArabicText, syntax.Perl, r))
+}
+
+// NewGreekText returns new Generator of GreekText.
+// If r is nil, the random number generator seeded with current time is used.
+func NewGreekText(r *rand.Rand) *rerand.Generator {
+	return rerand.Must(rerand.New(GreekText, syntax.Perl, r))
+}
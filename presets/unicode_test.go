@@ -0,0 +1,38 @@
+package presets
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+	"unicode"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		g       *rerand.Generator
+		tables  []*unicode.RangeTable
+	}{
+		{"Japanese", JapaneseText, NewJapaneseText(rand.New(rand.NewSource(1))), []*unicode.RangeTable{unicode.Hiragana, unicode.Katakana, unicode.Han}},
+		{"Cyrillic", CyrillicText, NewCyrillicText(rand.New(rand.NewSource(1))), []*unicode.RangeTable{unicode.Cyrillic}},
+		{"Arabic", ArabicText, NewArabicText(rand.New(rand.NewSource(1))), []*unicode.RangeTable{unicode.Arabic}},
+		{"Greek", GreekText, NewGreekText(rand.New(rand.NewSource(1))), []*unicode.RangeTable{unicode.Greek}},
+	}
+	for _, tt := range tests {
+		re := regexp.MustCompile(`\A(?:` + tt.pattern + `)\z`)
+		for i := 0; i < 100; i++ {
+			s := tt.g.Generate()
+			if !re.MatchString(s) {
+				t.Errorf("%s: %q does not match", tt.name, s)
+			}
+			for _, r := range s {
+				if unicode.IsLetter(r) && !unicode.IsOneOf(tt.tables, r) && r != 'ー' {
+					t.Errorf("%s: unexpected rune %U in %q", tt.name, r, s)
+				}
+			}
+		}
+	}
+}
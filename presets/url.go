@@ -0,0 +1,235 @@
+package presets
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// The parts of the patterns of URLs.
+const (
+	// urlChar is an unreserved character of the paths and the queries.
+	urlChar = `[a-z0-9._~-]`
+
+	// urlEscaped is a run of the characters that has the percent-encoding of a reserved character,
+	// %20 to %2C, %3A, %3B, %3D, %3F, %40, %5B or %5D.
+	urlEscaped = urlChar + `{0,6}%(?:2[0-9A-C]|3[ABDF]|40|5[BD])` + urlChar + `{0,5}`
+
+	urlHost      = domainLabel + `(?:\.` + domainLabel + `){0,2}\.(?:com|net|org|io|dev|jp)`
+	urlSegment   = `/` + urlChar + `{1,12}`
+	urlParamName = `[a-z][a-z0-9_]{0,7}=`
+	urlParam     = urlParamName + urlChar + `{0,12}`
+	urlNextParam = `&` + urlParam
+)
+
+// urlEscapeProb is the probability that the path has a percent-encoding, and so is the query.
+// It is applied by the weights of the outermost Union, because an alternation of urlChar and the percent-encodings
+// is weighted by the number of its branches, and most characters would be percent-encoded.
+const urlEscapeProb = 0.1
+
+// weighted is a Generator of a component and its weight.
+type weighted struct {
+	gen    *rerand.Generator
+	weight float64
+}
+
+var validScheme = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*$`)
+
+// URLOptions is the options of URL.
+// The zero value generates https and http URLs with short paths and queries.
+type URLOptions struct {
+	// Schemes maps the schemes to their weights.
+	// If nil, https and http are chosen with the weights 4 and 1.
+	Schemes map[string]float64
+
+	// PathDepths is the weights of the numbers of the path segments, i.e. PathDepths[i] is the weight of i segments.
+	// If nil, {1, 3, 3, 2, 1} is used.
+	PathDepths []float64
+
+	// QueryParams is the weights of the numbers of the query parameters, i.e. QueryParams[i] is the weight of i parameters.
+	// If nil, {4, 2, 1, 1} is used.
+	QueryParams []float64
+
+	// Rand is the random number generator.
+	// If nil, the random number generator seeded with current time is used.
+	Rand *rand.Rand
+}
+
+// URL returns new Generator of URLs, combining the generators of the components by rerand.Concat, rerand.Repeat and rerand.Union.
+// The reserved characters in the paths and the queries are percent-encoded, and the escapes are always valid.
+// About one in ten of the paths, and of the queries, has a percent-encoding.
+func URL(opts URLOptions) (*rerand.Generator, error) {
+	schemes := opts.Schemes
+	if schemes == nil {
+		schemes = map[string]float64{"https": 4, "http": 1}
+	}
+	depths := opts.PathDepths
+	if depths == nil {
+		depths = []float64{1, 3, 3, 2, 1}
+	}
+	params := opts.QueryParams
+	if params == nil {
+		params = []float64{4, 2, 1, 1}
+	}
+	r := opts.Rand
+	for _, ws := range [][]float64{depths, params, slices.Collect(maps.Values(schemes))} {
+		for _, w := range ws {
+			if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+				return nil, errors.New("presets: invalid weight")
+			}
+		}
+	}
+
+	schemeGens, schemeWeights, err := urlSchemes(schemes, r)
+	if err != nil {
+		return nil, err
+	}
+	sep, err := rerand.New(`://`, syntax.Perl, r)
+	if err != nil {
+		return nil, err
+	}
+	host, err := rerand.New(urlHost, syntax.Perl, r)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([][]weighted, len(depths))
+	for i := range depths {
+		if paths[i], err = urlPath(i, r); err != nil {
+			return nil, err
+		}
+	}
+	queries := make([][]weighted, len(params))
+	for i := range params {
+		if queries[i], err = urlQuery(i, r); err != nil {
+			return nil, err
+		}
+	}
+
+	// the weights of Union are lost if it is combined again,
+	// so the URL is the union of all combinations of the components weighted by the products of their weights.
+	var gens []*rerand.Generator
+	var weights []float64
+	for i, scheme := range schemeGens {
+		for j, pathVariants := range paths {
+			for _, path := range pathVariants {
+				for k, queryVariants := range queries {
+					for _, query := range queryVariants {
+						w := schemeWeights[i] * depths[j] * path.weight * params[k] * query.weight
+						if w == 0 {
+							continue
+						}
+						g, err := rerand.Concat(scheme, sep, host, path.gen, query.gen)
+						if err != nil {
+							return nil, err
+						}
+						gens = append(gens, g)
+						weights = append(weights, w)
+					}
+				}
+			}
+		}
+	}
+	if len(gens) == 0 {
+		return nil, errors.New("presets: the sum of weights must be positive")
+	}
+	return rerand.Union(weights, gens...)
+}
+
+// urlPath returns the Generators of the paths of n segments, without and with a percent-encoding.
+// The percent-encoding is in the last segment.
+func urlPath(n int, r *rand.Rand) ([]weighted, error) {
+	if n == 0 {
+		g, err := rerand.New(``, syntax.Perl, r)
+		if err != nil {
+			return nil, err
+		}
+		return []weighted{{g, 1}}, nil
+	}
+	seg, err := rerand.New(urlSegment, syntax.Perl, r)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := rerand.Repeat(seg, n, n)
+	if err != nil {
+		return nil, err
+	}
+	escapedSeg, err := rerand.New(`/`+urlEscaped, syntax.Perl, r)
+	if err != nil {
+		return nil, err
+	}
+	rest, err := rerand.Repeat(seg, n-1, n-1)
+	if err != nil {
+		return nil, err
+	}
+	escaped, err := rerand.Concat(rest, escapedSeg)
+	if err != nil {
+		return nil, err
+	}
+	return []weighted{{plain, 1 - urlEscapeProb}, {escaped, urlEscapeProb}}, nil
+}
+
+// urlQuery returns the Generators of the queries of n parameters, without and with a percent-encoding.
+// The percent-encoding is in the value of the first parameter.
+func urlQuery(n int, r *rand.Rand) ([]weighted, error) {
+	if n == 0 {
+		g, err := rerand.New(``, syntax.Perl, r)
+		if err != nil {
+			return nil, err
+		}
+		return []weighted{{g, 1}}, nil
+	}
+	next, err := rerand.New(urlNextParam, syntax.Perl, r)
+	if err != nil {
+		return nil, err
+	}
+	rest, err := rerand.Repeat(next, n-1, n-1)
+	if err != nil {
+		return nil, err
+	}
+	firsts := []weighted{
+		{weight: 1 - urlEscapeProb},
+		{weight: urlEscapeProb},
+	}
+	for i, pattern := range []string{`\?` + urlParam, `\?` + urlParamName + urlEscaped} {
+		first, err := rerand.New(pattern, syntax.Perl, r)
+		if err != nil {
+			return nil, err
+		}
+		if firsts[i].gen, err = rerand.Concat(first, rest); err != nil {
+			return nil, err
+		}
+	}
+	return firsts, nil
+}
+
+// urlSchemes returns the Generators of the schemes and their weights.
+func urlSchemes(schemes map[string]float64, r *rand.Rand) ([]*rerand.Generator, []float64, error) {
+	if len(schemes) == 0 {
+		return nil, nil, errors.New("presets: no schemes")
+	}
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		if !validScheme.MatchString(name) {
+			return nil, nil, errors.New("presets: invalid scheme " + name)
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	weights := make([]float64, len(names))
+	gens := make([]*rerand.Generator, len(names))
+	for i, name := range names {
+		g, err := rerand.New(regexp.QuoteMeta(name), syntax.Perl, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		weights[i] = schemes[name]
+		gens[i] = g
+	}
+	return gens, weights, nil
+}
@@ -0,0 +1,109 @@
+package presets
+
+import (
+	"math/rand"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestURL(t *testing.T) {
+	g, err := URL(URLOptions{Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemes := map[string]int{}
+	var escaped bool
+	for i := 0; i < 1000; i++ {
+		s := g.Generate()
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatalf("%q is invalid: %v", s, err)
+		}
+		if u.Host == "" {
+			t.Errorf("%q has no host", s)
+		}
+		if _, err := url.ParseQuery(u.RawQuery); err != nil {
+			t.Errorf("%q has invalid query: %v", s, err)
+		}
+		if strings.Contains(s, "%") {
+			escaped = true
+		}
+		if depth := strings.Count(u.EscapedPath(), "/"); depth > 4 {
+			t.Errorf("%q is too deep", s)
+		}
+		if n := len(strings.Split(u.RawQuery, "&")); n > 3 {
+			t.Errorf("%q has too many parameters", s)
+		}
+		schemes[u.Scheme]++
+	}
+	if len(schemes) != 2 || schemes["https"] < 700 {
+		t.Errorf("unexpected schemes: %v", schemes)
+	}
+	if !escaped {
+		t.Error("no percent-encoding is generated")
+	}
+}
+
+func TestURLOptions(t *testing.T) {
+	g, err := URL(URLOptions{
+		Schemes:     map[string]float64{"ftp": 1},
+		PathDepths:  []float64{0, 0, 1},
+		QueryParams: []float64{0, 0, 0, 1},
+		Rand:        rand.New(rand.NewSource(1)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		s := g.Generate()
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatalf("%q is invalid: %v", s, err)
+		}
+		if u.Scheme != "ftp" {
+			t.Errorf("%q: unexpected scheme", s)
+		}
+		if depth := strings.Count(u.EscapedPath(), "/"); depth != 2 {
+			t.Errorf("%q: want 2 segments, got %d", s, depth)
+		}
+		if n := len(strings.Split(u.RawQuery, "&")); n != 3 {
+			t.Errorf("%q: want 3 parameters, got %d", s, n)
+		}
+	}
+}
+
+func TestURLError(t *testing.T) {
+	for _, opts := range []URLOptions{
+		{Schemes: map[string]float64{}},
+		{Schemes: map[string]float64{"1http": 1}},
+		{PathDepths: []float64{-1}},
+		{QueryParams: []float64{0}},
+		{PathDepths: []float64{-1}, QueryParams: []float64{-1}},
+	} {
+		if _, err := URL(opts); err == nil {
+			t.Errorf("%+v: want error, got nil", opts)
+		}
+	}
+}
+
+func TestURLEscapes(t *testing.T) {
+	g, err := URL(URLOptions{
+		PathDepths:  []float64{0, 1},
+		QueryParams: []float64{1},
+		Rand:        rand.New(rand.NewSource(1)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var escaped int
+	for i := 0; i < 10000; i++ {
+		if strings.Contains(g.Generate(), "%") {
+			escaped++
+		}
+	}
+	// one in ten of the paths has a percent-encoding.
+	if escaped < 800 || escaped > 1200 {
+		t.Errorf("want about 1000 percent-encoded paths, got %d", escaped)
+	}
+}
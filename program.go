@@ -0,0 +1,33 @@
+package rerand
+
+import (
+	"math/big"
+	"regexp/syntax"
+	"slices"
+)
+
+// Program returns a copy of the compiled program that g runs.
+// The character classes are the ones that g generates, e.g. limited by WithStreamVersion and WithoutAmbiguousRunes,
+// and `.` is compiled into InstRuneAny or InstRuneAnyNotNL.
+// Modifying the returned program does not affect g.
+func (g *Generator) Program() *syntax.Prog {
+	prog := &syntax.Prog{
+		Inst:   slices.Clone(g.prog.Inst),
+		Start:  g.prog.Start,
+		NumCap: g.prog.NumCap,
+	}
+	for i := range prog.Inst {
+		prog.Inst[i].Rune = slices.Clone(prog.Inst[i].Rune)
+	}
+	return prog
+}
+
+// Counts returns the number of the ways to generate strings from each instruction of Program.
+// With WithDistinctRunes, each rune of the character classes counts as a way, otherwise a character class counts as one.
+// InstAlt takes Out with the probability Counts()[Out]/Counts()[pc],
+// unless the probability is given by WithProbability, WithTargetLength, WithUniformAlternation or Union.
+// The count is nil if the instruction generates infinitely many strings, e.g. with WithTargetLength.
+// Modifying the returned counts does not affect g.
+func (g *Generator) Counts() []*big.Int {
+	return g.pathCounts(g.opts.distinctRunes)
+}
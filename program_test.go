@@ -0,0 +1,84 @@
+package rerand
+
+import (
+	"math/big"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestProgram(t *testing.T) {
+	g := Must(New(`a[b-d]`, syntax.Perl, nil))
+	prog := g.Program()
+	if prog.String() != g.prog.String() {
+		t.Errorf("want %s, got %s", g.prog, prog)
+	}
+
+	// the copy does not share the runes.
+	for i := range prog.Inst {
+		if prog.Inst[i].Op == syntax.InstRune {
+			prog.Inst[i].Rune[0] = 'x'
+		}
+		prog.Inst[i].Op = syntax.InstFail
+	}
+	if g.Generate()[1] == 'x' || g.prog.Inst[prog.Start].Op == syntax.InstFail {
+		t.Error("the program is modified")
+	}
+}
+
+func TestCounts(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		distinct bool
+		want     int64
+	}{
+		{`ab|cd|ef`, false, 3},
+		{`[a-c]`, false, 1},
+		{`[a-c]`, true, 3},
+		{`[ab]{2}|c`, true, 5},
+		{`a{0,3}`, false, 4},
+	}
+	for _, tt := range tests {
+		var opts []Option
+		if tt.distinct {
+			opts = append(opts, WithDistinctRunes())
+		}
+		g := Must(New(tt.pattern, syntax.Perl, nil, opts...))
+		counts := g.Counts()
+		if len(counts) != len(g.Program().Inst) {
+			t.Errorf("%s: want %d counts, got %d", tt.pattern, len(g.Program().Inst), len(counts))
+			continue
+		}
+		if got := counts[g.Program().Start]; got.Cmp(big.NewInt(tt.want)) != 0 {
+			t.Errorf("%s: want %d, got %s", tt.pattern, tt.want, got)
+		}
+	}
+}
+
+func TestCountsWeights(t *testing.T) {
+	// the probabilities of InstAlt are the ratios of the counts.
+	g := Must(New(`[a-c]{2}|x`, syntax.Perl, nil, WithDistinctRunes()))
+	counts := g.Counts()
+	for pc, in := range g.Program().Inst {
+		if in.Op != syntax.InstAlt {
+			continue
+		}
+		x := new(big.Int).Mul(counts[in.Out], big.NewInt(g.inst[pc].y))
+		y := new(big.Int).Mul(counts[pc], big.NewInt(g.inst[pc].x))
+		if x.Cmp(y) != 0 {
+			t.Errorf("%d: want %s/%s, got %d/%d", pc, counts[in.Out], counts[pc], g.inst[pc].x, g.inst[pc].y)
+		}
+	}
+}
+
+func TestCountsInfinite(t *testing.T) {
+	g := Must(New(`x(?:ab)*`, syntax.Perl, nil, WithTargetLength(4)))
+	counts := g.Counts()
+	if counts[g.Program().Start] != nil {
+		t.Errorf("want nil, got %s", counts[g.Program().Start])
+	}
+	for pc, in := range g.Program().Inst {
+		if in.Op == syntax.InstMatch && counts[pc].Cmp(big.NewInt(1)) != 0 {
+			t.Errorf("want 1, got %s", counts[pc])
+		}
+	}
+}
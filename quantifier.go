@@ -0,0 +1,96 @@
+package rerand
+
+import (
+	"fmt"
+	"math"
+	"regexp/syntax"
+)
+
+type continueProbOption struct {
+	group int
+	prob  float64
+}
+
+// WithQuantifierContinueProbability sets the probability p of repeating the capture group once more,
+// if the group is quantified, e.g. `(abc)?`, `(abc)*`, `(abc)+` and `(abc){2,5}`.
+// For example, the group of `(abc)?` appears with probability p, and `(abc)*` repeats n times with probability p^n(1-p).
+// The group is specified by its index, e.g. 1 for the first group, and may be named.
+// It overrides the probabilities of the repetitions of the group given by the other options,
+// e.g. WithProbability and WithTargetLength, so it can make one optional group appear 10% of the time
+// and another 90% in the same pattern.
+// The unbounded repetitions, e.g. `(abc)*`, still require WithTargetLength as usual.
+// New fails if the group does not exist or is not quantified, or if p is not in [0, 1].
+func WithQuantifierContinueProbability(groupIndex int, p float64) Option {
+	return func(o *options) {
+		o.continueProbs = append(o.continueProbs, continueProbOption{group: groupIndex, prob: p})
+	}
+}
+
+// checkContinueProbs checks that the groups of WithQuantifierContinueProbability are quantified in re.
+// re must not be simplified, because Simplify expands the repetitions of the groups.
+func checkContinueProbs(re *syntax.Regexp, opts []continueProbOption) error {
+	if len(opts) == 0 {
+		return nil
+	}
+	quantified := map[int]bool{}
+	var walk func(re *syntax.Regexp)
+	walk = func(re *syntax.Regexp) {
+		switch re.Op {
+		case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+			if re.Sub[0].Op == syntax.OpCapture {
+				quantified[re.Sub[0].Cap] = true
+			}
+		}
+		for _, sub := range re.Sub {
+			walk(sub)
+		}
+	}
+	walk(re)
+	for _, opt := range opts {
+		if !(opt.prob >= 0 && opt.prob <= 1) {
+			return fmt.Errorf("rerand: invalid probability %v of group %d", opt.prob, opt.group)
+		}
+		if !quantified[opt.group] {
+			return fmt.Errorf("rerand: group %d is not quantified", opt.group)
+		}
+	}
+	return nil
+}
+
+// setContinueProbs sets the probabilities of InstAlt that enter the groups of WithQuantifierContinueProbability.
+func setContinueProbs(inst []myinst, opts []continueProbOption) {
+	if len(opts) == 0 {
+		return
+	}
+	// skipNop returns the first instruction that is not InstNop.
+	skipNop := func(pc uint32) uint32 {
+		for n := 0; n < len(inst) && inst[pc].Op == syntax.InstNop; n++ {
+			pc = inst[pc].Out
+		}
+		return pc
+	}
+	// enters reports whether the instruction begins the group.
+	enters := func(pc uint32, group int) bool {
+		in := &inst[skipNop(pc)]
+		return in.Op == syntax.InstCapture && in.Arg == uint32(2*group)
+	}
+	for i := range inst {
+		in := &inst[i]
+		if in.Op != syntax.InstAlt {
+			continue
+		}
+		for _, opt := range opts {
+			var p float64
+			switch {
+			case enters(in.Out, opt.group):
+				p = opt.prob
+			case enters(in.Arg, opt.group):
+				p = 1 - opt.prob
+			default:
+				continue
+			}
+			in.x, in.y = int64(math.Round(p*fixedPointOne)), fixedPointOne
+			in.bigX, in.bigY = nil, nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package rerand
+
+import (
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithQuantifierContinueProbability(t *testing.T) {
+	g := Must(New(`(a)?(b)?`, syntax.Perl, rand.New(rand.NewSource(1)),
+		WithQuantifierContinueProbability(1, 0.1),
+		WithQuantifierContinueProbability(2, 0.9),
+	))
+	const n = 10000
+	var a, b int
+	for i := 0; i < n; i++ {
+		s := g.Generate()
+		if strings.Contains(s, "a") {
+			a++
+		}
+		if strings.Contains(s, "b") {
+			b++
+		}
+	}
+	if p := float64(a) / n; math.Abs(p-0.1) > 0.02 {
+		t.Errorf("(a)?: want 0.1, got %f", p)
+	}
+	if p := float64(b) / n; math.Abs(p-0.9) > 0.02 {
+		t.Errorf("(b)?: want 0.9, got %f", p)
+	}
+}
+
+func TestWithQuantifierContinueProbabilityRepeat(t *testing.T) {
+	tests := []struct {
+		pattern string
+		p       float64
+		mean    float64 // the expected number of x
+	}{
+		{`(x)*`, 0.5, 1},
+		{`(x)+`, 0.5, 2},
+		{`(x){1,3}`, 0.5, 1 + 0.5 + 0.25},
+		{`(?P<name>x){0,2}`, 0.8, 0.8 + 0.64},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, rand.New(rand.NewSource(1)), WithTargetLength(10), WithQuantifierContinueProbability(1, tt.p)))
+		const n = 10000
+		var total int
+		for i := 0; i < n; i++ {
+			total += len(g.Generate())
+		}
+		if mean := float64(total) / n; math.Abs(mean-tt.mean) > 0.1 {
+			t.Errorf("%s: want %f, got %f", tt.pattern, tt.mean, mean)
+		}
+	}
+}
+
+func TestWithQuantifierContinueProbabilityError(t *testing.T) {
+	tests := []struct {
+		pattern string
+		group   int
+		p       float64
+	}{
+		{`(a)?`, 2, 0.5},
+		{`(a)`, 1, 0.5},
+		{`(a)|b`, 1, 0.5},
+		{`(a)?`, 1, 1.5},
+		{`(a)?`, 1, math.NaN()},
+	}
+	for _, tt := range tests {
+		if _, err := New(tt.pattern, syntax.Perl, nil, WithQuantifierContinueProbability(tt.group, tt.p)); err == nil {
+			t.Errorf("%s, %d, %f: want error, got nil", tt.pattern, tt.group, tt.p)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package rerand
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Value generates a random string using r, and returns it as reflect.Value.
+// Value does not use the *rand.Rand of the Generator, so the caller must not share r among goroutines.
+// It is useful for the Values function of testing/quick.Config.
+func (g *Generator) Value(r *rand.Rand) reflect.Value {
+	return reflect.ValueOf(g.generate(r, nopLocker{}))
+}
+
+// Func returns a function that generates a random string using the given *rand.Rand.
+func (g *Generator) Func() func(r *rand.Rand) string {
+	return func(r *rand.Rand) string {
+		return g.generate(r, nopLocker{})
+	}
+}
+
+// Values returns a function for testing/quick.Config.Values.
+// The i-th argument of the function under test is generated by gens[i].
+func Values(gens ...*Generator) func(args []reflect.Value, r *rand.Rand) {
+	return func(args []reflect.Value, r *rand.Rand) {
+		for i, g := range gens {
+			args[i] = g.Value(r)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+	"testing/quick"
+)
+
+func TestValues(t *testing.T) {
+	re1 := regexp.MustCompile(`^[a-z]{3}$`)
+	re2 := regexp.MustCompile(`^\d{2,4}$`)
+	g1 := Must(New(`[a-z]{3}`, syntax.Perl, nil))
+	g2 := Must(New(`\d{2,4}`, syntax.Perl, nil))
+	f := func(a, b string) bool {
+		return re1.MatchString(a) && re2.MatchString(b)
+	}
+	if err := quick.Check(f, &quick.Config{Values: Values(g1, g2)}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFunc(t *testing.T) {
+	g := Must(New(`[a-z]{3}`, syntax.Perl, nil))
+	f := g.Func()
+	a := f(rand.New(rand.NewSource(1)))
+	b := f(rand.New(rand.NewSource(1)))
+	if a != b {
+		t.Errorf("want same strings, got %q and %q", a, b)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build race
+
+package rerand
+
+// raceEnabled reports whether the race detector is enabled, which makes extra allocations.
+const raceEnabled = true
@@ -0,0 +1,53 @@
+package rerand
+
+import (
+	"fmt"
+	"io"
+)
+
+// Reader returns an io.Reader that reads infinite random strings separated by sep,
+// or framed by WithFraming.
+// The strings are generated lazily when they are read.
+// The Read method returns an error if the generation fails,
+// or io.ErrNoProgress if the frames are empty too many times in a row, e.g. if the pattern only generates the empty string and sep is empty.
+func (g *Generator) Reader(sep []byte) io.Reader {
+	return &reader{
+		g:   g,
+		sep: append([]byte(nil), sep...),
+	}
+}
+
+// maxEmptyFrames is the number of the empty frames in a row, after which Read gives up.
+const maxEmptyFrames = 100
+
+type reader struct {
+	g   *Generator
+	sep []byte
+	buf []byte
+	off int
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	var n, empty int
+	for n < len(p) {
+		if r.off == len(r.buf) {
+			buf, err := r.g.appendFrame(r.buf[:0], r.sep)
+			if err != nil {
+				return n, err
+			}
+			r.buf = buf
+			r.off = 0
+			if len(buf) == 0 {
+				if empty++; empty >= maxEmptyFrames {
+					return n, fmt.Errorf("rerand: %d empty strings in a row: %w", empty, io.ErrNoProgress)
+				}
+				continue
+			}
+			empty = 0
+		}
+		m := copy(p[n:], r.buf[r.off:])
+		n += m
+		r.off += m
+	}
+	return n, nil
+}
@@ -0,0 +1,64 @@
+package rerand
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	pattern := `[a-z]{1,5}-\d{3}`
+	re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+	g := Must(New(pattern, syntax.Perl, nil))
+	s := bufio.NewScanner(g.Reader([]byte("\n")))
+	for i := 0; i < 100; i++ {
+		if !s.Scan() {
+			t.Fatal(s.Err())
+		}
+		if !re.MatchString(s.Text()) {
+			t.Errorf("%q does not match", s.Text())
+		}
+	}
+}
+
+func TestReaderSmallBuffer(t *testing.T) {
+	g := Must(New(`abc`, syntax.Perl, nil))
+	r := g.Reader([]byte(", "))
+	var got []byte
+	buf := make([]byte, 2)
+	for len(got) < 15 {
+		n, err := r.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, buf[:n]...)
+	}
+	if string(got[:15]) != "abc, abc, abc, " {
+		t.Errorf("want %q, got %q", "abc, abc, abc, ", got)
+	}
+}
+
+func TestReaderError(t *testing.T) {
+	g := Must(New(`[a-z]`, syntax.Perl, nil, WithFilter(func(string) bool { return false }, 1)))
+	_, err := g.Reader(nil).Read(make([]byte, 10))
+	if !errors.Is(err, ErrFilterExhausted) {
+		t.Errorf("want ErrFilterExhausted, got %v", err)
+	}
+}
+
+func TestReaderEmpty(t *testing.T) {
+	g := Must(New(``, syntax.Perl, nil))
+	_, err := g.Reader(nil).Read(make([]byte, 10))
+	if !errors.Is(err, io.ErrNoProgress) {
+		t.Errorf("want io.ErrNoProgress, got %v", err)
+	}
+
+	// the empty strings are fine if some of the strings are not empty.
+	g = Must(New(`a?`, syntax.Perl, nil))
+	if _, err := io.ReadFull(g.Reader(nil), make([]byte, 1000)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,70 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"sync"
+)
+
+// ErrNotRegistered is returned by Lookup if no pattern is registered with the name.
+var ErrNotRegistered = errors.New("rerand: not registered")
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*registered{}
+)
+
+// registered is a pattern in the registry, compiled on the first use.
+type registered struct {
+	pattern string
+	opts    []Option
+
+	once sync.Once
+	g    *Generator
+	err  error
+}
+
+// Register registers the pattern with the name, so that the packages share the compiled Generator by the name,
+// instead of passing *Generator around.
+// The pattern is parsed with syntax.Perl, and it is compiled on the first Generate or Lookup of the name,
+// with the random number generator seeded with current time.
+// It panics if the name is empty or already registered.
+func Register(name, pattern string, opts ...Option) {
+	if name == "" {
+		panic("rerand: Register with empty name")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic("rerand: Register called twice for " + name)
+	}
+	registry[name] = &registered{pattern: pattern, opts: opts}
+}
+
+// Lookup returns the Generator of the pattern registered with the name, compiling it if it is not compiled yet.
+// It returns an error wrapping ErrNotRegistered if the name is not registered,
+// or the error of New if the pattern is invalid.
+func Lookup(name string) (*Generator, error) {
+	registryMu.RLock()
+	reg, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	}
+	reg.once.Do(func() {
+		reg.g, reg.err = New(reg.pattern, syntax.Perl, nil, reg.opts...)
+	})
+	return reg.g, reg.err
+}
+
+// Generate returns a random string by the Generator registered with the name.
+// It is safe for concurrent use by multiple goroutines.
+// It panics if Lookup fails, or the generation fails in the same way as (*Generator).Generate.
+func Generate(name string) string {
+	g, err := Lookup(name)
+	if err != nil {
+		panic(err)
+	}
+	return g.Generate()
+}
@@ -0,0 +1,72 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	Register("test-registry-id", `id-[0-9a-f]{8}`)
+	re := regexp.MustCompile(`^id-[0-9a-f]{8}$`)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if s := Generate("test-registry-id"); !re.MatchString(s) {
+					t.Errorf("unexpected %q", s)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	g1, err := Lookup("test-registry-id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, _ := Lookup("test-registry-id")
+	if g1 != g2 {
+		t.Error("the generator is compiled twice")
+	}
+}
+
+func TestRegisterOptions(t *testing.T) {
+	Register("test-registry-options", `[a-z]{1,10}`, WithMaxLength(3, MaxLengthTruncate))
+	for i := 0; i < 100; i++ {
+		if s := Generate("test-registry-options"); len(s) > 3 {
+			t.Errorf("too long %q", s)
+		}
+	}
+}
+
+func TestRegisterError(t *testing.T) {
+	if _, err := Lookup("test-registry-unknown"); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("want ErrNotRegistered, got %v", err)
+	}
+
+	// invalid patterns are reported on the first use.
+	Register("test-registry-invalid", `(`)
+	if _, err := Lookup("test-registry-invalid"); err == nil {
+		t.Error("want error, got nil")
+	}
+
+	for _, f := range []func(){
+		func() { Register("test-registry-invalid", `a`) },
+		func() { Register("", `a`) },
+		func() { Generate("test-registry-unknown") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("want panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,123 @@
+package rerand
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"strconv"
+)
+
+// RepeatError is returned if the pattern has a repetition that the generator does not support,
+// e.g. an unbounded repetition such as `*` and `+` without WithTargetLength.
+type RepeatError struct {
+	// Pattern is the pattern of the generator.
+	Pattern string
+
+	// Expr is the offending repetition, or empty if it is unknown.
+	Expr string
+
+	// Offset is the byte offset of the repetition operator in Pattern, or -1 if it is unknown.
+	Offset int
+
+	// Inst is the index of the instruction where the repetition is detected in the compiled program.
+	Inst int
+}
+
+func (e *RepeatError) Error() string {
+	msg := "rerand: counted too many repeat"
+	if e.Expr != "" {
+		msg += fmt.Sprintf(": %s", strconv.Quote(e.Expr))
+	}
+	if e.Offset >= 0 {
+		msg += fmt.Sprintf(" at offset %d", e.Offset)
+	}
+	return msg + fmt.Sprintf(" in %q", e.Pattern)
+}
+
+// Unwrap returns ErrTooManyRepeat.
+func (e *RepeatError) Unwrap() error {
+	return ErrTooManyRepeat
+}
+
+// newRepeatError returns the error of the repetition detected at the instruction pc.
+// If emptyBody is true, the repetition is the one whose body may match the empty string.
+func newRepeatError(pattern string, flags syntax.Flags, pc uint32, emptyBody bool) *RepeatError {
+	e := &RepeatError{Pattern: pattern, Offset: -1, Inst: int(pc)}
+	re, err := syntax.Parse(pattern, flags)
+	if err != nil {
+		return e
+	}
+
+	// collect the unbounded repetitions in the order of their operators in the pattern.
+	var reps []*syntax.Regexp
+	var walk func(re *syntax.Regexp)
+	walk = func(re *syntax.Regexp) {
+		for _, sub := range re.Sub {
+			walk(sub)
+		}
+		if isUnbounded(re) {
+			reps = append(reps, re)
+		}
+	}
+	walk(re)
+
+	var offsets []int
+	for _, d := range Check(pattern, flags) {
+		if d.Expr == "*" || d.Expr == "+" || isUnboundedRepeat(d.Expr) {
+			offsets = append(offsets, d.Offset)
+		}
+	}
+
+	for i, rep := range reps {
+		if emptyBody && !matchesEmpty(rep.Sub[0]) {
+			continue
+		}
+		e.Expr = rep.String()
+		if len(offsets) == len(reps) {
+			// the offsets are reliable only if all repetitions are found in the pattern.
+			e.Offset = offsets[i]
+		}
+		break
+	}
+	return e
+}
+
+// isUnbounded reports whether re is an unbounded repetition.
+func isUnbounded(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus:
+		return true
+	case syntax.OpRepeat:
+		return re.Max < 0
+	}
+	return false
+}
+
+// matchesEmpty reports whether re may match the empty string.
+func matchesEmpty(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpStar, syntax.OpQuest,
+		syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	case syntax.OpLiteral:
+		return len(re.Rune) == 0
+	case syntax.OpRepeat:
+		return re.Min == 0 || matchesEmpty(re.Sub[0])
+	case syntax.OpPlus, syntax.OpCapture:
+		return matchesEmpty(re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !matchesEmpty(sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if matchesEmpty(sub) {
+				return true
+			}
+		}
+	}
+	return false
+}
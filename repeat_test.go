@@ -0,0 +1,51 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestRepeatError(t *testing.T) {
+	tests := []struct {
+		pattern string
+		expr    string
+		offset  int
+	}{
+		{`\d{3}-[a-z]+`, `[a-z]+`, 11},
+		{`(ab){2,}c`, `(ab){2,}`, 4},
+		{`x{1,3}|y*`, `y*`, 8},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.pattern, syntax.Perl, nil)
+		var rerr *RepeatError
+		if !errors.As(err, &rerr) {
+			t.Errorf("%s: want *RepeatError, got %v", tt.pattern, err)
+			continue
+		}
+		if !errors.Is(err, ErrTooManyRepeat) {
+			t.Errorf("%s: want ErrTooManyRepeat, got %v", tt.pattern, err)
+		}
+		if rerr.Pattern != tt.pattern || rerr.Expr != tt.expr || rerr.Offset != tt.offset {
+			t.Errorf("%s: want %s at %d, got %s at %d", tt.pattern, tt.expr, tt.offset, rerr.Expr, rerr.Offset)
+		}
+	}
+}
+
+func TestRepeatErrorEmptyBody(t *testing.T) {
+	err := newRepeatError(`a+(b?)+`, syntax.Perl, 5, true)
+	if err.Expr != `(b?)+` || err.Offset != 6 || err.Inst != 5 {
+		t.Errorf("want (b?)+ at 6, got %s at %d", err.Expr, err.Offset)
+	}
+}
+
+func TestRepeatErrorMessage(t *testing.T) {
+	err := newRepeatError(`a+b|a+`, syntax.Perl, 0, false)
+	if want := `rerand: counted too many repeat: "a+" at offset 1 in "a+b|a+"`; err.Error() != want {
+		t.Errorf("want %s, got %s", want, err.Error())
+	}
+	err = &RepeatError{Pattern: `a+`, Offset: -1}
+	if want := `rerand: counted too many repeat in "a+"`; err.Error() != want {
+		t.Errorf("want %s, got %s", want, err.Error())
+	}
+}
@@ -0,0 +1,174 @@
+package rerand
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"regexp/syntax"
+	"sync"
+)
+
+// ErrRequireClasses is returned if no generated string contains the runes of all the classes of WithRequireClasses.
+var ErrRequireClasses = errors.New("rerand: no string contains all the required classes")
+
+// WithRequireClasses makes generated strings contain at least one rune from each of the classes,
+// e.g. upper case letters, lower case letters, digits and symbols for passwords.
+// The strings still match the pattern.
+// The runes of the character classes in the pattern are sampled so that they cover the required classes,
+// and the structure of the string, e.g. the repetition counts, is sampled again only if it cannot cover them.
+// The generation fails with ErrRequireClasses if no structure covers them after DefaultMaxAttempts attempts.
+// It disables WithDependency and WithGraphemeClusters.
+func WithRequireClasses(classes ...[]rune) Option {
+	return func(o *options) {
+		o.requiredClasses = append(o.requiredClasses, classes...)
+	}
+}
+
+// runRequired runs the program using r, and makes the string contain all the required classes.
+// It stops if ctx is done.
+// mu guards r.
+func (g *Generator) runRequired(ctx context.Context, r *rand.Rand, mu sync.Locker) (string, error) {
+	classes := g.opts.requiredClasses
+	for attempt := 0; attempt < DefaultMaxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		runes, insts, err := g.runInsts(r, mu)
+		if err != nil {
+			return "", err
+		}
+
+		// candidates[i] is the positions where the i-th class can be generated.
+		candidates := make([][]int, len(classes))
+		for i, class := range classes {
+			mu.Lock()
+			perm := r.Perm(len(runes))
+			mu.Unlock()
+			var satisfied, other []int
+			for _, pos := range perm {
+				if containsRune(class, runes[pos]) {
+					satisfied = append(satisfied, pos)
+				} else if in := insts[pos]; in != nil && in.runeSource == nil && in.intersects(class) {
+					other = append(other, pos)
+				}
+			}
+			// prefer the positions already satisfied, so that fewer runes are sampled again.
+			candidates[i] = append(satisfied, other...)
+		}
+
+		match, ok := matchClasses(candidates, len(runes))
+		if !ok {
+			continue
+		}
+		for i, pos := range match {
+			if containsRune(classes[i], runes[pos]) {
+				continue
+			}
+			var allowed []rune
+			for _, c := range classes[i] {
+				if insts[pos].matchRune(c) {
+					allowed = append(allowed, c)
+				}
+			}
+			mu.Lock()
+			runes[pos] = allowed[r.Intn(len(allowed))]
+			mu.Unlock()
+		}
+		return string(runes), nil
+	}
+	g.log(slog.LevelWarn, "rerand: no string contains the required classes", slog.Int("attempts", DefaultMaxAttempts))
+	return "", ErrRequireClasses
+}
+
+// runInsts runs the program using r, and returns the generated runes and the instructions of InstRune that generated them.
+// The instructions of the literals are nil.
+// mu guards r.
+func (g *Generator) runInsts(r *rand.Rand, mu sync.Locker) ([]rune, []*myinst, error) {
+	var runes []rune
+	var insts []*myinst
+	limit := g.lengthLimit(0)
+	pc := uint32(g.prog.Start)
+	for {
+		in := &g.inst[pc]
+		switch in.Op {
+		case syntax.InstMatch:
+			return runes, insts, nil
+		case syntax.InstRune, syntax.InstRune1:
+			if in.Op == syntax.InstRune {
+				runes = append(runes, in.generateRune(r, mu))
+				insts = append(insts, in)
+			} else {
+				runes = append(runes, in.Rune[0])
+				insts = append(insts, nil)
+			}
+			if len(runes) > limit {
+				runes, err := g.tooLong(runes, limit)
+				return runes, insts[:len(runes)], err
+			}
+			pc = in.Out
+		case syntax.InstAlt:
+			if in.alt(r, mu) {
+				pc = in.Out
+			} else {
+				pc = in.Arg
+			}
+		default:
+			pc = in.Out
+		}
+	}
+}
+
+// intersects reports whether the instruction can generate some rune of the class.
+func (in *myinst) intersects(class []rune) bool {
+	for _, c := range class {
+		if in.matchRune(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRune(class []rune, r rune) bool {
+	for _, c := range class {
+		if c == r {
+			return true
+		}
+	}
+	return false
+}
+
+// matchClasses assigns distinct positions to the classes by the bipartite matching.
+// candidates[i] is the positions for the i-th class in the order of preference.
+func matchClasses(candidates [][]int, n int) ([]int, bool) {
+	owner := make([]int, n) // the class that owns the position, or -1
+	for i := range owner {
+		owner[i] = -1
+	}
+	var augment func(class int, visited []bool) bool
+	augment = func(class int, visited []bool) bool {
+		for _, pos := range candidates[class] {
+			if visited[pos] {
+				continue
+			}
+			visited[pos] = true
+			if owner[pos] < 0 || augment(owner[pos], visited) {
+				owner[pos] = class
+				return true
+			}
+		}
+		return false
+	}
+	for class := range candidates {
+		if !augment(class, make([]bool, n)) {
+			return nil, false
+		}
+	}
+	match := make([]int, len(candidates))
+	for pos, class := range owner {
+		if class >= 0 {
+			match[class] = pos
+		}
+	}
+	return match, true
+}
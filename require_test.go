@@ -0,0 +1,50 @@
+package rerand
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithRequireClasses(t *testing.T) {
+	upper := []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	lower := []rune("abcdefghijklmnopqrstuvwxyz")
+	digit := []rune("0123456789")
+	symbol := []rune("!@#$%^&*")
+	pattern := `[A-Za-z0-9!@#$%^&*]{4,8}`
+	re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+	g := Must(New(pattern, syntax.Perl, nil, WithRequireClasses(upper, lower, digit, symbol)))
+	for i := 0; i < 1000; i++ {
+		s := g.Generate()
+		if !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+		for _, class := range [][]rune{upper, lower, digit, symbol} {
+			if !strings.ContainsAny(s, string(class)) {
+				t.Errorf("%q does not contain %q", s, string(class))
+			}
+		}
+	}
+}
+
+func TestWithRequireClassesStructure(t *testing.T) {
+	// the short strings never cover the classes, so the length is sampled again.
+	g := Must(New(`[a-z0-9]{1,3}`, syntax.Perl, nil, WithRequireClasses([]rune("abc"), []rune("0123"), []rune("xyz"))))
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); len(s) != 3 {
+			t.Errorf("want 3 runes, got %q", s)
+		}
+	}
+}
+
+func TestWithRequireClassesError(t *testing.T) {
+	g := Must(New(`[a-z]{8}`, syntax.Perl, nil, WithRequireClasses([]rune("0123456789"))))
+	if _, err := g.TryGenerate(); !errors.Is(err, ErrRequireClasses) {
+		t.Errorf("want ErrRequireClasses, got %v", err)
+	}
+	if _, err := New(`a`, syntax.Perl, nil, WithRequireClasses([]rune{})); err == nil {
+		t.Error("want error, got nil")
+	}
+}
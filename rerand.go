@@ -1,17 +1,21 @@
 package rerand
 
 import (
+	"context"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"math"
 	"math/big"
 	"math/rand"
+	"regexp"
 	"regexp/syntax"
 	"sync"
 	"time"
 )
 
 // ErrTooManyRepeat the error used for New.
+// It is wrapped by *RepeatError.
 var ErrTooManyRepeat = errors.New("rerand: counted too many repeat")
 
 // runes excluding private use area
@@ -20,20 +24,39 @@ const maxRune = 0xEFFFF
 // Generator is random string generator
 type Generator struct {
 	pattern  string
+	flags    syntax.Flags
+	opts     *options
+	re       *syntax.Regexp // the simplified pattern
 	prog     *syntax.Prog
 	inst     []myinst
+	code     []genInst // the generation program compiled from inst
 	min, max int
-	runes    *sync.Pool
+	runes    *sync.Pool // the pool of *[]rune, or nil with WithoutPool
+	weighted bool       // the branches are weighted by Union, so the pattern does not represent the distribution
 
 	mu   sync.Mutex
 	rand *rand.Rand
+	src  *pcgSource // the source of rand if it is owned by the Generator
+
+	lengthCounts *lengthCounts
+	verifier     *regexp.Regexp
+	interned     map[string]string // the strings interned by WithInterning, or nil
+
+	matcherOnce sync.Once
+	matcherRe   *regexp.Regexp // the lazily compiled pattern, see matcher
 }
 
 type myinst struct {
 	syntax.Inst
 	runeGenerator *RuneGenerator
+	runeSource    RuneSource // overrides runeGenerator if not nil
+	grapheme      bool       // generates grapheme clusters instead of runes, see WithGraphemeClusters
 	x, y          int64
 	bigX, bigY    *big.Int
+
+	// the run of InstRune1 starting from the instruction, and the next instruction of the run.
+	literal    []rune
+	literalOut uint32
 }
 
 // Must is a helper that wraps a call to a function returning (*Generator, error) and panics if the error is non-nil.
@@ -45,47 +68,133 @@ func Must(g *Generator, err error) *Generator {
 }
 
 // New returns new Generator.
-func New(pattern string, flags syntax.Flags, r *rand.Rand) (*Generator, error) {
-	return newGenerator(pattern, flags, r, false, 0)
+func New(pattern string, flags syntax.Flags, r *rand.Rand, opts ...Option) (*Generator, error) {
+	return newGenerator(pattern, flags, r, opts)
 }
 
 // NewDistinctRunes returns new Generator.
-func NewDistinctRunes(pattern string, flags syntax.Flags, r *rand.Rand) (*Generator, error) {
-	return newGenerator(pattern, flags, r, true, 0)
+func NewDistinctRunes(pattern string, flags syntax.Flags, r *rand.Rand, opts ...Option) (*Generator, error) {
+	return newGenerator(pattern, flags, r, append([]Option{WithDistinctRunes()}, opts...))
 }
 
 // NewWithProbability returns new Generator.
-func NewWithProbability(pattern string, flags syntax.Flags, r *rand.Rand, prob int64) (*Generator, error) {
-	return newGenerator(pattern, flags, r, false, prob)
+func NewWithProbability(pattern string, flags syntax.Flags, r *rand.Rand, prob int64, opts ...Option) (*Generator, error) {
+	return newGenerator(pattern, flags, r, append([]Option{WithProbability(prob)}, opts...))
 }
 
-func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, distinctRunes bool, prob int64) (g *Generator, err error) {
-	if r == nil {
-		r = rand.New(rand.NewSource(time.Now().UnixNano()))
-	}
+// NewFromRegexp returns new Generator that generates the strings matching re.
+// re must be compiled by regexp.Compile, not by regexp.CompilePOSIX.
+func NewFromRegexp(re *regexp.Regexp, r *rand.Rand, opts ...Option) (*Generator, error) {
+	return newGenerator(re.String(), syntax.Perl, r, opts)
+}
+
+// NewFromSyntax returns new Generator that generates the strings matching the parsed regular expression re.
+// re is not modified. The options that take patterns, e.g. WithRuneSource, parse them with syntax.Perl.
+func NewFromSyntax(re *syntax.Regexp, r *rand.Rand, opts ...Option) (*Generator, error) {
+	return compileGenerator(re, re.String(), syntax.Perl, r, opts, nil)
+}
 
+func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, opts []Option) (*Generator, error) {
 	re, err := syntax.Parse(pattern, flags)
 	if err != nil {
 		return nil, err
 	}
+	return compileGenerator(re, pattern, flags, r, opts, nil)
+}
+
+// compileGenerator returns new Generator that generates the strings matching re.
+// If unionWeights is not nil, re must be an alternation, and its branches are chosen with the probabilities proportional to unionWeights.
+func compileGenerator(re *syntax.Regexp, pattern string, flags syntax.Flags, r *rand.Rand, opts []Option, unionWeights []int64) (g *Generator, err error) {
+	var src *pcgSource
+	if r == nil {
+		src = newPCGSource(time.Now().UnixNano())
+		r = rand.New(src)
+	}
+	o := newOptions(opts)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	distinctRunes := o.distinctRunes
+	prob := o.prob
+
+	if o.strictQuantifiers {
+		if lazy := findLazy(re); lazy != nil {
+			return nil, fmt.Errorf("%w: %s", ErrLazyQuantifier, lazy)
+		}
+	}
+	re = greedy(re)
+	re = applyCase(re, o.caseMode)
+	if err := checkContinueProbs(re, o.continueProbs); err != nil {
+		return nil, err
+	}
+
+	budget := newCompileBudget(o.compileBudget)
+	if err := budget.charge("program", programBytes(re)); err != nil {
+		return nil, err
+	}
+
 	min := re.Min
 	max := re.Max
 	re = re.Simplify()
-	prog, err := syntax.Compile(re)
+	compiled := re
+	var marker *alternationMarker
+	if unionWeights != nil {
+		marker = newAlternationMarker(re)
+		compiled = marker.markBranches(re, unionWeights)
+	} else if o.uniformAlternation {
+		marker = newAlternationMarker(re)
+		if o.streamVersion == StreamLatest || o.streamVersion >= StreamV4 {
+			compiled = uniformTree(re, pattern, flags, o)
+		}
+		compiled = marker.mark(compiled)
+	}
+	prog, err := syntax.Compile(compiled)
 	if err != nil {
 		return nil, err
 	}
+	if o.bytes {
+		if err := limitBytes(prog); err != nil {
+			return nil, err
+		}
+	} else if o.streamVersion == StreamLatest || o.streamVersion >= StreamV3 || o.runeCeiling != 0 {
+		for i := range prog.Inst {
+			if prog.Inst[i].Op == syntax.InstRune {
+				prog.Inst[i].Rune = limitRunes(prog.Inst[i].Rune, o.maxRune())
+			}
+		}
+	}
+	if err := excludeRunes(prog, o.excludedRunes, o.maxRune()); err != nil {
+		return nil, err
+	}
+	if err := checkInsts(prog, pattern, flags); err != nil {
+		return nil, err
+	}
+	if o.nonEmpty {
+		prog, err = nonEmptyProg(prog)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := budget.charge("instructions", int64(len(prog.Inst))*instBytes); err != nil {
+		return nil, err
+	}
 
+	var loop uint32 // the instruction where the repetition is detected
 	defer func() {
 		e := recover()
 		if e == nil {
 			return
 		}
 		if e == ErrTooManyRepeat {
-			err = ErrTooManyRepeat
+			err = newRepeatError(pattern, flags, loop, false)
 			return
 		}
-		panic(err)
+		if e, ok := e.(*BudgetError); ok {
+			err = e
+			return
+		}
+		panic(e)
 	}()
 
 	cache := make([]*big.Int, len(prog.Inst))
@@ -93,6 +202,7 @@ func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, distinctRune
 	var count func(i uint32) *big.Int
 	count = func(i uint32) *big.Int {
 		if visitied[i] {
+			loop = i
 			panic(ErrTooManyRepeat)
 		}
 		if cache[i] != nil {
@@ -125,13 +235,13 @@ func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, distinctRune
 		case syntax.InstRuneAny:
 			ret = count(prog.Inst[i].Out)
 			if distinctRunes {
-				runes := big.NewInt(maxRune + 1)
+				runes := big.NewInt(int64(o.maxRune()) + 1)
 				ret = runes.Mul(runes, ret)
 			}
 		case syntax.InstRuneAnyNotNL:
 			ret = count(prog.Inst[i].Out)
 			if distinctRunes {
-				runes := big.NewInt(maxRune)
+				runes := big.NewInt(int64(o.maxRune()))
 				ret = runes.Mul(runes, ret)
 			}
 		case syntax.InstAlt:
@@ -142,6 +252,11 @@ func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, distinctRune
 		case syntax.InstMatch:
 			ret = big.NewInt(1)
 		}
+		if ret != cache[prog.Inst[i].Out] {
+			if err := budget.charge("counting", bigIntBytes+int64(len(ret.Bits()))*8); err != nil {
+				panic(err)
+			}
+		}
 		cache[i] = ret
 		visitied[i] = false
 		return ret
@@ -156,16 +271,25 @@ func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, distinctRune
 			in2.runeGenerator = NewRuneGenerator(in.Rune, r)
 		case syntax.InstRuneAny:
 			in2.Inst.Op = syntax.InstRune
-			// runes excluding private use area
-			in2.runeGenerator = NewRuneGenerator([]rune{0, maxRune}, r)
+			// runes excluding private use area by default
+			in2.runeGenerator = NewRuneGenerator([]rune{0, o.maxRune()}, r)
+			in2.grapheme = o.graphemeClusters
 		case syntax.InstRuneAnyNotNL:
 			in2.Inst.Op = syntax.InstRune
-			// runes excluding private use area
-			in2.runeGenerator = NewRuneGenerator([]rune{0, '\n' - 1, '\n' + 1, maxRune}, r)
+			// runes excluding private use area by default
+			in2.runeGenerator = NewRuneGenerator([]rune{0, '\n' - 1, '\n' + 1, o.maxRune()}, r)
+			in2.grapheme = o.graphemeClusters
 		case syntax.InstAlt:
-			if prob == 0 {
+			if o.targetLength > 0 || o.lengthBias != 0 {
+				// the probability is set by boltzmann or lengthBias below
+			} else if prob == 0 {
 				x := count(in.Out)
 				y := count(uint32(i))
+				if y.Sign() == 0 {
+					// no string is generated, e.g. the branches pruned by WithNonEmpty
+					in2.x, in2.y = 0, 1
+					break
+				}
 				var gcd big.Int
 				gcd.GCD(nil, nil, x, y)
 				x = new(big.Int).Div(x, &gcd)
@@ -173,9 +297,11 @@ func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, distinctRune
 				if y.Cmp(maxInt64) <= 0 {
 					in2.x = x.Int64()
 					in2.y = y.Int64()
-				} else {
+				} else if o.streamVersion == StreamV1 {
 					in2.bigX = x
 					in2.bigY = y
+				} else {
+					in2.x, in2.y = fixedPoint(x, y)
 				}
 			} else {
 				in2.x = prob
@@ -184,17 +310,61 @@ func newGenerator(pattern string, flags syntax.Flags, r *rand.Rand, distinctRune
 		}
 		inst[i] = in2
 	}
+	var verifier *regexp.Regexp
+	if o.verify {
+		verifier, err = regexp.Compile(`\A(?:` + re.String() + `)\z`)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if o.targetLength > 0 {
+		if err := boltzmann(inst, prog.Start, distinctRunes, o.targetLength); err != nil {
+			return nil, err
+		}
+	}
+	if o.lengthBias != 0 {
+		if err := lengthBias(inst, distinctRunes, o.lengthBias); err != nil {
+			return nil, err
+		}
+	}
+	if err := setRuneSources(inst, prog, re, flags, o.runeSources, o.maxRune()); err != nil {
+		return nil, err
+	}
+	deps, err := newDependencies(re, flags, o.dependencies)
+	if err != nil {
+		return nil, err
+	}
+	if marker != nil {
+		for pc, xy := range marker.probabilities(prog) {
+			inst[pc].x, inst[pc].y = xy[0], xy[1]
+			inst[pc].bigX, inst[pc].bigY = nil, nil
+		}
+	}
+	setContinueProbs(inst, o.continueProbs)
+	if o.nonEmpty {
+		pruneDeadBranches(inst)
+	}
+	setLiterals(inst)
 
 	gen := &Generator{
-		pattern: pattern,
-		prog:    prog,
-		inst:    inst,
-		min:     min,
-		max:     max,
-		rand:    r,
-		runes: &sync.Pool{
-			New: func() interface{} { return []rune{} },
-		},
+		pattern:      pattern,
+		flags:        flags,
+		re:           re,
+		opts:         o,
+		prog:         prog,
+		inst:         inst,
+		code:         compileGenProg(inst, prog.Start, deps, o.streamVersion == StreamLatest || o.streamVersion >= StreamV3),
+		min:          min,
+		max:          max,
+		rand:         r,
+		src:          src,
+		verifier:     verifier,
+		lengthCounts: &lengthCounts{},
+		runes:        newRunePool(o),
+		weighted:     unionWeights != nil,
+	}
+	if o.internLimit > 0 {
+		gen.interned = gen.newInternTable(o.internLimit)
 	}
 	return gen, nil
 }
@@ -204,63 +374,227 @@ func (g *Generator) String() string {
 }
 
 // Generate generates a random string.
+// It panics if the generation fails, e.g. no string passes the filter of WithFilter.
 // It is safe for concurrent use by multiple goroutines.
 func (g *Generator) Generate() string {
-	inst := g.inst
-	pc := uint32(g.prog.Start)
-	i := inst[pc]
-	result := g.runes.Get().([]rune)[:0]
-	var a big.Int
+	return g.generate(g.rand, &g.mu)
+}
+
+// GenerateContext generates a random string, and stops if ctx is done.
+// The context is checked in the retries, e.g. of WithFilter and WithProbabilisticUnique,
+// and in the unbounded repetitions allowed by WithTargetLength.
+// It returns ctx.Err() if ctx is done, and an error if the generation fails in the same way as TryGenerate.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateContext(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return g.tryGenerateContext(ctx, g.rand, &g.mu)
+}
+
+// TryGenerate generates a random string.
+// It returns an error if the generation fails, e.g. no string passes the filter of WithFilter.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) TryGenerate() (string, error) {
+	return g.tryGenerate(g.rand, &g.mu)
+}
+
+// generate generates a random string using r, and panics if the generation fails.
+// mu guards r.
+func (g *Generator) generate(r *rand.Rand, mu sync.Locker) string {
+	s, err := g.tryGenerate(r, mu)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// tryGenerate generates a random string using r, and applies the options.
+// mu guards r.
+func (g *Generator) tryGenerate(r *rand.Rand, mu sync.Locker) (string, error) {
+	return g.tryGenerateContext(context.Background(), r, mu)
+}
+
+// tryGenerateContext is same as tryGenerate, but stops if ctx is done.
+func (g *Generator) tryGenerateContext(ctx context.Context, r *rand.Rand, mu sync.Locker) (string, error) {
+	if len(g.opts.hooks) == 0 {
+		return g.tryGenerateFiltered(ctx, r, mu)
+	}
+	start := time.Now()
+	s, err := g.tryGenerateFiltered(ctx, r, mu)
+	g.opts.onGenerate(g.pattern, s, time.Since(start), err)
+	return s, err
+}
+
+// tryGenerateFiltered is same as tryGenerate, but does not call the hooks of WithHooks.
+func (g *Generator) tryGenerateFiltered(ctx context.Context, r *rand.Rand, mu sync.Locker) (string, error) {
+	return g.filter(ctx, func() (string, error) {
+		var s string
+		if len(g.opts.requiredClasses) > 0 {
+			var err error
+			s, err = g.runRequired(ctx, r, mu)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			var err error
+			s, err = g.run(ctx, r, mu)
+			if err != nil {
+				return "", err
+			}
+		}
+		if err := g.verify(s); err != nil {
+			return "", err
+		}
+		return g.transform(s), nil
+	})
+}
+
+// run runs the program using r, and stops if ctx is done.
+// mu guards r.
+func (g *Generator) run(ctx context.Context, r *rand.Rand, mu sync.Locker) (string, error) {
+	if g.interned != nil {
+		return g.runInterned(ctx, r, mu)
+	}
+	if g.runes == nil {
+		var buf [64]rune
+		result := buf[:0]
+		if n := g.opts.expectedLength; n > len(buf) {
+			result = make([]rune, 0, n)
+		}
+		result, err := g.appendRunes(ctx, result, r, mu)
+		if err != nil {
+			return "", err
+		}
+		return string(result), nil
+	}
+
+	p := g.runes.Get().(*[]rune)
+	result, err := g.appendRunes(ctx, (*p)[:0], r, mu)
+	strresult := string(result)
+	*p = result
+	g.runes.Put(p)
+	if err != nil {
+		return "", err
+	}
+	return strresult, nil
+}
+
+// appendRunes runs the program using r, and appends the generated runes to result.
+// It fails if the generated runes exceed the maximum length of WithMaxLength, or ctx is done.
+// mu guards r.
+func (g *Generator) appendRunes(ctx context.Context, result []rune, r *rand.Rand, mu sync.Locker) ([]rune, error) {
+	code := g.code
+	i := &code[0]
+	var caps []int // the positions of the capture groups, only for WithDependency
+	limit := g.lengthLimit(len(result))
+	done := ctx.Done()
+	var alts uint // the number of InstAlt executed, to check done occasionally
 
 	for {
 		switch i.Op {
 		default:
-			log.Fatalf("%v: %v", i.Op, "bad operation")
-		case syntax.InstFail:
-			// nothing
-	        case syntax.InstNop:
-			// nothing
+			// checkInsts rejects the unsupported instructions, so it never happens.
+			g.log(slog.LevelError, "rerand: unsupported operation", slog.String("op", i.Op.String()))
+			return result, &UnsupportedError{Pattern: g.pattern, Op: i.Op, Pos: -1, Inst: -1}
 		case syntax.InstRune:
-			g.mu.Lock()
-			r := i.runeGenerator.Generate()
-			g.mu.Unlock()
-			result = append(result, r)
-			pc = i.Out
-			i = inst[pc]
+			if i.grapheme {
+				result = appendGrapheme(result, i.runeGenerator, r, mu)
+			} else {
+				result = append(result, i.generateRune(r, mu))
+			}
+			if len(result) > limit {
+				return g.tooLong(result, limit)
+			}
+			i = &code[i.Out]
 		case syntax.InstRune1:
-			result = append(result, i.Rune[0])
-			pc = i.Out
-			i = inst[pc]
+			result = append(result, i.literal...)
+			if len(result) > limit {
+				return g.tooLong(result, limit)
+			}
+			i = &code[i.Out]
 		case syntax.InstAlt:
+			if done != nil {
+				alts++
+				if alts%ctxCheckInterval == 0 {
+					select {
+					case <-done:
+						return result, ctx.Err()
+					default:
+					}
+				}
+			}
+			if i.table != nil {
+				i = &code[i.table.choose(r, mu)]
+				break
+			}
 			var cmp bool
 			if i.y > 0 {
-				g.mu.Lock()
-				a := g.rand.Int63n(i.y)
-				g.mu.Unlock()
+				mu.Lock()
+				a := r.Int63n(i.y)
+				mu.Unlock()
 				cmp = a < i.x
 			} else {
-				g.mu.Lock()
-				a.Rand(g.rand, i.bigY)
-				g.mu.Unlock()
-				cmp = a.Cmp(i.bigX) < 0
+				cmp = i.altBig(r, mu)
 			}
 			if cmp {
-				pc = i.Out
+				i = &code[i.Out]
 			} else {
-				pc = i.Arg
+				i = &code[i.Arg]
 			}
-			i = inst[pc]
 		case syntax.InstCapture:
-			pc = i.Out
-			i = inst[pc]
+			if caps == nil {
+				caps = make([]int, g.prog.NumCap)
+				for j := range caps {
+					caps[j] = -1
+				}
+			}
+			caps[i.Arg] = len(result)
+			if i.dependency != nil {
+				result = i.dependency.generate(result, caps, r, mu)
+				if len(result) > limit {
+					return g.tooLong(result, limit)
+				}
+			}
+			i = &code[i.Out]
 		case syntax.InstMatch:
-			strresult := string(result)
-			g.runes.Put(result)
-			return strresult
+			return result, nil
 		}
 	}
 }
 
+// ctxCheckInterval is the number of InstAlt executed between the checks of the context.
+const ctxCheckInterval = 1024
+
+// fixedPointOne is the denominator of fixedPoint.
+// It is a power of two, so that rand.Int63n draws exactly one random number.
+const fixedPointOne = 1 << 62
+
+// fixedPoint approximates x/y by the fraction with the denominator fixedPointOne.
+// The error is at most 2^-63.
+// If 0 < x < y, the numerator is neither zero nor the denominator, so that both branches are possible.
+func fixedPoint(x, y *big.Int) (int64, int64) {
+	var n big.Int
+	n.Lsh(x, 62)
+	n.Add(&n, new(big.Int).Rsh(y, 1)) // round to nearest
+	n.Div(&n, y)
+	ret := n.Int64()
+	if x.Sign() > 0 && ret == 0 {
+		ret = 1
+	}
+	if x.Cmp(y) < 0 && ret == fixedPointOne {
+		ret = fixedPointOne - 1
+	}
+	return ret, fixedPointOne
+}
+
+// nopLocker is a sync.Locker that does nothing.
+// It is used when the caller owns the *rand.Rand.
+type nopLocker struct{}
+
+func (nopLocker) Lock()   {}
+func (nopLocker) Unlock() {}
+
 // RuneGenerator is random rune generator.
 type RuneGenerator struct {
 	aliases []int
@@ -285,15 +619,53 @@ func NewRuneGenerator(runes []rune, r *rand.Rand) *RuneGenerator {
 		}
 	}
 
+	pairs := len(runes) / 2
+	weights := make([]int64, pairs)
+	for i := 0; i < pairs; i++ {
+		weights[i] = int64(runes[i*2+1] - runes[i*2] + 1)
+	}
+	return newAliasRuneGenerator(runes, weights, r)
+}
+
+// NewWeightedRuneGenerator returns new RuneGenerator that chooses the i-th range of runes with the probability
+// proportional to weights[i], and chooses a rune from the range uniformly.
+// runes is pairs of the first and the last runes of the ranges, and len(weights) must be len(runes)/2.
+// The sum of weights multiplied by len(weights) must fit in int64.
+func NewWeightedRuneGenerator(runes []rune, weights []int64, r *rand.Rand) *RuneGenerator {
+	if len(runes)%2 != 0 || len(weights) != len(runes)/2 {
+		panic("rerand: the length of weights must be the half of the length of runes")
+	}
+	var sum int64
+	for _, w := range weights {
+		if w < 0 {
+			panic("rerand: negative weight")
+		}
+		sum += w
+	}
+	if sum <= 0 {
+		panic("rerand: the sum of weights must be positive")
+	}
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if len(runes) <= 2 {
+		return &RuneGenerator{
+			runes: runes,
+			rand:  r,
+		}
+	}
+	return newAliasRuneGenerator(runes, weights, r)
+}
+
+func newAliasRuneGenerator(runes []rune, weights []int64, r *rand.Rand) *RuneGenerator {
 	pairs := len(runes) / 2
 	aliases := make([]int, pairs)
 	probs := make([]int64, pairs)
 
-	// calculate weights and normalize them
+	// normalize weights
 	var sum int64
-	for i := 0; i < pairs; i++ {
+	for i, w := range weights {
 		aliases[i] = i
-		w := int64(runes[i*2+1] - runes[i*2] + 1)
 		probs[i] = w * int64(pairs)
 		sum += w
 	}
@@ -338,16 +710,22 @@ func NewRuneGenerator(runes []rune, r *rand.Rand) *RuneGenerator {
 // Generate generates random rune.
 // It is safe for concurrent use by multiple goroutines.
 func (g *RuneGenerator) Generate() rune {
+	return g.generate(g.rand, &g.mu)
+}
+
+// generate generates random rune using r.
+// mu guards r.
+func (g *RuneGenerator) generate(r *rand.Rand, mu sync.Locker) rune {
 	if len(g.runes) == 1 {
 		return g.runes[0]
 	}
 
 	i := 0
 	if len(g.runes) > 2 {
-		g.mu.Lock()
-		i = g.rand.Intn(len(g.probs))
-		v := g.rand.Int63n(g.sum)
-		g.mu.Unlock()
+		mu.Lock()
+		i = r.Intn(len(g.probs))
+		v := r.Int63n(g.sum)
+		mu.Unlock()
 		if g.probs[i] <= v {
 			i = g.aliases[i]
 		}
@@ -359,8 +737,8 @@ func (g *RuneGenerator) Generate() rune {
 		return rune(min)
 	}
 	randi := min
-	g.mu.Lock()
-	randi += g.rand.Intn(max - min + 1)
-	g.mu.Unlock()
+	mu.Lock()
+	randi += r.Intn(max - min + 1)
+	mu.Unlock()
 	return rune(randi)
 }
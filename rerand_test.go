@@ -1,6 +1,7 @@
 package rerand
 
 import (
+	"errors"
 	"math"
 	"math/rand"
 	"regexp"
@@ -13,7 +14,7 @@ func TestError(t *testing.T) {
 		t.Error("want syntax error, got nil")
 	}
 
-	if _, err := New(`[a-z]*`, syntax.Perl, nil); err != ErrTooManyRepeat {
+	if _, err := New(`[a-z]*`, syntax.Perl, nil); !errors.Is(err, ErrTooManyRepeat) {
 		t.Errorf("want too many repeat error, got %v", err)
 	}
 }
@@ -133,6 +134,81 @@ func TestRuneGenerator(t *testing.T) {
 	}
 }
 
+func TestWeightedRuneGenerator(t *testing.T) {
+	const RuneNum = 100000
+	const AllowError = 2000
+	in := []struct {
+		runes   []rune
+		weights []int64
+		want    map[rune]int
+	}{
+		{[]rune{'a', 'a', 'b', 'b'}, []int64{1, 3}, map[rune]int{'a': 1, 'b': 3}},
+		{[]rune{'a', 'b', 'x', 'x', 'z', 'z'}, []int64{2, 0, 1}, map[rune]int{'a': 1, 'b': 1, 'z': 1}},
+		{[]rune{'a', 'c'}, []int64{5}, map[rune]int{'a': 1, 'b': 1, 'c': 1}},
+	}
+
+	for _, c := range in {
+		num := 0
+		for _, n := range c.want {
+			num += n
+		}
+		g := NewWeightedRuneGenerator(c.runes, c.weights, rand.New(rand.NewSource(1)))
+		count := map[rune]int{}
+		for i := 0; i < RuneNum*num; i++ {
+			count[g.Generate()]++
+		}
+		if len(count) != len(c.want) {
+			t.Errorf("%+v: want %v, got %v", c.runes, c.want, count)
+		}
+		for r, n := range count {
+			want := RuneNum * c.want[r]
+			if n < want-AllowError || n > want+AllowError {
+				t.Errorf("%+v: incorrect count of '%c'(%d)", c.runes, r, n)
+			}
+		}
+	}
+}
+
+func TestNewFromRegexp(t *testing.T) {
+	re := regexp.MustCompile(`(?i)[a-z]{2}-\d{3,4}`)
+	g, err := NewFromRegexp(re, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.String() != re.String() {
+		t.Errorf("want %s, got %s", re.String(), g.String())
+	}
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("%q does not match %s", s, re)
+		}
+	}
+
+	if _, err := NewFromRegexp(regexp.MustCompile(`a+`), nil); !errors.Is(err, ErrTooManyRepeat) {
+		t.Errorf("want ErrTooManyRepeat, got %v", err)
+	}
+}
+
+func TestNewFromSyntax(t *testing.T) {
+	re, err := syntax.Parse(`(foo|bar){1,3}\d`, syntax.Perl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := re.String()
+	g, err := NewFromSyntax(re, rand.New(rand.NewSource(1)), WithVerify())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if _, err := g.TryGenerate(); err != nil {
+			t.Error(err)
+		}
+	}
+	if re.String() != want {
+		t.Errorf("re is modified: want %s, got %s", want, re.String())
+	}
+}
+
 func BenchmarkGenerator(b *testing.B) {
 	cases := []struct {
 		name   string
@@ -0,0 +1,46 @@
+// Package rerandarrow fills Apache Arrow arrays with random strings generated by rerand.
+package rerandarrow
+
+import (
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// AppendStrings appends n strings generated by g to b.
+// The strings are generated into a reused buffer and copied to b directly,
+// so no string is allocated per row unless g has options of post-processing, e.g. WithFilter.
+// It panics if the generation fails, in the same way as Generate.
+func AppendStrings(b *array.StringBuilder, g *rerand.Generator, n int) {
+	b.Reserve(n)
+	var buf []byte
+	for i := 0; i < n; i++ {
+		buf = g.AppendString(buf[:0])
+		b.BinaryBuilder.Append(buf)
+	}
+}
+
+// NewStringArray returns new array of n strings generated by g.
+// The caller must release the array.
+// If mem is nil, memory.DefaultAllocator is used.
+func NewStringArray(mem memory.Allocator, g *rerand.Generator, n int) *array.String {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	b := array.NewStringBuilder(mem)
+	defer b.Release()
+	AppendStrings(b, g, n)
+	return b.NewStringArray()
+}
+
+// NewStringArrays returns new arrays of n strings generated by gens, e.g. the columns of a record batch.
+// The i-th array is generated by gens[i].
+// The caller must release the arrays.
+// If mem is nil, memory.DefaultAllocator is used.
+func NewStringArrays(mem memory.Allocator, gens []*rerand.Generator, n int) []*array.String {
+	arrays := make([]*array.String, len(gens))
+	for i, g := range gens {
+		arrays[i] = NewStringArray(mem, g, n)
+	}
+	return arrays
+}
@@ -0,0 +1,77 @@
+package rerandarrow
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestNewStringArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	g := rerand.Must(rerand.New(`[a-z]{3,8}@example\.com`, syntax.Perl, rand.New(rand.NewSource(1))))
+	arr := NewStringArray(mem, g, 1000)
+	defer arr.Release()
+
+	if arr.Len() != 1000 || arr.NullN() != 0 {
+		t.Fatalf("unexpected array: len %d, nulls %d", arr.Len(), arr.NullN())
+	}
+	re := regexp.MustCompile(`^[a-z]{3,8}@example\.com$`)
+	for i := 0; i < arr.Len(); i++ {
+		if s := arr.Value(i); !re.MatchString(s) {
+			t.Errorf("%d: unexpected %q", i, s)
+		}
+	}
+
+	// same as the per-string API
+	g2 := rerand.Must(rerand.New(`[a-z]{3,8}@example\.com`, syntax.Perl, rand.New(rand.NewSource(1))))
+	for i := 0; i < arr.Len(); i++ {
+		if want := g2.Generate(); arr.Value(i) != want {
+			t.Fatalf("%d: want %q, got %q", i, want, arr.Value(i))
+		}
+	}
+}
+
+func TestNewStringArrays(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	gens := []*rerand.Generator{
+		rerand.Must(rerand.New(`\d{4}`, syntax.Perl, nil)),
+		rerand.Must(rerand.New(`[A-Z]{2}`, syntax.Perl, nil)),
+	}
+	arrays := NewStringArrays(mem, gens, 10)
+	for _, arr := range arrays {
+		defer arr.Release()
+	}
+	if len(arrays) != 2 || arrays[0].Len() != 10 || len(arrays[1].Value(9)) != 2 {
+		t.Errorf("unexpected arrays: %v", arrays)
+	}
+}
+
+func TestAppendStrings(t *testing.T) {
+	b := array.NewStringBuilder(memory.DefaultAllocator)
+	defer b.Release()
+	b.Append("first")
+	AppendStrings(b, rerand.Must(rerand.New(`x{3}`, syntax.Perl, nil)), 2)
+	arr := b.NewStringArray()
+	defer arr.Release()
+	if arr.Len() != 3 || arr.Value(0) != "first" || arr.Value(2) != "xxx" {
+		t.Errorf("unexpected array: %v", arr)
+	}
+}
+
+func BenchmarkNewStringArray(b *testing.B) {
+	g := rerand.Must(rerand.New(`[a-z]{3,8}@example\.com`, syntax.Perl, rand.New(rand.NewSource(1))))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		arr := NewStringArray(nil, g, 1024)
+		arr.Release()
+	}
+}
@@ -0,0 +1,154 @@
+// Package rerandfake bridges rerand and gofakeit.
+// Register makes rerand generators available as gofakeit functions, e.g. `{orderid}` in templates and struct tags,
+// and Schema mixes rerand patterns and gofakeit templates in a record.
+package rerandfake
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/brianvoe/gofakeit/v7"
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// Category is the category of the functions registered by Register.
+const Category = "rerand"
+
+// TemplatePrefix begins the fields of Schema that are gofakeit templates, e.g. "fake:{firstname} {lastname}".
+const TemplatePrefix = "fake:"
+
+// Register registers g as the gofakeit function name,
+// so that gofakeit.Generate("{name}") and the struct tag `fake:"{name}"` generate strings that match the pattern of g.
+// The strings are generated with the random source of the Faker, so a seeded Faker generates reproducible strings.
+// It returns an error if name is empty or already registered.
+func Register(name string, g *rerand.Generator) error {
+	if name == "" {
+		return errors.New("rerandfake: empty function name")
+	}
+	if gofakeit.GetFuncLookup(name) != nil {
+		return fmt.Errorf("rerandfake: function %q is already registered", name)
+	}
+	fn := g.Func()
+	gofakeit.AddFuncLookup(name, gofakeit.Info{
+		Display:     name,
+		Category:    Category,
+		Description: "Random string matching " + g.String(),
+		Example:     g.Generate(),
+		Output:      "string",
+		Generate: func(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (any, error) {
+			return fn(newRand(f)), nil
+		},
+	})
+	return nil
+}
+
+// Unregister removes the gofakeit function registered by Register.
+func Unregister(name string) {
+	if info := gofakeit.GetFuncLookup(name); info != nil && info.Category == Category {
+		gofakeit.RemoveFuncLookup(name)
+	}
+}
+
+// newRand returns *rand.Rand that draws random numbers from f.
+func newRand(f *gofakeit.Faker) *rand.Rand {
+	return rand.New(fakerSource{f})
+}
+
+// fakerSource is rand.Source64 backed by gofakeit.Faker.
+type fakerSource struct {
+	f *gofakeit.Faker
+}
+
+func (s fakerSource) Int63() int64 {
+	return int64(s.f.Uint64() >> 1)
+}
+
+func (s fakerSource) Uint64() uint64 {
+	return s.f.Uint64()
+}
+
+func (s fakerSource) Seed(seed int64) {
+	panic("rerandfake: the source of Faker cannot be seeded")
+}
+
+// Schema generates records whose fields are rerand patterns or gofakeit templates.
+type Schema struct {
+	names  []string
+	fields []field
+
+	mu    sync.Mutex
+	faker *gofakeit.Faker
+}
+
+// field is either a generator or a template.
+type field struct {
+	gen      func(r *rand.Rand) string
+	template string
+}
+
+// NewSchema returns new Schema.
+// fields maps the name of each field to its pattern, or to its gofakeit template prefixed by TemplatePrefix,
+// e.g. map[string]string{"id": `[0-9a-f]{8}`, "name": "fake:{firstname} {lastname}"}.
+// The patterns are compiled with flags and opts.
+// All the fields draw random numbers from f, so a seeded Faker generates reproducible records.
+// If f is nil, gofakeit.GlobalFaker is used.
+func NewSchema(fields map[string]string, flags syntax.Flags, f *gofakeit.Faker, opts ...rerand.Option) (*Schema, error) {
+	if f == nil {
+		f = gofakeit.GlobalFaker
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := &Schema{
+		names:  names,
+		fields: make([]field, len(names)),
+		faker:  f,
+	}
+	for i, name := range names {
+		if template, ok := strings.CutPrefix(fields[name], TemplatePrefix); ok {
+			s.fields[i].template = template
+			continue
+		}
+		g, err := rerand.New(fields[name], flags, nil, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("rerandfake: field %q: %w", name, err)
+		}
+		s.fields[i].gen = g.Func()
+	}
+	return s, nil
+}
+
+// Fields returns the names of the fields in sorted order.
+func (s *Schema) Fields() []string {
+	return append([]string(nil), s.names...)
+}
+
+// GenerateRecord generates a random record.
+// It returns an error if a template is invalid.
+// It is safe for concurrent use by multiple goroutines.
+func (s *Schema) GenerateRecord() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r := newRand(s.faker)
+	record := make(map[string]string, len(s.names))
+	for i, f := range s.fields {
+		if f.gen != nil {
+			record[s.names[i]] = f.gen(r)
+			continue
+		}
+		v, err := s.faker.Generate(f.template)
+		if err != nil {
+			return nil, fmt.Errorf("rerandfake: field %q: %w", s.names[i], err)
+		}
+		record[s.names[i]] = v
+	}
+	return record, nil
+}
@@ -0,0 +1,101 @@
+package rerandfake
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v7"
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestRegister(t *testing.T) {
+	g := rerand.Must(rerand.New(`ORD-[0-9]{6}`, syntax.Perl, nil))
+	if err := Register("rerandorderid", g); err != nil {
+		t.Fatal(err)
+	}
+	defer Unregister("rerandorderid")
+	if err := Register("rerandorderid", g); err == nil {
+		t.Error("want error, got nil")
+	}
+
+	re := regexp.MustCompile(`^ORD-[0-9]{6}$`)
+	f := gofakeit.New(1)
+	s, err := f.Generate("{rerandorderid}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString(s) {
+		t.Errorf("unexpected %q", s)
+	}
+
+	// the seeded faker generates the same strings.
+	s2, err := gofakeit.New(1).Generate("{rerandorderid}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != s2 {
+		t.Errorf("want %q, got %q", s, s2)
+	}
+
+	var v struct {
+		ID string `fake:"{rerandorderid}"`
+	}
+	if err := f.Struct(&v); err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString(v.ID) {
+		t.Errorf("unexpected %q", v.ID)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	// the functions of gofakeit are not removed.
+	Unregister("firstname")
+	if gofakeit.GetFuncLookup("firstname") == nil {
+		t.Error("firstname is removed")
+	}
+}
+
+func TestSchema(t *testing.T) {
+	fields := map[string]string{
+		"id":   `[0-9a-f]{8}`,
+		"name": "fake:{firstname} {lastname}",
+	}
+	s, err := NewSchema(fields, syntax.Perl, gofakeit.New(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(s.Fields(), ","); got != "id,name" {
+		t.Errorf("unexpected fields %s", got)
+	}
+	record, err := s.GenerateRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}$`).MatchString(record["id"]) {
+		t.Errorf("unexpected id %q", record["id"])
+	}
+	if first, last, ok := strings.Cut(record["name"], " "); !ok || first == "" || last == "" {
+		t.Errorf("unexpected name %q", record["name"])
+	}
+
+	s2, err := NewSchema(fields, syntax.Perl, gofakeit.New(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	record2, err := s2.GenerateRecord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record["id"] != record2["id"] || record["name"] != record2["name"] {
+		t.Errorf("want %v, got %v", record, record2)
+	}
+}
+
+func TestSchemaError(t *testing.T) {
+	if _, err := NewSchema(map[string]string{"id": `[a-z`}, syntax.Perl, nil); err == nil {
+		t.Error("want error, got nil")
+	}
+}
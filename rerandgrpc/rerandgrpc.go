@@ -0,0 +1,308 @@
+// Package rerandgrpc provides a gRPC service that generates random strings by registered patterns.
+// The protocol is defined in rerandv1/rerand.proto, so clients in any language can use it.
+//
+//	s := grpc.NewServer()
+//	rerandv1.RegisterRerandServiceServer(s, rerandgrpc.NewServer())
+package rerandgrpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+	"sync"
+	"time"
+
+	rerand "github.com/shogo82148/go-rerand"
+	"github.com/shogo82148/go-rerand/rerandgrpc/rerandv1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultMaxPatterns is the default maximum number of registered patterns.
+	DefaultMaxPatterns = 1024
+
+	// DefaultMaxPatternLength is the default maximum length of patterns in bytes.
+	DefaultMaxPatternLength = 1024
+
+	// DefaultMaxBatch is the default maximum number of strings generated by GenerateBatch.
+	DefaultMaxBatch = 1000
+
+	// DefaultQuotaInterval is the default interval of Quota.
+	DefaultQuotaInterval = time.Minute
+
+	// DefaultMaxLength is the default maximum length of generated strings in runes.
+	DefaultMaxLength = 1 << 16
+
+	// DefaultCompileBudget is the default memory budget of compiling a pattern in bytes.
+	DefaultCompileBudget = 64 << 20
+)
+
+// Server implements rerandv1.RerandServiceServer.
+type Server struct {
+	rerandv1.UnimplementedRerandServiceServer
+
+	// Flags is used for parsing patterns. If zero, syntax.Perl is used.
+	Flags syntax.Flags
+
+	// MaxPatterns is the maximum number of registered patterns. If zero, DefaultMaxPatterns is used.
+	MaxPatterns int
+
+	// MaxPatternLength is the maximum length of patterns. If zero, DefaultMaxPatternLength is used.
+	MaxPatternLength int
+
+	// MaxBatch is the maximum number of strings generated by GenerateBatch. If zero, DefaultMaxBatch is used.
+	MaxBatch int
+
+	// Quota is the maximum number of strings generated by each pattern in QuotaInterval. If zero, it is unlimited.
+	Quota int
+
+	// QuotaInterval is the interval of Quota. If zero, DefaultQuotaInterval is used.
+	QuotaInterval time.Duration
+
+	// MaxLength is the maximum length of generated strings in runes, and of target_length.
+	// If zero, DefaultMaxLength is used.
+	MaxLength int
+
+	// CompileBudget is the memory budget of compiling a pattern in bytes. If zero, DefaultCompileBudget is used.
+	CompileBudget int64
+
+	mu       sync.Mutex
+	patterns map[string]*entry                // the name to the registered pattern
+	compiled map[compileKey]*rerand.Generator // the compiled patterns shared among the names
+}
+
+type compileKey struct {
+	pattern       string
+	distinctRunes bool
+	targetLength  float64
+}
+
+type entry struct {
+	key compileKey
+	gen *rerand.Generator
+
+	// the number of generated strings in the current window of the quota
+	used        int
+	windowStart time.Time
+}
+
+// NewServer returns new Server.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// RegisterPattern implements rerandv1.RerandServiceServer.
+func (s *Server) RegisterPattern(ctx context.Context, req *rerandv1.RegisterPatternRequest) (*rerandv1.RegisterPatternResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.GetPattern()) > s.maxPatternLength() {
+		return nil, status.Error(codes.InvalidArgument, "pattern is too long")
+	}
+	if req.GetTargetLength() > float64(s.maxLength()) {
+		return nil, status.Error(codes.InvalidArgument, "target_length is too large")
+	}
+	key := compileKey{
+		pattern:       req.GetPattern(),
+		distinctRunes: req.GetDistinctRunes(),
+		targetLength:  req.GetTargetLength(),
+	}
+	var r *rand.Rand
+	if seed := req.GetSeed(); seed != 0 {
+		r = rand.New(rand.NewSource(seed))
+	}
+
+	// compile the pattern without the lock, because it may take a while.
+	s.mu.Lock()
+	compiled, ok := s.compiled[key]
+	s.mu.Unlock()
+	if !ok {
+		var err error
+		compiled, err = s.compile(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.patterns == nil {
+		s.patterns = make(map[string]*entry)
+		s.compiled = make(map[compileKey]*rerand.Generator)
+	}
+	if _, ok := s.patterns[req.GetName()]; !ok && len(s.patterns) >= s.maxPatterns() {
+		return nil, status.Error(codes.ResourceExhausted, "too many patterns")
+	}
+	if c, ok := s.compiled[key]; ok {
+		// another request has compiled the same pattern in the meantime.
+		compiled = c
+	} else {
+		s.compiled[key] = compiled
+	}
+	if old, ok := s.patterns[req.GetName()]; ok {
+		s.release(old)
+	}
+	s.patterns[req.GetName()] = &entry{
+		key: key,
+		gen: compiled.Clone(r),
+	}
+
+	maxLen, ok := compiled.MaxLen()
+	if !ok {
+		maxLen = -1
+	}
+	return &rerandv1.RegisterPatternResponse{MaxLength: int64(maxLen)}, nil
+}
+
+// compile compiles the pattern of key.
+func (s *Server) compile(key compileKey) (*rerand.Generator, error) {
+	opts := []rerand.Option{
+		rerand.WithMaxLength(s.maxLength(), rerand.MaxLengthError),
+		rerand.WithCompileBudget(s.compileBudget()),
+	}
+	if key.distinctRunes {
+		opts = append(opts, rerand.WithDistinctRunes())
+	}
+	if key.targetLength != 0 {
+		opts = append(opts, rerand.WithTargetLength(key.targetLength))
+	}
+	g, err := rerand.New(key.pattern, s.flags(), nil, opts...)
+	if err != nil {
+		if errors.Is(err, rerand.ErrCompileBudget) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return g, nil
+}
+
+// release removes the compiled pattern if no name uses it.
+// s.mu must be held.
+func (s *Server) release(e *entry) {
+	for _, other := range s.patterns {
+		if other != e && other.key == e.key {
+			return
+		}
+	}
+	delete(s.compiled, e.key)
+}
+
+// Generate implements rerandv1.RerandServiceServer.
+func (s *Server) Generate(ctx context.Context, req *rerandv1.GenerateRequest) (*rerandv1.GenerateResponse, error) {
+	g, err := s.acquire(req.GetName(), 1)
+	if err != nil {
+		return nil, err
+	}
+	v, err := g.GenerateContext(ctx)
+	if err != nil {
+		return nil, generateError(ctx, err)
+	}
+	return &rerandv1.GenerateResponse{Value: v}, nil
+}
+
+// GenerateBatch implements rerandv1.RerandServiceServer.
+func (s *Server) GenerateBatch(ctx context.Context, req *rerandv1.GenerateBatchRequest) (*rerandv1.GenerateBatchResponse, error) {
+	n := int(req.GetN())
+	if n < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid n")
+	}
+	if n > s.maxBatch() {
+		return nil, status.Error(codes.InvalidArgument, "n is too large")
+	}
+	g, err := s.acquire(req.GetName(), n)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, n)
+	for i := range values {
+		values[i], err = g.GenerateContext(ctx)
+		if err != nil {
+			return nil, generateError(ctx, err)
+		}
+	}
+	return &rerandv1.GenerateBatchResponse{Values: values}, nil
+}
+
+// generateError converts the error of GenerateContext into the status.
+func generateError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(err).Err()
+	}
+	if errors.Is(err, rerand.ErrTooLong) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// acquire returns the generator of the name, and consumes n strings of the quota.
+func (s *Server) acquire(name string, n int) (*rerand.Generator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.patterns[name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "pattern %q is not registered", name)
+	}
+	if s.Quota > 0 {
+		now := time.Now()
+		if now.Sub(e.windowStart) >= s.quotaInterval() {
+			e.windowStart = now
+			e.used = 0
+		}
+		if e.used+n > s.Quota {
+			return nil, status.Errorf(codes.ResourceExhausted, "quota of pattern %q is exceeded", name)
+		}
+		e.used += n
+	}
+	return e.gen, nil
+}
+
+func (s *Server) flags() syntax.Flags {
+	if s.Flags == 0 {
+		return syntax.Perl
+	}
+	return s.Flags
+}
+
+func (s *Server) maxPatterns() int {
+	if s.MaxPatterns == 0 {
+		return DefaultMaxPatterns
+	}
+	return s.MaxPatterns
+}
+
+func (s *Server) maxPatternLength() int {
+	if s.MaxPatternLength == 0 {
+		return DefaultMaxPatternLength
+	}
+	return s.MaxPatternLength
+}
+
+func (s *Server) maxBatch() int {
+	if s.MaxBatch == 0 {
+		return DefaultMaxBatch
+	}
+	return s.MaxBatch
+}
+
+func (s *Server) maxLength() int {
+	if s.MaxLength == 0 {
+		return DefaultMaxLength
+	}
+	return s.MaxLength
+}
+
+func (s *Server) compileBudget() int64 {
+	if s.CompileBudget == 0 {
+		return DefaultCompileBudget
+	}
+	return s.CompileBudget
+}
+
+func (s *Server) quotaInterval() time.Duration {
+	if s.QuotaInterval == 0 {
+		return DefaultQuotaInterval
+	}
+	return s.QuotaInterval
+}
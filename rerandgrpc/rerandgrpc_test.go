@@ -0,0 +1,263 @@
+package rerandgrpc
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/shogo82148/go-rerand/rerandgrpc/rerandv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newClient(t *testing.T, srv *Server) rerandv1.RerandServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	rerandv1.RegisterRerandServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rerandv1.NewRerandServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, NewServer())
+	re := regexp.MustCompile(`^[a-z]{8}$`)
+
+	res, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "id", Pattern: `[a-z]{8}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetMaxLength() != 8 {
+		t.Errorf("want max length 8, got %d", res.GetMaxLength())
+	}
+
+	gen, err := c.Generate(ctx, &rerandv1.GenerateRequest{Name: "id"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString(gen.GetValue()) {
+		t.Errorf("%q does not match", gen.GetValue())
+	}
+
+	batch, err := c.GenerateBatch(ctx, &rerandv1.GenerateBatchRequest{Name: "id", N: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.GetValues()) != 10 {
+		t.Errorf("want 10 values, got %d", len(batch.GetValues()))
+	}
+	for _, s := range batch.GetValues() {
+		if !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+}
+
+func TestServerUnbounded(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, NewServer())
+	res, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "any", Pattern: `a+`, TargetLength: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.GetMaxLength() != -1 {
+		t.Errorf("want max length -1, got %d", res.GetMaxLength())
+	}
+}
+
+func TestServerSeed(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, NewServer())
+	for _, name := range []string{"a", "b"} {
+		_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: name, Pattern: `\d{6}`, Seed: 42})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, err := c.GenerateBatch(ctx, &rerandv1.GenerateBatchRequest{Name: "a", N: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := c.GenerateBatch(ctx, &rerandv1.GenerateBatchRequest{Name: "b", N: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range a.GetValues() {
+		if a.GetValues()[i] != b.GetValues()[i] {
+			t.Errorf("want same values, got %v and %v", a.GetValues(), b.GetValues())
+			break
+		}
+	}
+}
+
+func TestServerCache(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer()
+	c := newClient(t, srv)
+	for _, name := range []string{"a", "b"} {
+		_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: name, Pattern: `[a-z]{8}`})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(srv.compiled) != 1 {
+		t.Errorf("want 1 compiled pattern, got %d", len(srv.compiled))
+	}
+
+	// replace the patterns, and the unused compiled pattern is released.
+	for _, name := range []string{"a", "b"} {
+		_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: name, Pattern: `[0-9]{8}`})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(srv.compiled) != 1 {
+		t.Errorf("want 1 compiled pattern, got %d", len(srv.compiled))
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer()
+	srv.MaxPatterns = 1
+	srv.MaxPatternLength = 16
+	srv.MaxBatch = 5
+	srv.MaxLength = 10
+	srv.CompileBudget = 1 << 16
+	c := newClient(t, srv)
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{
+			name: "empty name",
+			call: func() error {
+				_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Pattern: `a`})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "invalid pattern",
+			call: func() error {
+				_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "x", Pattern: `(`})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "too long pattern",
+			call: func() error {
+				_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "x", Pattern: `aaaaaaaaaaaaaaaaa`})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "too large target length",
+			call: func() error {
+				_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "x", Pattern: `a*`, TargetLength: 11})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "compile budget",
+			call: func() error {
+				_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "x", Pattern: `\pL{1000}`})
+				return err
+			},
+			code: codes.ResourceExhausted,
+		},
+		{
+			name: "not found",
+			call: func() error {
+				_, err := c.Generate(ctx, &rerandv1.GenerateRequest{Name: "unknown"})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "register",
+			call: func() error {
+				_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "x", Pattern: `a`})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name: "too many patterns",
+			call: func() error {
+				_, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "y", Pattern: `a`})
+				return err
+			},
+			code: codes.ResourceExhausted,
+		},
+		{
+			name: "too large batch",
+			call: func() error {
+				_, err := c.GenerateBatch(ctx, &rerandv1.GenerateBatchRequest{Name: "x", N: 6})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "too long string",
+			call: func() error {
+				if _, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "x", Pattern: `a{11}`}); err != nil {
+					return err
+				}
+				_, err := c.Generate(ctx, &rerandv1.GenerateRequest{Name: "x"})
+				return err
+			},
+			code: codes.OutOfRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.code {
+				t.Errorf("want %v, got %v", tt.code, got)
+			}
+		})
+	}
+}
+
+func TestServerQuota(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer()
+	srv.Quota = 5
+	c := newClient(t, srv)
+	if _, err := c.RegisterPattern(ctx, &rerandv1.RegisterPatternRequest{Name: "x", Pattern: `a`}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GenerateBatch(ctx, &rerandv1.GenerateBatchRequest{Name: "x", N: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Generate(ctx, &rerandv1.GenerateRequest{Name: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.Generate(ctx, &rerandv1.GenerateRequest{Name: "x"})
+	if got := status.Code(err); got != codes.ResourceExhausted {
+		t.Errorf("want %v, got %v", codes.ResourceExhausted, got)
+	}
+}
@@ -0,0 +1,4 @@
+// Package rerandv1 is the protocol of the rerand gRPC service.
+package rerandv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative rerand.proto
@@ -0,0 +1,422 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.29.3
+// source: rerand.proto
+
+package rerandv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RegisterPatternRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the pattern. If a pattern is already registered with the name, it is replaced.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The pattern in the syntax of Go's regexp package.
+	Pattern string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Makes each distinct string equally likely.
+	DistinctRunes bool `protobuf:"varint,3,opt,name=distinct_runes,json=distinctRunes,proto3" json:"distinct_runes,omitempty"`
+	// The expected length of generated strings, which allows unbounded repetitions such as `*` and `+`.
+	TargetLength float64 `protobuf:"fixed64,4,opt,name=target_length,json=targetLength,proto3" json:"target_length,omitempty"`
+	// The seed of the random number generator. If zero, the generator is seeded with current time.
+	Seed          int64 `protobuf:"varint,5,opt,name=seed,proto3" json:"seed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPatternRequest) Reset() {
+	*x = RegisterPatternRequest{}
+	mi := &file_rerand_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPatternRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPatternRequest) ProtoMessage() {}
+
+func (x *RegisterPatternRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rerand_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPatternRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPatternRequest) Descriptor() ([]byte, []int) {
+	return file_rerand_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RegisterPatternRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterPatternRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *RegisterPatternRequest) GetDistinctRunes() bool {
+	if x != nil {
+		return x.DistinctRunes
+	}
+	return false
+}
+
+func (x *RegisterPatternRequest) GetTargetLength() float64 {
+	if x != nil {
+		return x.TargetLength
+	}
+	return 0
+}
+
+func (x *RegisterPatternRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type RegisterPatternResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The maximum length in runes of generated strings, or -1 if it is unbounded.
+	MaxLength     int64 `protobuf:"varint,1,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPatternResponse) Reset() {
+	*x = RegisterPatternResponse{}
+	mi := &file_rerand_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPatternResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPatternResponse) ProtoMessage() {}
+
+func (x *RegisterPatternResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rerand_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPatternResponse.ProtoReflect.Descriptor instead.
+func (*RegisterPatternResponse) Descriptor() ([]byte, []int) {
+	return file_rerand_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterPatternResponse) GetMaxLength() int64 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+type GenerateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the registered pattern.
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_rerand_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rerand_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_rerand_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GenerateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_rerand_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rerand_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_rerand_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GenerateResponse) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type GenerateBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the registered pattern.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The number of strings.
+	N             int32 `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBatchRequest) Reset() {
+	*x = GenerateBatchRequest{}
+	mi := &file_rerand_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBatchRequest) ProtoMessage() {}
+
+func (x *GenerateBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rerand_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBatchRequest.ProtoReflect.Descriptor instead.
+func (*GenerateBatchRequest) Descriptor() ([]byte, []int) {
+	return file_rerand_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GenerateBatchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GenerateBatchRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type GenerateBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateBatchResponse) Reset() {
+	*x = GenerateBatchResponse{}
+	mi := &file_rerand_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateBatchResponse) ProtoMessage() {}
+
+func (x *GenerateBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rerand_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateBatchResponse.ProtoReflect.Descriptor instead.
+func (*GenerateBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rerand_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GenerateBatchResponse) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_rerand_proto protoreflect.FileDescriptor
+
+const file_rerand_proto_rawDesc = "" +
+	"\n" +
+	"\frerand.proto\x12\trerand.v1\"\xa6\x01\n" +
+	"\x16RegisterPatternRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\apattern\x18\x02 \x01(\tR\apattern\x12%\n" +
+	"\x0edistinct_runes\x18\x03 \x01(\bR\rdistinctRunes\x12#\n" +
+	"\rtarget_length\x18\x04 \x01(\x01R\ftargetLength\x12\x12\n" +
+	"\x04seed\x18\x05 \x01(\x03R\x04seed\"8\n" +
+	"\x17RegisterPatternResponse\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x01 \x01(\x03R\tmaxLength\"%\n" +
+	"\x0fGenerateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"(\n" +
+	"\x10GenerateResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"8\n" +
+	"\x14GenerateBatchRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\f\n" +
+	"\x01n\x18\x02 \x01(\x05R\x01n\"/\n" +
+	"\x15GenerateBatchResponse\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values2\x82\x02\n" +
+	"\rRerandService\x12X\n" +
+	"\x0fRegisterPattern\x12!.rerand.v1.RegisterPatternRequest\x1a\".rerand.v1.RegisterPatternResponse\x12C\n" +
+	"\bGenerate\x12\x1a.rerand.v1.GenerateRequest\x1a\x1b.rerand.v1.GenerateResponse\x12R\n" +
+	"\rGenerateBatch\x12\x1f.rerand.v1.GenerateBatchRequest\x1a .rerand.v1.GenerateBatchResponseB5Z3github.com/shogo82148/go-rerand/rerandgrpc/rerandv1b\x06proto3"
+
+var (
+	file_rerand_proto_rawDescOnce sync.Once
+	file_rerand_proto_rawDescData []byte
+)
+
+func file_rerand_proto_rawDescGZIP() []byte {
+	file_rerand_proto_rawDescOnce.Do(func() {
+		file_rerand_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rerand_proto_rawDesc), len(file_rerand_proto_rawDesc)))
+	})
+	return file_rerand_proto_rawDescData
+}
+
+var file_rerand_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_rerand_proto_goTypes = []any{
+	(*RegisterPatternRequest)(nil),  // 0: rerand.v1.RegisterPatternRequest
+	(*RegisterPatternResponse)(nil), // 1: rerand.v1.RegisterPatternResponse
+	(*GenerateRequest)(nil),         // 2: rerand.v1.GenerateRequest
+	(*GenerateResponse)(nil),        // 3: rerand.v1.GenerateResponse
+	(*GenerateBatchRequest)(nil),    // 4: rerand.v1.GenerateBatchRequest
+	(*GenerateBatchResponse)(nil),   // 5: rerand.v1.GenerateBatchResponse
+}
+var file_rerand_proto_depIdxs = []int32{
+	0, // 0: rerand.v1.RerandService.RegisterPattern:input_type -> rerand.v1.RegisterPatternRequest
+	2, // 1: rerand.v1.RerandService.Generate:input_type -> rerand.v1.GenerateRequest
+	4, // 2: rerand.v1.RerandService.GenerateBatch:input_type -> rerand.v1.GenerateBatchRequest
+	1, // 3: rerand.v1.RerandService.RegisterPattern:output_type -> rerand.v1.RegisterPatternResponse
+	3, // 4: rerand.v1.RerandService.Generate:output_type -> rerand.v1.GenerateResponse
+	5, // 5: rerand.v1.RerandService.GenerateBatch:output_type -> rerand.v1.GenerateBatchResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rerand_proto_init() }
+func file_rerand_proto_init() {
+	if File_rerand_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rerand_proto_rawDesc), len(file_rerand_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rerand_proto_goTypes,
+		DependencyIndexes: file_rerand_proto_depIdxs,
+		MessageInfos:      file_rerand_proto_msgTypes,
+	}.Build()
+	File_rerand_proto = out.File
+	file_rerand_proto_goTypes = nil
+	file_rerand_proto_depIdxs = nil
+}
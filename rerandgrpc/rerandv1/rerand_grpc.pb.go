@@ -0,0 +1,207 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v5.29.3
+// source: rerand.proto
+
+package rerandv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RerandService_RegisterPattern_FullMethodName = "/rerand.v1.RerandService/RegisterPattern"
+	RerandService_Generate_FullMethodName        = "/rerand.v1.RerandService/Generate"
+	RerandService_GenerateBatch_FullMethodName   = "/rerand.v1.RerandService/GenerateBatch"
+)
+
+// RerandServiceClient is the client API for RerandService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RerandService generates random strings that match registered patterns.
+type RerandServiceClient interface {
+	// RegisterPattern compiles the pattern and registers it with the name.
+	RegisterPattern(ctx context.Context, in *RegisterPatternRequest, opts ...grpc.CallOption) (*RegisterPatternResponse, error)
+	// Generate generates a random string by the registered pattern.
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+	// GenerateBatch generates random strings by the registered pattern.
+	GenerateBatch(ctx context.Context, in *GenerateBatchRequest, opts ...grpc.CallOption) (*GenerateBatchResponse, error)
+}
+
+type rerandServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRerandServiceClient(cc grpc.ClientConnInterface) RerandServiceClient {
+	return &rerandServiceClient{cc}
+}
+
+func (c *rerandServiceClient) RegisterPattern(ctx context.Context, in *RegisterPatternRequest, opts ...grpc.CallOption) (*RegisterPatternResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterPatternResponse)
+	err := c.cc.Invoke(ctx, RerandService_RegisterPattern_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rerandServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, RerandService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rerandServiceClient) GenerateBatch(ctx context.Context, in *GenerateBatchRequest, opts ...grpc.CallOption) (*GenerateBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateBatchResponse)
+	err := c.cc.Invoke(ctx, RerandService_GenerateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RerandServiceServer is the server API for RerandService service.
+// All implementations must embed UnimplementedRerandServiceServer
+// for forward compatibility.
+//
+// RerandService generates random strings that match registered patterns.
+type RerandServiceServer interface {
+	// RegisterPattern compiles the pattern and registers it with the name.
+	RegisterPattern(context.Context, *RegisterPatternRequest) (*RegisterPatternResponse, error)
+	// Generate generates a random string by the registered pattern.
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	// GenerateBatch generates random strings by the registered pattern.
+	GenerateBatch(context.Context, *GenerateBatchRequest) (*GenerateBatchResponse, error)
+	mustEmbedUnimplementedRerandServiceServer()
+}
+
+// UnimplementedRerandServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRerandServiceServer struct{}
+
+func (UnimplementedRerandServiceServer) RegisterPattern(context.Context, *RegisterPatternRequest) (*RegisterPatternResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterPattern not implemented")
+}
+func (UnimplementedRerandServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedRerandServiceServer) GenerateBatch(context.Context, *GenerateBatchRequest) (*GenerateBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateBatch not implemented")
+}
+func (UnimplementedRerandServiceServer) mustEmbedUnimplementedRerandServiceServer() {}
+func (UnimplementedRerandServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRerandServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RerandServiceServer will
+// result in compilation errors.
+type UnsafeRerandServiceServer interface {
+	mustEmbedUnimplementedRerandServiceServer()
+}
+
+func RegisterRerandServiceServer(s grpc.ServiceRegistrar, srv RerandServiceServer) {
+	// If the following call panics, it indicates UnimplementedRerandServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RerandService_ServiceDesc, srv)
+}
+
+func _RerandService_RegisterPattern_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPatternRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RerandServiceServer).RegisterPattern(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RerandService_RegisterPattern_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RerandServiceServer).RegisterPattern(ctx, req.(*RegisterPatternRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RerandService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RerandServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RerandService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RerandServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RerandService_GenerateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RerandServiceServer).GenerateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RerandService_GenerateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RerandServiceServer).GenerateBatch(ctx, req.(*GenerateBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RerandService_ServiceDesc is the grpc.ServiceDesc for RerandService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RerandService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rerand.v1.RerandService",
+	HandlerType: (*RerandServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterPattern",
+			Handler:    _RerandService_RegisterPattern_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _RerandService_Generate_Handler,
+		},
+		{
+			MethodName: "GenerateBatch",
+			Handler:    _RerandService_GenerateBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rerand.proto",
+}
@@ -0,0 +1,197 @@
+// Package rerandhttp provides an http.Handler that serves random strings generated by rerand.
+package rerandhttp
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"regexp/syntax"
+	"strconv"
+	"sync"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+const (
+	// DefaultMaxN is the default maximum number of strings generated by a request.
+	DefaultMaxN = 1000
+
+	// DefaultMaxPatternLength is the default maximum length of patterns in bytes.
+	DefaultMaxPatternLength = 1024
+
+	// DefaultCacheSize is the default number of cached generators.
+	DefaultCacheSize = 128
+
+	// DefaultMaxLength is the default maximum length of generated strings in runes.
+	DefaultMaxLength = 1 << 16
+
+	// DefaultCompileBudget is the default memory budget of compiling a pattern in bytes.
+	DefaultCompileBudget = 64 << 20
+)
+
+// Handler serves random strings.
+//
+//	GET /generate?pattern=[a-z]{8}&n=10&format=text
+//
+// The format is "json" (default) or "text" (newline-delimited).
+// If seed is given, the result is reproducible and the generator is not cached.
+type Handler struct {
+	// Flags is used for parsing patterns. If zero, syntax.Perl is used.
+	Flags syntax.Flags
+
+	// MaxN is the maximum number of strings generated by a request. If zero, DefaultMaxN is used.
+	MaxN int
+
+	// MaxPatternLength is the maximum length of patterns. If zero, DefaultMaxPatternLength is used.
+	MaxPatternLength int
+
+	// CacheSize is the number of cached generators. If zero, DefaultCacheSize is used.
+	CacheSize int
+
+	// MaxLength is the maximum length of generated strings in runes. If zero, DefaultMaxLength is used.
+	MaxLength int
+
+	// CompileBudget is the memory budget of compiling a pattern in bytes. If zero, DefaultCompileBudget is used.
+	CompileBudget int64
+
+	once  sync.Once
+	cache *rerand.Cache
+}
+
+// NewHandler returns new Handler.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := req.URL.Query()
+	pattern := query.Get("pattern")
+	if len(pattern) > h.maxPatternLength() {
+		http.Error(w, "pattern is too long", http.StatusBadRequest)
+		return
+	}
+
+	n := 1
+	if s := query.Get("n"); s != "" {
+		var err error
+		n, err = strconv.Atoi(s)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid n", http.StatusBadRequest)
+			return
+		}
+	}
+	if n > h.maxN() {
+		http.Error(w, "n is too large", http.StatusBadRequest)
+		return
+	}
+
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "text" {
+		http.Error(w, "invalid format", http.StatusBadRequest)
+		return
+	}
+
+	var g *rerand.Generator
+	var err error
+	if s := query.Get("seed"); s != "" {
+		seed, perr := strconv.ParseInt(s, 10, 64)
+		if perr != nil {
+			http.Error(w, "invalid seed", http.StatusBadRequest)
+			return
+		}
+		g, err = rerand.New(pattern, h.flags(), rand.New(rand.NewSource(seed)), h.options()...)
+	} else {
+		g, err = h.generator(pattern)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	results := make([]string, n)
+	for i := range results {
+		results[i], err = g.TryGenerate()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if format == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, s := range results {
+			w.Write([]byte(s))
+			w.Write([]byte{'\n'})
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(results)
+}
+
+// generator returns the cached generator for the pattern, or compiles new one.
+func (h *Handler) generator(pattern string) (*rerand.Generator, error) {
+	h.once.Do(func() {
+		h.cache = rerand.NewCache(h.cacheSize())
+	})
+	return h.cache.Get(pattern, h.flags(), h.options()...)
+}
+
+// options returns the options that limit the resources of the pattern given by the client.
+func (h *Handler) options() []rerand.Option {
+	return []rerand.Option{
+		rerand.WithMaxLength(h.maxLength(), rerand.MaxLengthError),
+		rerand.WithCompileBudget(h.compileBudget()),
+	}
+}
+
+func (h *Handler) flags() syntax.Flags {
+	if h.Flags == 0 {
+		return syntax.Perl
+	}
+	return h.Flags
+}
+
+func (h *Handler) maxN() int {
+	if h.MaxN == 0 {
+		return DefaultMaxN
+	}
+	return h.MaxN
+}
+
+func (h *Handler) maxPatternLength() int {
+	if h.MaxPatternLength == 0 {
+		return DefaultMaxPatternLength
+	}
+	return h.MaxPatternLength
+}
+
+func (h *Handler) maxLength() int {
+	if h.MaxLength == 0 {
+		return DefaultMaxLength
+	}
+	return h.MaxLength
+}
+
+func (h *Handler) compileBudget() int64 {
+	if h.CompileBudget == 0 {
+		return DefaultCompileBudget
+	}
+	return h.CompileBudget
+}
+
+func (h *Handler) cacheSize() int {
+	if h.CacheSize == 0 {
+		return DefaultCacheSize
+	}
+	return h.CacheSize
+}
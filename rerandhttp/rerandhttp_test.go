@@ -0,0 +1,120 @@
+package rerandhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	h := NewHandler()
+	re := regexp.MustCompile(`^[a-z]{8}$`)
+
+	req := httptest.NewRequest(http.MethodGet, "/generate?n=10&pattern="+url.QueryEscape(`[a-z]{8}`), nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want %d, got %d", http.StatusOK, rec.Code)
+	}
+	var results []string
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 10 {
+		t.Errorf("want 10 results, got %d", len(results))
+	}
+	for _, s := range results {
+		if !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/generate?format=text&n=5&pattern="+url.QueryEscape(`[a-z]{8}`), nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Errorf("want 5 lines, got %d", len(lines))
+	}
+	for _, s := range lines {
+		if !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+}
+
+func TestHandlerSeed(t *testing.T) {
+	h := NewHandler()
+	get := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/generate?n=3&seed=42&pattern="+url.QueryEscape(`\d{6}`), nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+	if a, b := get(), get(); a != b {
+		t.Errorf("want same results, got %s and %s", a, b)
+	}
+}
+
+func TestHandlerError(t *testing.T) {
+	h := &Handler{MaxN: 10, MaxPatternLength: 10, MaxLength: 10}
+	cases := []struct {
+		method string
+		query  string
+		code   int
+	}{
+		{http.MethodPost, "pattern=a", http.StatusMethodNotAllowed},
+		{http.MethodGet, "pattern=" + url.QueryEscape(`[a-z`), http.StatusBadRequest},
+		{http.MethodGet, "pattern=" + url.QueryEscape(`[a-z]*`), http.StatusBadRequest},
+		{http.MethodGet, "pattern=aaaaaaaaaaa", http.StatusBadRequest},
+		{http.MethodGet, "pattern=a&n=11", http.StatusBadRequest},
+		{http.MethodGet, "pattern=a&n=-1", http.StatusBadRequest},
+		{http.MethodGet, "pattern=a&format=xml", http.StatusBadRequest},
+		{http.MethodGet, "pattern=" + url.QueryEscape(`a{11}`), http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, "/generate?"+c.query, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != c.code {
+			t.Errorf("%s %s: want %d, got %d", c.method, c.query, c.code, rec.Code)
+		}
+	}
+}
+
+func TestHandlerCompileBudget(t *testing.T) {
+	h := NewHandler()
+	pattern := strings.Repeat(`\pL{1000}`, 113)
+	req := httptest.NewRequest(http.MethodGet, "/generate?pattern="+url.QueryEscape(pattern), nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("want %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "budget") {
+		t.Errorf("want the error of the budget, got %q", rec.Body.String())
+	}
+}
+
+func TestHandlerCache(t *testing.T) {
+	h := &Handler{CacheSize: 2}
+	for _, p := range []string{"a", "b", "a", "c"} {
+		if _, err := h.generator(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := h.cache.Stats().Len; n != 2 {
+		t.Errorf("want 2 cached generators, got %d", n)
+	}
+	misses := h.cache.Stats().Misses
+	if _, err := h.generator("b"); err != nil {
+		t.Fatal(err)
+	}
+	if h.cache.Stats().Misses != misses+1 {
+		t.Error("want b to be evicted")
+	}
+}
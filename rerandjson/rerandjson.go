@@ -0,0 +1,201 @@
+// Package rerandjson generates random JSON documents from a spec of patterns.
+package rerandjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"sort"
+	"sync"
+	"time"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// Spec describes a JSON value.
+// Exactly one of Pattern, Properties and Items must be set.
+// It can be decoded from JSON, e.g.
+//
+//	{
+//	  "properties": {
+//	    "id": {"pattern": "[0-9a-f]{8}"},
+//	    "age": {"pattern": "[1-9][0-9]?", "raw": true},
+//	    "tags": {"items": {"pattern": "[a-z]{3,8}"}, "minItems": 0, "maxItems": 3},
+//	    "nickname": {"pattern": "[A-Z][a-z]{2,8}", "probability": 0.5}
+//	  }
+//	}
+type Spec struct {
+	// Pattern generates a string.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Raw embeds the string generated by Pattern as a raw JSON value instead of a string,
+	// e.g. `-?[1-9][0-9]{0,3}` for numbers and `true|false` for booleans.
+	// The pattern must generate valid JSON values.
+	Raw bool `json:"raw,omitempty"`
+
+	// Properties describes the properties of an object.
+	Properties map[string]*Spec `json:"properties,omitempty"`
+
+	// Items describes the elements of an array.
+	Items *Spec `json:"items,omitempty"`
+
+	// MinItems and MaxItems are the range of the number of the elements of an array.
+	MinItems int `json:"minItems,omitempty"`
+	MaxItems int `json:"maxItems,omitempty"`
+
+	// Probability is the probability that the property appears in the object.
+	// If zero, the property always appears.
+	Probability float64 `json:"probability,omitempty"`
+}
+
+// Generator generates random JSON documents.
+type Generator struct {
+	root *node
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+type node struct {
+	gen  func(r *rand.Rand) string
+	raw  bool
+	keys []string // the sorted names of the properties
+	enc  [][]byte // the JSON encoded names of the properties
+	prop []*node
+	item *node
+	min  int
+	max  int
+	prob float64
+}
+
+// New returns new Generator.
+// The patterns are parsed with flags, and compiled with opts.
+// If r is nil, the random number generator seeded with current time is used.
+func New(spec *Spec, flags syntax.Flags, r *rand.Rand, opts ...rerand.Option) (*Generator, error) {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	root, err := compile(spec, flags, opts, "$")
+	if err != nil {
+		return nil, err
+	}
+	return &Generator{
+		root: root,
+		rand: r,
+	}, nil
+}
+
+func compile(spec *Spec, flags syntax.Flags, opts []rerand.Option, path string) (*node, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("rerandjson: %s: nil spec", path)
+	}
+	n := &node{prob: spec.Probability}
+	if spec.Probability < 0 || spec.Probability > 1 {
+		return nil, fmt.Errorf("rerandjson: %s: invalid probability", path)
+	}
+
+	kinds := 0
+	if spec.Pattern != "" {
+		kinds++
+		g, err := rerand.New(spec.Pattern, flags, nil, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("rerandjson: %s: %w", path, err)
+		}
+		n.gen = g.Func()
+		n.raw = spec.Raw
+	}
+	if spec.Properties != nil {
+		kinds++
+		for name := range spec.Properties {
+			n.keys = append(n.keys, name)
+		}
+		sort.Strings(n.keys)
+		for _, name := range n.keys {
+			prop, err := compile(spec.Properties[name], flags, opts, path+"."+name)
+			if err != nil {
+				return nil, err
+			}
+			enc, err := json.Marshal(name)
+			if err != nil {
+				return nil, err
+			}
+			n.enc = append(n.enc, enc)
+			n.prop = append(n.prop, prop)
+		}
+	}
+	if spec.Items != nil {
+		kinds++
+		if spec.MinItems < 0 || spec.MinItems > spec.MaxItems {
+			return nil, fmt.Errorf("rerandjson: %s: invalid range of items", path)
+		}
+		item, err := compile(spec.Items, flags, opts, path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		n.item = item
+		n.min, n.max = spec.MinItems, spec.MaxItems
+	}
+	if kinds != 1 {
+		return nil, fmt.Errorf("rerandjson: %s: exactly one of pattern, properties and items must be set", path)
+	}
+	return n, nil
+}
+
+// Generate generates a random JSON document.
+// It panics if the generation fails, in the same way as rerand.Generator.Generate.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) Generate() []byte {
+	return g.AppendJSON(nil)
+}
+
+// AppendJSON appends a random JSON document to dst, and returns the extended buffer.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) AppendJSON(dst []byte) []byte {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.root.append(dst, g.rand)
+}
+
+func (n *node) append(dst []byte, r *rand.Rand) []byte {
+	switch {
+	case n.gen != nil:
+		s := n.gen(r)
+		if n.raw {
+			return append(dst, s...)
+		}
+		enc, err := json.Marshal(s)
+		if err != nil {
+			// json.Marshal never fails for strings
+			panic(errors.New("rerandjson: " + err.Error()))
+		}
+		return append(dst, enc...)
+	case n.item != nil:
+		dst = append(dst, '[')
+		count := n.min + r.Intn(n.max-n.min+1)
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = n.item.append(dst, r)
+		}
+		return append(dst, ']')
+	default:
+		dst = append(dst, '{')
+		first := true
+		for i, prop := range n.prop {
+			if prop.prob != 0 && r.Float64() >= prop.prob {
+				continue
+			}
+			if !first {
+				dst = append(dst, ',')
+			}
+			first = false
+			dst = append(dst, n.enc[i]...)
+			dst = append(dst, ':')
+			dst = prop.append(dst, r)
+		}
+		return append(dst, '}')
+	}
+}
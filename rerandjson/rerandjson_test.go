@@ -0,0 +1,101 @@
+package rerandjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+const testSpec = `{
+	"properties": {
+		"id": {"pattern": "[0-9a-f]{8}"},
+		"age": {"pattern": "[1-9][0-9]?", "raw": true},
+		"active": {"pattern": "true|false", "raw": true},
+		"tags": {"items": {"pattern": "[a-z]{3,8}"}, "minItems": 1, "maxItems": 3},
+		"nickname": {"pattern": "\"[A-Z]\\\\", "probability": 0.5},
+		"address": {"properties": {"zip": {"pattern": "\\d{3}-\\d{4}"}}}
+	}
+}`
+
+func TestGenerate(t *testing.T) {
+	var spec Spec
+	if err := json.Unmarshal([]byte(testSpec), &spec); err != nil {
+		t.Fatal(err)
+	}
+	g, err := New(&spec, syntax.Perl, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var withNickname int
+	for i := 0; i < 1000; i++ {
+		doc := g.Generate()
+		var v struct {
+			ID       string   `json:"id"`
+			Age      int      `json:"age"`
+			Active   bool     `json:"active"`
+			Tags     []string `json:"tags"`
+			Nickname *string  `json:"nickname"`
+			Address  struct {
+				Zip string `json:"zip"`
+			} `json:"address"`
+		}
+		if err := json.Unmarshal(doc, &v); err != nil {
+			t.Fatalf("%s: %v", doc, err)
+		}
+		if !regexp.MustCompile(`^[0-9a-f]{8}$`).MatchString(v.ID) || v.Age < 1 || v.Age > 99 {
+			t.Errorf("unexpected document: %s", doc)
+		}
+		if len(v.Tags) < 1 || len(v.Tags) > 3 || !regexp.MustCompile(`^\d{3}-\d{4}$`).MatchString(v.Address.Zip) {
+			t.Errorf("unexpected document: %s", doc)
+		}
+		if v.Nickname != nil {
+			withNickname++
+			if !regexp.MustCompile(`^"[A-Z]\\$`).MatchString(*v.Nickname) {
+				t.Errorf("unexpected nickname: %q", *v.Nickname)
+			}
+		}
+	}
+	if withNickname < 400 || withNickname > 600 {
+		t.Errorf("want about 500 documents with nickname, got %d", withNickname)
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	var spec Spec
+	if err := json.Unmarshal([]byte(testSpec), &spec); err != nil {
+		t.Fatal(err)
+	}
+	g1, err := New(&spec, syntax.Perl, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, err := New(&spec, syntax.Perl, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if a, b := g1.Generate(), g2.Generate(); !bytes.Equal(a, b) {
+			t.Errorf("want %s, got %s", a, b)
+		}
+	}
+}
+
+func TestNewError(t *testing.T) {
+	specs := []*Spec{
+		nil,
+		{},
+		{Pattern: "a", Items: &Spec{Pattern: "b"}},
+		{Pattern: "[a-"},
+		{Items: &Spec{Pattern: "a"}, MinItems: 2, MaxItems: 1},
+		{Properties: map[string]*Spec{"a": {Pattern: "a", Probability: 2}}},
+		{Properties: map[string]*Spec{"a": nil}},
+	}
+	for _, spec := range specs {
+		if _, err := New(spec, syntax.Perl, nil); err == nil {
+			t.Errorf("%+v: want error, got nil", spec)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Package rerandpb populates protobuf messages with random strings generated by rerand.
+package rerandpb
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rerand "github.com/shogo82148/go-rerand"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CountSuffix is the suffix of the keys of rules that specify the number of elements of repeated fields.
+const CountSuffix = "#count"
+
+// Fill populates the string and bytes fields of msg with the strings generated by the patterns of rules.
+//
+// The keys of rules are the paths of fields, which are the names of the fields in the proto file joined by dots,
+// e.g. "user.email". Nested messages are populated recursively.
+// The patterns of repeated fields are applied to each element.
+// The number of elements of a repeated field is given by the rule of "path#count", which is "n" or "min,max".
+// Without it, the existing elements are populated, or one element is appended if there is none.
+// Bytes fields are populated with the strings in UTF-8.
+//
+// The patterns are parsed with syntax.Perl.
+// If r is nil, the random number generator seeded with current time is used.
+// It fails if a rule does not match any field.
+func Fill(msg proto.Message, rules map[string]string, r *rand.Rand) error {
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	f := &filler{
+		rules: rules,
+		used:  make(map[string]bool, len(rules)),
+		gens:  make(map[string]func(r *rand.Rand) string),
+		rand:  r,
+	}
+	if err := f.fillMessage(msg.ProtoReflect(), ""); err != nil {
+		return err
+	}
+
+	var unused []string
+	for key := range rules {
+		if !f.used[key] {
+			unused = append(unused, key)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return fmt.Errorf("rerandpb: unknown fields: %s", strings.Join(unused, ", "))
+	}
+	return nil
+}
+
+type filler struct {
+	rules map[string]string
+	used  map[string]bool
+	gens  map[string]func(r *rand.Rand) string
+	rand  *rand.Rand
+}
+
+func (f *filler) fillMessage(m protoreflect.Message, prefix string) error {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		if !f.hasRules(path) || fd.IsMap() {
+			continue
+		}
+		var err error
+		if fd.IsList() {
+			err = f.fillList(m, fd, path)
+		} else {
+			err = f.fillField(m, fd, path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasRules reports whether the field or its nested fields have rules.
+func (f *filler) hasRules(path string) bool {
+	for key := range f.rules {
+		if key == path || key == path+CountSuffix || strings.HasPrefix(key, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *filler) fillField(m protoreflect.Message, fd protoreflect.FieldDescriptor, path string) error {
+	if fd.Message() != nil {
+		return f.fillMessage(m.Mutable(fd).Message(), path+".")
+	}
+	v, ok, err := f.generate(fd, path)
+	if err != nil || !ok {
+		return err
+	}
+	m.Set(fd, v)
+	return nil
+}
+
+func (f *filler) fillList(m protoreflect.Message, fd protoreflect.FieldDescriptor, path string) error {
+	list := m.Mutable(fd).List()
+	n := list.Len()
+	if n == 0 {
+		n = 1
+	}
+	if count, ok := f.rules[path+CountSuffix]; ok {
+		f.used[path+CountSuffix] = true
+		min, max, err := parseCount(count)
+		if err != nil {
+			return fmt.Errorf("rerandpb: %s: %w", path, err)
+		}
+		n = min + f.rand.Intn(max-min+1)
+	}
+	if list.Len() > n {
+		list.Truncate(n)
+	}
+	for list.Len() < n {
+		if fd.Message() != nil {
+			list.AppendMutable()
+		} else {
+			list.Append(list.NewElement())
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if fd.Message() != nil {
+			if err := f.fillMessage(list.Get(i).Message(), path+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		v, ok, err := f.generate(fd, path)
+		if err != nil || !ok {
+			return err
+		}
+		list.Set(i, v)
+	}
+	return nil
+}
+
+// generate generates the value of the string or bytes field.
+func (f *filler) generate(fd protoreflect.FieldDescriptor, path string) (protoreflect.Value, bool, error) {
+	pattern, ok := f.rules[path]
+	if !ok {
+		return protoreflect.Value{}, false, nil
+	}
+	f.used[path] = true
+	kind := fd.Kind()
+	if kind != protoreflect.StringKind && kind != protoreflect.BytesKind {
+		return protoreflect.Value{}, false, fmt.Errorf("rerandpb: %s: %v field is not supported", path, kind)
+	}
+	gen, ok := f.gens[pattern]
+	if !ok {
+		g, err := rerand.New(pattern, syntax.Perl, nil)
+		if err != nil {
+			return protoreflect.Value{}, false, fmt.Errorf("rerandpb: %s: %w", path, err)
+		}
+		gen = g.Func()
+		f.gens[pattern] = gen
+	}
+	s := gen(f.rand)
+	if kind == protoreflect.BytesKind {
+		return protoreflect.ValueOfBytes([]byte(s)), true, nil
+	}
+	return protoreflect.ValueOfString(s), true, nil
+}
+
+// parseCount parses "n" or "min,max".
+func parseCount(s string) (min, max int, err error) {
+	lo, hi, ok := strings.Cut(s, ",")
+	min, err = strconv.Atoi(strings.TrimSpace(lo))
+	if err != nil {
+		return 0, 0, err
+	}
+	max = min
+	if ok {
+		max, err = strconv.Atoi(strings.TrimSpace(hi))
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if min < 0 || min > max {
+		return 0, 0, errors.New("invalid count")
+	}
+	return min, max, nil
+}
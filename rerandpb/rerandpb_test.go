@@ -0,0 +1,84 @@
+package rerandpb
+
+import (
+	"math/rand"
+	"regexp"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/typepb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestFill(t *testing.T) {
+	rules := map[string]string{
+		"name":                     `[A-Z][a-z]{3,8}`,
+		"oneofs":                   `[a-z]{4}`,
+		"oneofs#count":             `2,4`,
+		"fields.name":              `[a-z]{3}_[a-z]{3}`,
+		"fields.type_url":          `type\.googleapis\.com/[a-z]{5}`,
+		"fields#count":             `3`,
+		"source_context.file_name": `[a-z]{4,8}\.proto`,
+	}
+	for i := 0; i < 100; i++ {
+		var msg typepb.Type
+		if err := Fill(&msg, rules, rand.New(rand.NewSource(int64(i)))); err != nil {
+			t.Fatal(err)
+		}
+		if !regexp.MustCompile(`^[A-Z][a-z]{3,8}$`).MatchString(msg.Name) {
+			t.Errorf("unexpected name: %q", msg.Name)
+		}
+		if n := len(msg.Oneofs); n < 2 || n > 4 {
+			t.Errorf("unexpected oneofs: %q", msg.Oneofs)
+		}
+		if len(msg.Fields) != 3 {
+			t.Fatalf("want 3 fields, got %d", len(msg.Fields))
+		}
+		for _, f := range msg.Fields {
+			if !regexp.MustCompile(`^[a-z]{3}_[a-z]{3}$`).MatchString(f.Name) || f.JsonName != "" {
+				t.Errorf("unexpected field: %v", f)
+			}
+		}
+		if !regexp.MustCompile(`^[a-z]{4,8}\.proto$`).MatchString(msg.GetSourceContext().GetFileName()) {
+			t.Errorf("unexpected source context: %v", msg.SourceContext)
+		}
+		if len(msg.Options) != 0 {
+			t.Errorf("want no options, got %v", msg.Options)
+		}
+	}
+}
+
+func TestFillBytes(t *testing.T) {
+	var msg wrapperspb.BytesValue
+	if err := Fill(&msg, map[string]string{"value": `\d{4}`}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^\d{4}$`).Match(msg.Value) {
+		t.Errorf("unexpected value: %q", msg.Value)
+	}
+}
+
+func TestFillExisting(t *testing.T) {
+	msg := &typepb.Type{Oneofs: []string{"a", "b", "c"}}
+	if err := Fill(msg, map[string]string{"oneofs": `x`}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(msg.Oneofs) != 3 || msg.Oneofs[0] != "x" || msg.Oneofs[2] != "x" {
+		t.Errorf("unexpected oneofs: %q", msg.Oneofs)
+	}
+}
+
+func TestFillError(t *testing.T) {
+	rules := []map[string]string{
+		{"unknown": `a`},
+		{"fields.unknown": `a`},
+		{"name": `[a-`},
+		{"syntax": `a`},
+		{"oneofs#count": `3,1`},
+	}
+	for _, r := range rules {
+		var msg typepb.Type
+		if err := Fill(&msg, r, nil); err == nil {
+			t.Errorf("%v: want error, got nil", r)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Package rerandprom reports the activity of generators to Prometheus.
+//
+//	c := rerandprom.NewCollector("myapp")
+//	prometheus.MustRegister(c)
+//	g, err := rerand.New(pattern, syntax.Perl, nil, rerand.WithHooks(c.Hooks()))
+package rerandprom
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// Collector collects the metrics of generators per pattern.
+// It implements prometheus.Collector.
+type Collector struct {
+	generated *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	length    *prometheus.HistogramVec
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector returns a new Collector. The names of the metrics are prefixed with namespace.
+func NewCollector(namespace string) *Collector {
+	labels := []string{"pattern"}
+	return &Collector{
+		generated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "rerand",
+			Name:      "generated_total",
+			Help:      "The number of generated strings.",
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "rerand",
+			Name:      "retries_total",
+			Help:      "The number of strings rejected by the filters.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "rerand",
+			Name:      "errors_total",
+			Help:      "The number of failed generations.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "rerand",
+			Name:      "generate_duration_seconds",
+			Help:      "The time taken to generate a string.",
+			Buckets:   prometheus.ExponentialBuckets(1e-7, 4, 12),
+		}, labels),
+		length: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "rerand",
+			Name:      "output_length_runes",
+			Help:      "The length of generated strings in runes.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, labels),
+	}
+}
+
+// Hooks returns the hooks that record the metrics, for rerand.WithHooks.
+func (c *Collector) Hooks() rerand.Hooks {
+	return rerand.Hooks{
+		OnGenerate: func(pattern string, s string, elapsed time.Duration) {
+			c.generated.WithLabelValues(pattern).Inc()
+			c.duration.WithLabelValues(pattern).Observe(elapsed.Seconds())
+			c.length.WithLabelValues(pattern).Observe(float64(utf8.RuneCountInString(s)))
+		},
+		OnRetry: func(pattern string, attempt int) {
+			c.retries.WithLabelValues(pattern).Inc()
+		},
+		OnError: func(pattern string, err error) {
+			c.errors.WithLabelValues(pattern).Inc()
+		},
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.generated.Describe(ch)
+	c.retries.Describe(ch)
+	c.errors.Describe(ch)
+	c.duration.Describe(ch)
+	c.length.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.generated.Collect(ch)
+	c.retries.Collect(ch)
+	c.errors.Collect(ch)
+	c.duration.Collect(ch)
+	c.length.Collect(ch)
+}
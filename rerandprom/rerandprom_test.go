@@ -0,0 +1,48 @@
+package rerandprom
+
+import (
+	"regexp/syntax"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector("test")
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+
+	g := rerand.Must(rerand.New(`[ab]{4}`, syntax.Perl, nil, rerand.WithHooks(c.Hooks())))
+	for i := 0; i < 10; i++ {
+		g.Generate()
+	}
+	g = rerand.Must(rerand.New(`a{4}`, syntax.Perl, nil, rerand.WithHooks(c.Hooks()), rerand.WithFilter(func(s string) bool {
+		return false
+	}, 3)))
+	if _, err := g.TryGenerate(); err == nil {
+		t.Fatal("want error, got nil")
+	}
+
+	want := `
+# HELP test_rerand_errors_total The number of failed generations.
+# TYPE test_rerand_errors_total counter
+test_rerand_errors_total{pattern="a{4}"} 1
+# HELP test_rerand_generated_total The number of generated strings.
+# TYPE test_rerand_generated_total counter
+test_rerand_generated_total{pattern="[ab]{4}"} 10
+# HELP test_rerand_retries_total The number of strings rejected by the filters.
+# TYPE test_rerand_retries_total counter
+test_rerand_retries_total{pattern="a{4}"} 3
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(want),
+		"test_rerand_errors_total", "test_rerand_generated_total", "test_rerand_retries_total")
+	if err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(c, "test_rerand_output_length_runes"); n != 1 {
+		t.Errorf("want 1 histogram, got %d", n)
+	}
+}
@@ -0,0 +1,201 @@
+// Package rerandsql generates SQL statements for seeding databases with random strings,
+// and adapts generators to driver.Valuer for the fixture builders.
+package rerandsql
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"io"
+	"math/rand"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// DefaultBatchSize is the default number of rows in an INSERT statement.
+const DefaultBatchSize = 100
+
+// Dialect is the dialect of SQL.
+type Dialect int
+
+const (
+	// Standard is standard SQL. It is compatible with SQLite.
+	Standard Dialect = iota
+
+	// PostgreSQL is the dialect of PostgreSQL.
+	PostgreSQL
+
+	// MySQL is the dialect of MySQL.
+	MySQL
+)
+
+// Execer executes queries. *sql.DB, *sql.Tx and *sql.Conn satisfy it.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Seeder generates random rows of a table.
+type Seeder struct {
+	// Table is the name of the table.
+	Table string
+
+	// Dialect is the dialect of SQL.
+	Dialect Dialect
+
+	// BatchSize is the number of rows in an INSERT statement. If zero, DefaultBatchSize is used.
+	BatchSize int
+
+	schema *rerand.Schema
+}
+
+// New returns new Seeder.
+// columns maps the name of each column to its pattern.
+func New(table string, columns map[string]string, r *rand.Rand) (*Seeder, error) {
+	s, err := rerand.NewSchema(columns, syntax.Perl, r)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithSchema(table, s), nil
+}
+
+// NewWithSchema returns new Seeder that generates rows by s.
+func NewWithSchema(table string, s *rerand.Schema) *Seeder {
+	return &Seeder{
+		Table:  table,
+		schema: s,
+	}
+}
+
+// WriteInserts writes INSERT statements of n random rows.
+func (s *Seeder) WriteInserts(w io.Writer, n int) error {
+	bw := bufio.NewWriter(w)
+	var row []string
+	for n > 0 {
+		size := min(n, s.batchSize())
+		n -= size
+		bw.WriteString(s.insertPrefix())
+		for i := 0; i < size; i++ {
+			if i > 0 {
+				bw.WriteString(", ")
+			}
+			bw.WriteByte('(')
+			row = s.schema.AppendRecord(row[:0])
+			for j, v := range row {
+				if j > 0 {
+					bw.WriteString(", ")
+				}
+				bw.WriteString(s.quoteString(v))
+			}
+			bw.WriteByte(')')
+		}
+		bw.WriteString(";\n")
+	}
+	return bw.Flush()
+}
+
+// WriteCopy writes n random rows in the text format of COPY FROM STDIN of PostgreSQL.
+func (s *Seeder) WriteCopy(w io.Writer, n int) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("COPY ")
+	bw.WriteString(s.quoteIdent(s.Table))
+	bw.WriteString(" (")
+	bw.WriteString(s.columnList())
+	bw.WriteString(") FROM STDIN;\n")
+	var row []string
+	for i := 0; i < n; i++ {
+		row = s.schema.AppendRecord(row[:0])
+		for j, v := range row {
+			if j > 0 {
+				bw.WriteByte('\t')
+			}
+			bw.WriteString(copyEscaper.Replace(v))
+		}
+		bw.WriteByte('\n')
+	}
+	bw.WriteString("\\.\n")
+	return bw.Flush()
+}
+
+var copyEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"\t", "\\t",
+	"\n", "\\n",
+	"\r", "\\r",
+)
+
+// Exec inserts n random rows using placeholders.
+func (s *Seeder) Exec(ctx context.Context, db Execer, n int) error {
+	var args []interface{}
+	var row []string
+	for n > 0 {
+		size := min(n, s.batchSize())
+		n -= size
+
+		var query strings.Builder
+		query.WriteString(s.insertPrefix())
+		args = args[:0]
+		for i := 0; i < size; i++ {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteByte('(')
+			row = s.schema.AppendRecord(row[:0])
+			for j, v := range row {
+				if j > 0 {
+					query.WriteString(", ")
+				}
+				args = append(args, v)
+				query.WriteString(s.placeholder(len(args)))
+			}
+			query.WriteByte(')')
+		}
+		if _, err := db.ExecContext(ctx, query.String(), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Seeder) batchSize() int {
+	if s.BatchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return s.BatchSize
+}
+
+func (s *Seeder) insertPrefix() string {
+	return "INSERT INTO " + s.quoteIdent(s.Table) + " (" + s.columnList() + ") VALUES "
+}
+
+func (s *Seeder) columnList() string {
+	fields := s.schema.Fields()
+	for i, f := range fields {
+		fields[i] = s.quoteIdent(f)
+	}
+	return strings.Join(fields, ", ")
+}
+
+func (s *Seeder) quoteIdent(name string) string {
+	if s.Dialect == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (s *Seeder) quoteString(v string) string {
+	v = strings.ReplaceAll(v, "'", "''")
+	if s.Dialect == MySQL {
+		v = strings.ReplaceAll(v, `\`, `\\`)
+	}
+	return "'" + v + "'"
+}
+
+func (s *Seeder) placeholder(i int) string {
+	if s.Dialect == PostgreSQL {
+		return "$" + strconv.Itoa(i)
+	}
+	return "?"
+}
@@ -0,0 +1,103 @@
+package rerandsql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func newSeeder(t *testing.T) *Seeder {
+	t.Helper()
+	s, err := New("users", map[string]string{
+		"id":   `\d{3}`,
+		"name": `o'[a-z]{2}`,
+	}, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestWriteInserts(t *testing.T) {
+	s := newSeeder(t)
+	s.BatchSize = 2
+
+	var buf bytes.Buffer
+	if err := s.WriteInserts(&buf, 3); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 statements, got %d: %q", len(lines), buf.String())
+	}
+	prefix := `INSERT INTO "users" ("id", "name") VALUES `
+	for _, line := range lines {
+		if !strings.HasPrefix(line, prefix) {
+			t.Errorf("unexpected statement: %s", line)
+		}
+		if !strings.Contains(line, `'o''`) {
+			t.Errorf("quote is not escaped: %s", line)
+		}
+	}
+	if got := strings.Count(lines[0], "("); got != 3 {
+		t.Errorf("want 2 rows in the first statement, got %s", lines[0])
+	}
+}
+
+func TestWriteCopy(t *testing.T) {
+	s := newSeeder(t)
+
+	var buf bytes.Buffer
+	if err := s.WriteCopy(&buf, 3); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("want 5 lines, got %d: %q", len(lines), buf.String())
+	}
+	if want := `COPY "users" ("id", "name") FROM STDIN;`; lines[0] != want {
+		t.Errorf("want %s, got %s", want, lines[0])
+	}
+	for _, line := range lines[1:4] {
+		if len(strings.Split(line, "\t")) != 2 {
+			t.Errorf("want 2 columns, got %q", line)
+		}
+	}
+	if lines[4] != `\.` {
+		t.Errorf("want end of data, got %s", lines[4])
+	}
+}
+
+type execer struct {
+	queries []string
+	args    [][]interface{}
+}
+
+func (e *execer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+	return nil, nil
+}
+
+func TestExec(t *testing.T) {
+	s := newSeeder(t)
+	s.Dialect = PostgreSQL
+	s.BatchSize = 2
+
+	e := &execer{}
+	if err := s.Exec(context.Background(), e, 3); err != nil {
+		t.Fatal(err)
+	}
+	if len(e.queries) != 2 {
+		t.Fatalf("want 2 queries, got %d", len(e.queries))
+	}
+	if want := `INSERT INTO "users" ("id", "name") VALUES ($1, $2), ($3, $4)`; e.queries[0] != want {
+		t.Errorf("want %s, got %s", want, e.queries[0])
+	}
+	if len(e.args[0]) != 4 || len(e.args[1]) != 2 {
+		t.Errorf("unexpected args: %v", e.args)
+	}
+}
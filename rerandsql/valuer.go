@@ -0,0 +1,71 @@
+package rerandsql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"time"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// Valuer returns driver.Valuer that generates a random string by g for each call of Value,
+// e.g. for the row builders of sqlmock and testfixtures.
+func Valuer(g *rerand.Generator) driver.Valuer {
+	return valuerFunc(func() (driver.Value, error) {
+		return g.TryGenerate()
+	})
+}
+
+// Int64Valuer returns driver.Valuer that generates a random string by g, and parses it as a decimal int64,
+// e.g. for the pattern `[1-9][0-9]{0,8}`.
+// Value fails if the string is not a valid int64.
+func Int64Valuer(g *rerand.Generator) driver.Valuer {
+	return valuerFunc(func() (driver.Value, error) {
+		s, err := g.TryGenerate()
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("rerandsql: %q is not an int64: %w", s, err)
+		}
+		return n, nil
+	})
+}
+
+// TimeValuer returns driver.Valuer that generates a random string by g, and parses it as time.Time in layout,
+// e.g. for the pattern `20[0-9]{2}-(0[1-9]|1[0-2])-(0[1-9]|1[0-9]|2[0-8])` and the layout time.DateOnly.
+// Value fails if the string is not a valid time, e.g. February 30.
+func TimeValuer(g *rerand.Generator, layout string) driver.Valuer {
+	return valuerFunc(func() (driver.Value, error) {
+		s, err := g.TryGenerate()
+		if err != nil {
+			return nil, err
+		}
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return nil, fmt.Errorf("rerandsql: %q is not a time: %w", s, err)
+		}
+		return t, nil
+	})
+}
+
+// Provider returns the function that returns the value of v, for the builders that expect func() any.
+// The function panics if v fails, in the same way as rerand.Generator.Generate.
+func Provider(v driver.Valuer) func() any {
+	return func() any {
+		value, err := v.Value()
+		if err != nil {
+			panic(err)
+		}
+		return value
+	}
+}
+
+// valuerFunc is driver.Valuer of a function.
+type valuerFunc func() (driver.Value, error)
+
+func (f valuerFunc) Value() (driver.Value, error) {
+	return f()
+}
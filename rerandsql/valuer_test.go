@@ -0,0 +1,76 @@
+package rerandsql
+
+import (
+	"database/sql/driver"
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+	"time"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestValuer(t *testing.T) {
+	g := rerand.Must(rerand.New(`[a-z]{8}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	v, err := Valuer(g).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := v.(string); !ok || len(s) != 8 {
+		t.Errorf("unexpected %#v", v)
+	}
+	if !driver.IsValue(v) {
+		t.Errorf("%#v is not a driver.Value", v)
+	}
+}
+
+func TestInt64Valuer(t *testing.T) {
+	g := rerand.Must(rerand.New(`[1-9][0-9]{0,8}`, syntax.Perl, nil))
+	for i := 0; i < 100; i++ {
+		v, err := Int64Valuer(g).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n, ok := v.(int64); !ok || n <= 0 || n >= 1e9 {
+			t.Errorf("unexpected %#v", v)
+		}
+	}
+
+	g = rerand.Must(rerand.New(`x[0-9]`, syntax.Perl, nil))
+	if _, err := Int64Valuer(g).Value(); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestTimeValuer(t *testing.T) {
+	g := rerand.Must(rerand.New(`20[0-9]{2}-(0[1-9]|1[0-2])-(0[1-9]|1[0-9]|2[0-8])`, syntax.Perl, nil))
+	for i := 0; i < 100; i++ {
+		v, err := TimeValuer(g, time.DateOnly).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tm, ok := v.(time.Time); !ok || tm.Year() < 2000 || tm.Year() > 2099 {
+			t.Errorf("unexpected %#v", v)
+		}
+	}
+
+	g = rerand.Must(rerand.New(`2024-02-30`, syntax.Perl, nil))
+	if _, err := TimeValuer(g, time.DateOnly).Value(); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestProvider(t *testing.T) {
+	g := rerand.Must(rerand.New(`[0-9]{3}`, syntax.Perl, nil))
+	f := Provider(Int64Valuer(g))
+	if _, ok := f().(int64); !ok {
+		t.Error("want int64")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic, got nil")
+		}
+	}()
+	Provider(Int64Valuer(rerand.Must(rerand.New(`x`, syntax.Perl, nil))))()
+}
@@ -0,0 +1,129 @@
+// Package rerandstat provides statistical helpers for testing the distribution of generators.
+package rerandstat
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+// MaxOutcomes is the maximum number of the strings that ChiSquareUniform enumerates.
+const MaxOutcomes = 1 << 16
+
+// ErrInfinite is returned if the generator generates infinitely many strings.
+var ErrInfinite = errors.New("rerandstat: the language is infinite")
+
+// ErrTooManyOutcomes is returned if the generator generates more than MaxOutcomes strings.
+var ErrTooManyOutcomes = errors.New("rerandstat: too many outcomes")
+
+// Histogram generates n strings by g, and returns the number of times each string is generated.
+// It panics if the generation fails, in the same way as Generate.
+func Histogram(g *rerand.Generator, n int) map[string]int {
+	hist := make(map[string]int)
+	for i := 0; i < n; i++ {
+		hist[g.Generate()]++
+	}
+	return hist
+}
+
+// ChiSquareResult is the result of Pearson's chi-square test.
+type ChiSquareResult struct {
+	// Statistic is the chi-square statistic.
+	Statistic float64
+
+	// DegreesOfFreedom is the number of outcomes minus one.
+	DegreesOfFreedom int
+
+	// PValue is the probability that the statistic is at least as large as Statistic, if the distribution is uniform.
+	PValue float64
+}
+
+// ChiSquareUniform generates n strings by g, and tests whether every string of the finite language is equally likely.
+// The distribution is uniform with NewDistinctRunes, unless the pattern is ambiguous.
+// n should be at least 5 times the number of the strings.
+// In CI, compare PValue with a small significance level, e.g. 0.001, with a fixed seed to avoid flaky tests.
+func ChiSquareUniform(g *rerand.Generator, n int) (ChiSquareResult, error) {
+	if !g.IsFinite() {
+		return ChiSquareResult{}, ErrInfinite
+	}
+	outcomes := make(map[string]int)
+	for s := range g.Enumerate() {
+		outcomes[s] = 0
+		if len(outcomes) > MaxOutcomes {
+			return ChiSquareResult{}, ErrTooManyOutcomes
+		}
+	}
+	for s, count := range Histogram(g, n) {
+		if _, ok := outcomes[s]; !ok {
+			return ChiSquareResult{}, fmt.Errorf("rerandstat: %q is not enumerated", s)
+		}
+		outcomes[s] = count
+	}
+
+	k := len(outcomes)
+	expected := float64(n) / float64(k)
+	var stat float64
+	for _, count := range outcomes {
+		d := float64(count) - expected
+		stat += d * d / expected
+	}
+	df := k - 1
+	p := 1.0
+	if df > 0 {
+		p = gammaQ(float64(df)/2, stat/2)
+	}
+	return ChiSquareResult{
+		Statistic:        stat,
+		DegreesOfFreedom: df,
+		PValue:           p,
+	}, nil
+}
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a, x).
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgamma)
+	if x < a+1 {
+		// the series of P(a, x)
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 1000; n++ {
+			term *= x / (a + n)
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*prefix
+	}
+
+	// the continued fraction of Q(a, x) by Lentz's method
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return h * prefix
+}
@@ -0,0 +1,76 @@
+package rerandstat
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestHistogram(t *testing.T) {
+	g := rerand.Must(rerand.New(`[ab]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	hist := Histogram(g, 1000)
+	if len(hist) != 2 || hist["a"]+hist["b"] != 1000 {
+		t.Errorf("unexpected histogram: %v", hist)
+	}
+}
+
+func TestChiSquareUniform(t *testing.T) {
+	g := rerand.Must(rerand.NewDistinctRunes(`[a-c]{2}|x`, syntax.Perl, rand.New(rand.NewSource(1))))
+	res, err := ChiSquareUniform(g, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.DegreesOfFreedom != 9 {
+		t.Errorf("want 9 degrees of freedom, got %d", res.DegreesOfFreedom)
+	}
+	if res.PValue < 0.001 {
+		t.Errorf("want uniform, got %+v", res)
+	}
+
+	// `aa` is generated with the probability 1/2
+	g = rerand.Must(rerand.New(`aa|[b-k]{2}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	res, err = ChiSquareUniform(g, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PValue > 0.001 {
+		t.Errorf("want not uniform, got %+v", res)
+	}
+}
+
+func TestChiSquareUniformError(t *testing.T) {
+	g := rerand.Must(rerand.New(`a*`, syntax.Perl, nil, rerand.WithTargetLength(3)))
+	if _, err := ChiSquareUniform(g, 100); !errors.Is(err, ErrInfinite) {
+		t.Errorf("want ErrInfinite, got %v", err)
+	}
+	g = rerand.Must(rerand.New(`[a-z]{4}`, syntax.Perl, nil))
+	if _, err := ChiSquareUniform(g, 100); !errors.Is(err, ErrTooManyOutcomes) {
+		t.Errorf("want ErrTooManyOutcomes, got %v", err)
+	}
+}
+
+func TestGammaQ(t *testing.T) {
+	tests := []struct {
+		df   int
+		stat float64
+		want float64
+	}{
+		// the critical values of chi-square distribution
+		{1, 3.841, 0.05},
+		{2, 5.991, 0.05},
+		{10, 18.307, 0.05},
+		{10, 23.209, 0.01},
+		{100, 124.342, 0.05},
+		{1, 0, 1},
+	}
+	for _, tt := range tests {
+		got := gammaQ(float64(tt.df)/2, tt.stat/2)
+		if math.Abs(got-tt.want) > 1e-4 {
+			t.Errorf("df=%d, stat=%g: want %g, got %g", tt.df, tt.stat, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package rerandtime generates random timestamps formatted in Go layouts,
+// which are always valid unlike the ones generated from patterns such as `\d{2}:\d{2}`.
+package rerandtime
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Generator generates random timestamps in a range.
+// It implements rerand.GroupSource, so that it generates a named group of a pattern with rerand.WithGroupSource.
+type Generator struct {
+	layout string
+	from   time.Time
+	to     time.Time
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// New returns new Generator that generates the timestamps between from and to inclusive, formatted in layout,
+// e.g. New(time.DateOnly, from, to).
+// The timestamps are uniformly distributed in the range, and in the location of from.
+// The random number generator is seeded with current time, and Reseed seeds it explicitly.
+func New(layout string, from, to time.Time) (*Generator, error) {
+	if layout == "" {
+		return nil, errors.New("rerandtime: empty layout")
+	}
+	if to.Before(from) {
+		return nil, errors.New("rerandtime: to is before from")
+	}
+	return &Generator{
+		layout: layout,
+		from:   from,
+		to:     to,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// Reseed seeds the random number generator, so that the timestamps are reproducible.
+func (g *Generator) Reseed(seed int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rand.Seed(seed)
+}
+
+// Generate returns a random timestamp formatted in the layout.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) Generate() string {
+	return g.GenerateTime().Format(g.layout)
+}
+
+// GenerateTime returns a random time in the range.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateTime() time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.generateTime(g.rand)
+}
+
+// GenerateGroup returns a random timestamp formatted in the layout using r.
+// It implements rerand.GroupSource.
+func (g *Generator) GenerateGroup(r *rand.Rand) string {
+	return g.generateTime(r).Format(g.layout)
+}
+
+func (g *Generator) generateTime(r *rand.Rand) time.Time {
+	if d := g.to.Sub(g.from); d < time.Duration(1<<63-1) {
+		return g.from.Add(time.Duration(r.Int63n(int64(d) + 1)))
+	}
+
+	// the range is too wide for time.Duration, about 292 years.
+	// choose the second, and then the nanosecond.
+	secs := g.to.Unix() - g.from.Unix()
+	t := time.Unix(g.from.Unix()+r.Int63n(secs+1), r.Int63n(int64(time.Second))).In(g.from.Location())
+	if t.Before(g.from) {
+		return g.from
+	}
+	if t.After(g.to) {
+		return g.to
+	}
+	return t
+}
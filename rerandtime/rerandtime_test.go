@@ -0,0 +1,103 @@
+package rerandtime
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+	"time"
+
+	rerand "github.com/shogo82148/go-rerand"
+)
+
+func TestGenerate(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC)
+	g, err := New(time.DateOnly, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		s := g.Generate()
+		tm, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tm.Before(from) || tm.After(to) {
+			t.Errorf("%s is out of range", s)
+		}
+		seen[s] = true
+	}
+	// 29 days of February in 2024
+	if len(seen) != 29 {
+		t.Errorf("want 29 dates, got %d", len(seen))
+	}
+}
+
+func TestGenerateClock(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g, err := New("15:04", from, from.Add(24*time.Hour-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	re := regexp.MustCompile(`^(?:[01][0-9]|2[0-3]):[0-5][0-9]$`)
+	for i := 0; i < 1000; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("invalid time %q", s)
+		}
+	}
+}
+
+func TestGenerateWideRange(t *testing.T) {
+	from := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+	g, err := New(time.RFC3339Nano, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		tm := g.GenerateTime()
+		if tm.Before(from) || tm.After(to) {
+			t.Errorf("%s is out of range", tm)
+		}
+	}
+}
+
+func TestReseed(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g1, _ := New(time.RFC3339, from, from.AddDate(1, 0, 0))
+	g2, _ := New(time.RFC3339, from, from.AddDate(1, 0, 0))
+	g1.Reseed(1)
+	g2.Reseed(1)
+	for i := 0; i < 10; i++ {
+		if s1, s2 := g1.Generate(), g2.Generate(); s1 != s2 {
+			t.Errorf("want same timestamps, got %s and %s", s1, s2)
+		}
+	}
+}
+
+func TestNewError(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := New("", from, from); err == nil {
+		t.Error("want error, got nil")
+	}
+	if _, err := New(time.DateOnly, from, from.Add(-time.Second)); err == nil {
+		t.Error("want error, got nil")
+	}
+}
+
+func TestGroupSource(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts, err := New("15:04:05", from, from.Add(24*time.Hour-time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := rerand.Must(rerand.New(`(?P<ts>\d{2}:\d{2}:\d{2}) (INFO|WARN) [a-z]{4}`, syntax.Perl, rand.New(rand.NewSource(1)), rerand.WithGroupSource("ts", ts)))
+	re := regexp.MustCompile(`^(?:[01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9] (INFO|WARN) [a-z]{4}$`)
+	for i := 0; i < 1000; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("invalid log line %q", s)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package rerand
+
+import "unicode/utf8"
+
+// Reservoir returns k strings chosen uniformly without replacement from the enumeration of g, in random order,
+// keeping only k strings in memory, so it works for the languages too large to materialize.
+// If the language is infinite, the enumeration is bounded by the length of WithMaxLength.
+// If the enumeration has fewer than k strings, all of them are returned.
+// It panics with ErrInfinite if the language is infinite and WithMaxLength is not given.
+// The strings are streamed from Enumerate, so the strings generated in more than one way by an ambiguous pattern
+// may be chosen more than once, and it takes time proportional to the number of the strings.
+// It is safe for concurrent use by multiple goroutines.
+func Reservoir(g *Generator, k int) []string {
+	if k < 0 {
+		panic("rerand: negative sample size")
+	}
+	limit := g.opts.maxLength
+	if !g.IsFinite() && limit == 0 {
+		panic(ErrInfinite)
+	}
+
+	result := make([]string, 0, k)
+	if k == 0 {
+		return result
+	}
+	var n int64 // the number of the strings seen
+	for s := range g.Enumerate() {
+		if limit > 0 && utf8.RuneCountInString(s) > limit {
+			// the strings are enumerated in the order of length.
+			break
+		}
+		n++
+		if len(result) < k {
+			result = append(result, s)
+			continue
+		}
+		g.mu.Lock()
+		j := g.rand.Int63n(n)
+		g.mu.Unlock()
+		if j < int64(k) {
+			result[j] = s
+		}
+	}
+
+	g.mu.Lock()
+	g.rand.Shuffle(len(result), func(a, b int) {
+		result[a], result[b] = result[b], result[a]
+	})
+	g.mu.Unlock()
+	return result
+}
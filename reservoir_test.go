@@ -0,0 +1,64 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"slices"
+	"testing"
+)
+
+func TestReservoir(t *testing.T) {
+	g := Must(New(`[a-c][0-9]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		got := Reservoir(g, 3)
+		if len(got) != 3 {
+			t.Fatalf("want 3 strings, got %q", got)
+		}
+		sorted := slices.Clone(got)
+		slices.Sort(sorted)
+		if len(slices.Compact(sorted)) != 3 {
+			t.Fatalf("want distinct strings, got %q", got)
+		}
+		for _, s := range got {
+			counts[s]++
+		}
+	}
+	if len(counts) != 30 {
+		t.Fatalf("want 30 strings, got %d", len(counts))
+	}
+	// each string is chosen 300 times on average.
+	for s, n := range counts {
+		if n < 200 || n > 400 {
+			t.Errorf("%s is chosen %d times", s, n)
+		}
+	}
+}
+
+func TestReservoirFewStrings(t *testing.T) {
+	g := Must(New(`ab|cd`, syntax.Perl, nil))
+	got := Reservoir(g, 5)
+	slices.Sort(got)
+	if want := []string{"ab", "cd"}; !slices.Equal(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if got := Reservoir(g, 0); len(got) != 0 {
+		t.Errorf("want empty, got %q", got)
+	}
+}
+
+func TestReservoirInfinite(t *testing.T) {
+	g := Must(New(`(ab)*`, syntax.Perl, nil, WithTargetLength(2), WithMaxLength(6, MaxLengthTruncate)))
+	got := Reservoir(g, 10)
+	slices.Sort(got)
+	if want := []string{"", "ab", "abab", "ababab"}; !slices.Equal(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	defer func() {
+		if err := recover(); err != ErrInfinite {
+			t.Errorf("want ErrInfinite, got %v", err)
+		}
+	}()
+	Reservoir(Must(New(`a*`, syntax.Perl, nil, WithTargetLength(2))), 1)
+}
@@ -0,0 +1,33 @@
+package rerand
+
+import "unicode"
+
+// WithRuneCeiling sets the maximum rune of `.` and negated character classes, e.g. 0xFFFF for the systems that accept only BMP.
+// The character classes are limited to the ceiling in any stream version, unless all of their runes are beyond it.
+// It takes precedence over WithPrivateUseArea.
+// The ceiling must be greater than '\n' and at most unicode.MaxRune. Zero means the default, U+EFFFF.
+func WithRuneCeiling(r rune) Option {
+	return func(o *options) {
+		o.runeCeiling = r
+	}
+}
+
+// WithPrivateUseArea includes the supplementary private use areas U+F0000 to U+10FFFF in `.` and negated character classes,
+// which are excluded by default.
+// The private use area of BMP, U+E000 to U+F8FF, is always included below the ceiling.
+func WithPrivateUseArea(include bool) Option {
+	return func(o *options) {
+		o.privateUseArea = include
+	}
+}
+
+// maxRune returns the maximum rune of `.`.
+func (o *options) maxRune() rune {
+	if o.runeCeiling != 0 {
+		return o.runeCeiling
+	}
+	if o.privateUseArea {
+		return unicode.MaxRune
+	}
+	return maxRune
+}
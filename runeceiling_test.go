@@ -0,0 +1,76 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"testing"
+	"unicode"
+)
+
+func TestWithRuneCeiling(t *testing.T) {
+	patterns := []string{`.`, `(?s:.)`, `[^a]`, `\PL`}
+	for _, pattern := range patterns {
+		g := Must(New(pattern+`{100}`, syntax.Perl, nil, WithRuneCeiling(0xFFFF)))
+		for i := 0; i < 10; i++ {
+			for _, r := range g.Generate() {
+				if r > 0xFFFF {
+					t.Fatalf("%s: unexpected %U", pattern, r)
+				}
+			}
+		}
+	}
+
+	// StreamV1 limits the classes if the ceiling is given.
+	g := Must(New(`[^a]{100}`, syntax.Perl, nil, WithRuneCeiling(0x7F), WithStreamVersion(StreamV1)))
+	for _, r := range g.Generate() {
+		if r > 0x7F {
+			t.Fatalf("unexpected %U", r)
+		}
+	}
+
+	// the runes beyond the ceiling are kept.
+	g = Must(New(`\x{1F600}`, syntax.Perl, nil, WithRuneCeiling(0xFFFF)))
+	if s := g.Generate(); s != "\U0001F600" {
+		t.Errorf("unexpected %q", s)
+	}
+
+	g = Must(New(`.`, syntax.Perl, nil, WithRuneCeiling(0x7F), WithDistinctRunes()))
+	if n := g.Counts()[g.prog.Start]; n.Int64() != 0x7F {
+		t.Errorf("want %d strings, got %s", 0x7F, n)
+	}
+}
+
+func TestWithPrivateUseArea(t *testing.T) {
+	g := Must(New(`[\x{E0000}-\x{10FFFF}]{100}`, syntax.Perl, nil, WithPrivateUseArea(true)))
+	var beyond bool
+	for _, r := range g.Generate() {
+		if r > maxRune {
+			beyond = true
+		}
+	}
+	if !beyond {
+		t.Error("want runes in the supplementary private use areas, got none")
+	}
+
+	g = Must(New(`[\x{E0000}-\x{10FFFF}]{100}`, syntax.Perl, nil, WithPrivateUseArea(false)))
+	for _, r := range g.Generate() {
+		if r > maxRune {
+			t.Fatalf("unexpected %U", r)
+		}
+	}
+
+	// WithRuneCeiling takes precedence.
+	g = Must(New(`.{100}`, syntax.Perl, nil, WithPrivateUseArea(true), WithRuneCeiling(0xFFFF)))
+	for _, r := range g.Generate() {
+		if r > 0xFFFF {
+			t.Fatalf("unexpected %U", r)
+		}
+	}
+}
+
+func TestWithRuneCeilingError(t *testing.T) {
+	for _, ceiling := range []rune{-1, '\n', unicode.MaxRune + 1} {
+		if _, err := New(`.`, syntax.Perl, nil, WithRuneCeiling(ceiling)); err == nil {
+			t.Errorf("%U: want error, got nil", ceiling)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package rerand
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"slices"
+	"sync"
+)
+
+// RuneSource is a source of random runes.
+// RuneGenerator is an implementation of RuneSource.
+type RuneSource interface {
+	// Rune returns a random rune using r.
+	Rune(r *rand.Rand) rune
+}
+
+// Rune returns a random rune using r.
+// The caller must not share r among goroutines.
+func (g *RuneGenerator) Rune(r *rand.Rand) rune {
+	return g.generate(r, nopLocker{})
+}
+
+// generateRune generates a random rune by the instruction.
+// mu guards r.
+func (in *myinst) generateRune(r *rand.Rand, mu sync.Locker) rune {
+	if in.runeSource != nil {
+		mu.Lock()
+		defer mu.Unlock()
+		return in.runeSource.Rune(r)
+	}
+	return in.runeGenerator.generate(r, mu)
+}
+
+type runeSourceOption struct {
+	class string
+	group string
+	src   RuneSource
+}
+
+// setRuneSources applies the options of WithRuneSource and WithGroupRuneSource.
+// ceiling is the maximum rune of `.`.
+func setRuneSources(inst []myinst, prog *syntax.Prog, re *syntax.Regexp, flags syntax.Flags, opts []runeSourceOption, ceiling rune) error {
+	if len(opts) == 0 {
+		return nil
+	}
+	capNames := re.CapNames()
+	for _, opt := range opts {
+		if opt.group != "" {
+			cap := slices.Index(capNames, opt.group)
+			if cap < 0 {
+				return fmt.Errorf("rerand: unknown group %q", opt.group)
+			}
+			for _, pc := range groupInsts(prog, cap) {
+				if inst[pc].Op == syntax.InstRune {
+					inst[pc].runeSource = opt.src
+				}
+			}
+			continue
+		}
+
+		runes, err := classRunes(opt.class, flags, ceiling)
+		if err != nil {
+			return err
+		}
+		for i := range inst {
+			in := &inst[i]
+			if in.Op == syntax.InstRune && (slices.Equal(in.runeGenerator.runes, runes) || slices.Equal(in.runeGenerator.runes, limitRunes(runes, ceiling))) {
+				in.runeSource = opt.src
+			}
+		}
+	}
+	return nil
+}
+
+// classRunes returns the runes of the character class in the same form as RuneGenerator.
+func classRunes(class string, flags syntax.Flags, ceiling rune) ([]rune, error) {
+	re, err := syntax.Parse(class, flags)
+	if err != nil {
+		return nil, err
+	}
+	switch re.Op {
+	case syntax.OpCharClass:
+		return re.Rune, nil
+	case syntax.OpAnyChar:
+		return []rune{0, ceiling}, nil
+	case syntax.OpAnyCharNotNL:
+		return []rune{0, '\n' - 1, '\n' + 1, ceiling}, nil
+	case syntax.OpLiteral:
+		if len(re.Rune) == 1 {
+			return re.Rune, nil
+		}
+	}
+	return nil, fmt.Errorf("rerand: %q is not a character class", class)
+}
+
+// groupInsts returns the instructions in the capture group.
+func groupInsts(prog *syntax.Prog, cap int) []uint32 {
+	begin, end := uint32(2*cap), uint32(2*cap+1)
+	visited := make([]bool, len(prog.Inst))
+	var ret []uint32
+	var visit func(pc uint32)
+	visit = func(pc uint32) {
+		if visited[pc] {
+			return
+		}
+		visited[pc] = true
+		in := &prog.Inst[pc]
+		if in.Op == syntax.InstCapture && in.Arg == end {
+			return
+		}
+		ret = append(ret, pc)
+		switch in.Op {
+		case syntax.InstMatch, syntax.InstFail:
+		case syntax.InstAlt:
+			visit(in.Out)
+			visit(in.Arg)
+		default:
+			visit(in.Out)
+		}
+	}
+	for _, in := range prog.Inst {
+		if in.Op == syntax.InstCapture && in.Arg == begin {
+			visit(in.Out)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,51 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+// constRune always generates the same rune.
+type constRune rune
+
+func (c constRune) Rune(r *rand.Rand) rune {
+	return rune(c)
+}
+
+func TestWithRuneSource(t *testing.T) {
+	g := Must(New(`[a-z]\d{3}[0-9]`, syntax.Perl, nil, WithRuneSource(`\d`, constRune('7'))))
+	for i := 0; i < 100; i++ {
+		s := g.Generate()
+		if !strings.HasSuffix(s, "7777") {
+			t.Errorf("want suffix 7777, got %q", s)
+		}
+	}
+
+	// RuneGenerator is a RuneSource.
+	g = Must(New(`[a-z]{8}`, syntax.Perl, nil, WithRuneSource(`[a-z]`, NewRuneGenerator([]rune{'x', 'y'}, nil))))
+	for i := 0; i < 100; i++ {
+		s := g.Generate()
+		if strings.Trim(s, "xy") != "" {
+			t.Errorf("want only x and y, got %q", s)
+		}
+	}
+}
+
+func TestWithGroupRuneSource(t *testing.T) {
+	g := Must(New(`\d{2}-(?P<code>\d{2}[a-z])-\d{2}`, syntax.Perl, nil, WithGroupRuneSource("code", constRune('0'))))
+	for i := 0; i < 100; i++ {
+		s := g.Generate()
+		if s[3:6] != "000" {
+			t.Errorf("want 000 in the group, got %q", s)
+		}
+	}
+
+	if _, err := New(`(?P<code>\d)`, syntax.Perl, nil, WithGroupRuneSource("unknown", constRune('0'))); err == nil {
+		t.Error("want error, got nil")
+	}
+	if _, err := New(`\d`, syntax.Perl, nil, WithRuneSource(`abc`, constRune('0'))); err == nil {
+		t.Error("want error, got nil")
+	}
+}
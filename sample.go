@@ -0,0 +1,390 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp/syntax"
+	"slices"
+)
+
+// ErrInfinite is returned if the operation requires a finite language, but the generator generates infinitely many strings.
+var ErrInfinite = errors.New("rerand: the generator generates infinitely many strings")
+
+// ErrTooFewStrings is returned by Sample if the generator generates fewer distinct strings than requested.
+var ErrTooFewStrings = errors.New("rerand: the generator generates fewer strings than requested")
+
+// maxDistinctStates is the maximum number of the states of the automaton that counts the distinct strings.
+const maxDistinctStates = 1 << 14
+
+// Sample returns k distinct strings chosen uniformly without replacement from the strings that g generates,
+// in random order.
+// The strings are ranked, and the ranks are sampled by Floyd's algorithm,
+// so it never collides even if k is close to the number of the strings.
+// It returns ErrInfinite if the language is infinite, and ErrTooFewStrings if it has fewer than k strings.
+// If the pattern is ambiguous, e.g. `(?:ab|a)b?`, the distinct strings are ranked by the deterministic automaton of the pattern,
+// and it fails if the automaton has too many states.
+// Like Enumerate, it does not take WithDependency, WithGraphemeClusters and the options of post-processing into account.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) Sample(k int) ([]string, error) {
+	if k < 0 {
+		return nil, errors.New("rerand: negative sample size")
+	}
+	if !g.IsFinite() {
+		return nil, ErrInfinite
+	}
+	counts := g.pathCounts(true)
+	total := counts[g.prog.Start]
+	d, err := g.newDistinctStrings()
+	if err != nil {
+		return nil, err
+	}
+	unrank := func(i *big.Int) string {
+		return g.unrank(counts, i)
+	}
+	if d.total().Cmp(total) != 0 {
+		// the pattern is ambiguous, and generates some strings in more than one way.
+		total = d.total()
+		unrank = d.unrank
+	}
+	n := big.NewInt(int64(k))
+	if total.Cmp(n) < 0 {
+		return nil, ErrTooFewStrings
+	}
+
+	// Floyd's algorithm: for j in [total-k, total), choose t in [0, j],
+	// and take j if t is already taken.
+	taken := make(map[string]bool, k)
+	indexes := make([]*big.Int, 0, k)
+	take := func(i *big.Int) {
+		taken[i.String()] = true
+		indexes = append(indexes, i)
+	}
+	one := big.NewInt(1)
+	j := new(big.Int).Sub(total, n)
+	g.mu.Lock()
+	for ; j.Cmp(total) < 0; j.Add(j, one) {
+		t := new(big.Int).Rand(g.rand, new(big.Int).Add(j, one))
+		if taken[t.String()] {
+			take(new(big.Int).Set(j))
+		} else {
+			take(t)
+		}
+	}
+	g.rand.Shuffle(len(indexes), func(a, b int) {
+		indexes[a], indexes[b] = indexes[b], indexes[a]
+	})
+	g.mu.Unlock()
+
+	result := make([]string, 0, k)
+	for _, i := range indexes {
+		result = append(result, unrank(i))
+	}
+	return result, nil
+}
+
+// pathCounts returns the number of the ways to generate strings from each instruction.
+// If distinctRunes is true, the runes of InstRune are counted as distinct ways.
+// The count is nil if the instruction generates infinitely many strings.
+func (g *Generator) pathCounts(distinctRunes bool) []*big.Int {
+	counts := make([]*big.Int, len(g.inst))
+	visiting := make([]bool, len(g.inst))
+	done := make([]bool, len(g.inst))
+	var count func(pc uint32) *big.Int
+	count = func(pc uint32) *big.Int {
+		if done[pc] || visiting[pc] {
+			return counts[pc]
+		}
+		visiting[pc] = true
+		in := &g.inst[pc]
+		var ret *big.Int
+		switch in.Op {
+		case syntax.InstMatch:
+			ret = big.NewInt(1)
+		case syntax.InstRune, syntax.InstRune1:
+			if out := count(in.Out); out != nil {
+				ret = new(big.Int).Set(out)
+				if distinctRunes {
+					ret.Mul(ret, big.NewInt(in.runeCount()))
+				}
+			}
+		case syntax.InstAlt:
+			out, arg := count(in.Out), count(in.Arg)
+			if out != nil && arg != nil {
+				ret = new(big.Int).Add(out, arg)
+			}
+		case syntax.InstCapture, syntax.InstNop, syntax.InstEmptyWidth:
+			if out := count(in.Out); out != nil {
+				ret = new(big.Int).Set(out)
+			}
+		default:
+			ret = new(big.Int)
+		}
+		visiting[pc] = false
+		done[pc] = true
+		counts[pc] = ret
+		return ret
+	}
+	for pc := range g.inst {
+		count(uint32(pc))
+	}
+	return counts
+}
+
+// unrank returns the string of the rank i.
+func (g *Generator) unrank(counts []*big.Int, i *big.Int) string {
+	var result []rune
+	i = new(big.Int).Set(i)
+	var q big.Int
+	pc := uint32(g.prog.Start)
+	for {
+		in := &g.inst[pc]
+		switch in.Op {
+		case syntax.InstMatch:
+			return string(result)
+		case syntax.InstRune1:
+			result = append(result, in.Rune[0])
+			pc = in.Out
+		case syntax.InstRune:
+			q.DivMod(i, counts[in.Out], i)
+			result = append(result, in.nthRune(q.Int64()))
+			pc = in.Out
+		case syntax.InstAlt:
+			if i.Cmp(counts[in.Out]) < 0 {
+				pc = in.Out
+			} else {
+				i.Sub(i, counts[in.Out])
+				pc = in.Arg
+			}
+		default:
+			pc = in.Out
+		}
+	}
+}
+
+// nthRune returns the n-th rune that the instruction can generate.
+func (in *myinst) nthRune(n int64) rune {
+	runes := in.runeGenerator.runes
+	if len(runes) == 1 {
+		return runes[0]
+	}
+	return nthRangeRune(runes, n)
+}
+
+// nthRangeRune returns the n-th rune of the ranges, which are pairs of the lowest and the highest runes.
+func nthRangeRune(runes []rune, n int64) rune {
+	for i := 0; i < len(runes); i += 2 {
+		size := int64(runes[i+1] - runes[i] + 1)
+		if n < size {
+			return runes[i] + rune(n)
+		}
+		n -= size
+	}
+	panic("rerand: rune index out of range")
+}
+
+// distinctStrings ranks the distinct strings of a finite language,
+// by the deterministic automaton whose states are the sets of the instructions of runes.
+type distinctStrings struct {
+	states []distinctState
+	start  int
+}
+
+type distinctState struct {
+	match bool
+	trans []distinctTrans
+	count *big.Int // the number of the distinct strings from the state
+}
+
+// distinctTrans is the transition of a state by the runes.
+type distinctTrans struct {
+	runes []rune // the ranges of the runes, the lowest and the highest runes in pairs
+	size  int64  // the number of the runes
+	next  int
+}
+
+// newDistinctStrings builds the automaton of g, which must generate a finite language.
+// The runes are split by the boundaries of the character classes into the intervals,
+// whose runes are not distinguished by any instruction.
+func (g *Generator) newDistinctStrings() (*distinctStrings, error) {
+	live := g.reachable()
+	ranges := func(in *myinst) []rune {
+		if in.Op == syntax.InstRune1 {
+			return []rune{in.Rune[0], in.Rune[0]}
+		}
+		if runes := in.runeGenerator.runes; len(runes) != 1 {
+			return runes
+		}
+		return []rune{in.runeGenerator.runes[0], in.runeGenerator.runes[0]}
+	}
+	var bounds []rune
+	for pc := range g.inst {
+		in := &g.inst[pc]
+		if !live[pc] || (in.Op != syntax.InstRune && in.Op != syntax.InstRune1) {
+			continue
+		}
+		runes := ranges(in)
+		for i := 0; i < len(runes); i += 2 {
+			bounds = append(bounds, runes[i], runes[i+1]+1)
+		}
+	}
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+
+	// closure returns the instructions of runes that pcs reach without generating runes,
+	// and whether they reach InstMatch.
+	closure := func(pcs []uint32) ([]uint32, bool) {
+		visited := make(map[uint32]bool)
+		var runes []uint32
+		var match bool
+		stack := slices.Clone(pcs)
+		for len(stack) > 0 {
+			pc := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[pc] || !live[pc] {
+				continue
+			}
+			visited[pc] = true
+			in := &g.inst[pc]
+			switch in.Op {
+			case syntax.InstMatch:
+				match = true
+			case syntax.InstRune, syntax.InstRune1:
+				runes = append(runes, pc)
+			case syntax.InstAlt:
+				stack = append(stack, in.Out, in.Arg)
+			case syntax.InstFail:
+			default:
+				stack = append(stack, in.Out)
+			}
+		}
+		slices.Sort(runes)
+		return runes, match
+	}
+
+	d := &distinctStrings{}
+	index := make(map[string]int)
+	var state func(pcs []uint32) (int, error)
+	state = func(pcs []uint32) (int, error) {
+		runes, match := closure(pcs)
+		key := fmt.Sprint(match, runes)
+		if i, ok := index[key]; ok {
+			return i, nil
+		}
+		if len(d.states) >= maxDistinctStates {
+			return 0, fmt.Errorf("rerand: the distinct strings need more than %d states to be counted", maxDistinctStates)
+		}
+		i := len(d.states)
+		index[key] = i
+		d.states = append(d.states, distinctState{match: match})
+
+		// next[j] is the next instructions by the interval from bounds[j] to bounds[j+1]-1.
+		next := make(map[int][]uint32)
+		for _, pc := range runes {
+			in := &g.inst[pc]
+			rs := ranges(in)
+			for k := 0; k < len(rs); k += 2 {
+				j, _ := slices.BinarySearch(bounds, rs[k])
+				for ; j+1 < len(bounds) && bounds[j] <= rs[k+1]; j++ {
+					next[j] = append(next[j], in.Out)
+				}
+			}
+		}
+		intervals := make([]int, 0, len(next))
+		for j := range next {
+			intervals = append(intervals, j)
+		}
+		slices.Sort(intervals)
+
+		// the intervals to the same state share a transition.
+		var trans []distinctTrans
+		byNext := make(map[string]int)
+		for _, j := range intervals {
+			outs := next[j]
+			slices.Sort(outs)
+			outs = slices.Compact(outs)
+			key := fmt.Sprint(outs)
+			t, ok := byNext[key]
+			if !ok {
+				n, err := state(outs)
+				if err != nil {
+					return 0, err
+				}
+				t = len(trans)
+				byNext[key] = t
+				trans = append(trans, distinctTrans{next: n})
+			}
+			lo, hi := bounds[j], bounds[j+1]-1
+			trans[t].runes = append(trans[t].runes, lo, hi)
+			trans[t].size += int64(hi - lo + 1)
+		}
+		d.states[i].trans = trans
+		return i, nil
+	}
+	start, err := state([]uint32{uint32(g.prog.Start)})
+	if err != nil {
+		return nil, err
+	}
+	d.start = start
+
+	visiting := make([]bool, len(d.states))
+	var count func(i int) *big.Int
+	count = func(i int) *big.Int {
+		st := &d.states[i]
+		if st.count != nil || visiting[i] {
+			return st.count
+		}
+		visiting[i] = true
+		c := new(big.Int)
+		if st.match {
+			c.SetInt64(1)
+		}
+		for _, t := range st.trans {
+			next := count(t.next)
+			if next == nil {
+				return nil
+			}
+			c.Add(c, new(big.Int).Mul(next, big.NewInt(t.size)))
+		}
+		visiting[i] = false
+		st.count = c
+		return c
+	}
+	if count(d.start) == nil {
+		return nil, ErrInfinite
+	}
+	return d, nil
+}
+
+// total returns the number of the distinct strings.
+func (d *distinctStrings) total() *big.Int {
+	return d.states[d.start].count
+}
+
+// unrank returns the distinct string of the rank i.
+func (d *distinctStrings) unrank(i *big.Int) string {
+	var result []rune
+	i = new(big.Int).Set(i)
+	var q, block big.Int
+	s := d.start
+	for {
+		st := &d.states[s]
+		if st.match {
+			if i.Sign() == 0 {
+				return string(result)
+			}
+			i.Sub(i, big.NewInt(1))
+		}
+		for _, t := range st.trans {
+			next := d.states[t.next].count
+			block.Mul(next, big.NewInt(t.size))
+			if i.Cmp(&block) < 0 {
+				q.DivMod(i, next, i)
+				result = append(result, nthRangeRune(t.runes, q.Int64()))
+				s = t.next
+				break
+			}
+			i.Sub(i, &block)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	g := Must(New(`[a-c]{2}|x[0-9]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	re := regexp.MustCompile(`^(?:[a-c]{2}|x[0-9])$`)
+	for _, k := range []int{0, 1, 5, 18, 19} {
+		samples, err := g.Sample(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(samples) != k {
+			t.Fatalf("want %d samples, got %d", k, len(samples))
+		}
+		seen := map[string]bool{}
+		for _, s := range samples {
+			if !re.MatchString(s) {
+				t.Errorf("%q does not match", s)
+			}
+			if seen[s] {
+				t.Errorf("%q is sampled twice", s)
+			}
+			seen[s] = true
+		}
+	}
+
+	if _, err := g.Sample(20); !errors.Is(err, ErrTooFewStrings) {
+		t.Errorf("want ErrTooFewStrings, got %v", err)
+	}
+}
+
+func TestSampleUniform(t *testing.T) {
+	// a[0-9] has 10 strings, and b has 1, so b is chosen 1/11 of the time by Sample(1).
+	g := Must(New(`a[0-9]|b`, syntax.Perl, rand.New(rand.NewSource(1))))
+	counts := map[string]int{}
+	for i := 0; i < 11000; i++ {
+		samples, err := g.Sample(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[samples[0]]++
+	}
+	if len(counts) != 11 {
+		t.Fatalf("want 11 strings, got %v", counts)
+	}
+	for s, c := range counts {
+		if c < 800 || c > 1200 {
+			t.Errorf("%q is sampled %d times, want about 1000", s, c)
+		}
+	}
+}
+
+func TestSampleAmbiguous(t *testing.T) {
+	g := Must(New(`(?:ab|a)b?`, syntax.Perl, nil))
+	samples, err := g.Sample(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(samples)
+	if want := []string{"a", "ab", "abb"}; !slices.Equal(samples, want) {
+		t.Errorf("want %v, got %v", want, samples)
+	}
+	if _, err := g.Sample(4); !errors.Is(err, ErrTooFewStrings) {
+		t.Errorf("want ErrTooFewStrings, got %v", err)
+	}
+}
+
+func TestSampleLarge(t *testing.T) {
+	g := Must(New(`[0-9a-f]{32}`, syntax.Perl, nil))
+	samples, err := g.Sample(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	re := regexp.MustCompile(`^[0-9a-f]{32}$`)
+	for _, s := range samples {
+		if !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+}
+
+func TestSampleInfinite(t *testing.T) {
+	g := Must(New(`a+`, syntax.Perl, nil, WithTargetLength(3)))
+	if _, err := g.Sample(1); !errors.Is(err, ErrInfinite) {
+		t.Errorf("want ErrInfinite, got %v", err)
+	}
+}
+
+func TestSampleAmbiguousLarge(t *testing.T) {
+	// 41 distinct strings, generated in about 2^40 ways.
+	g := Must(New(`(?:a|aa){40}`, syntax.Perl, nil))
+	samples, err := g.Sample(41)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(samples)
+	if len(slices.Compact(samples)) != 41 {
+		t.Errorf("want 41 distinct strings, got %q", samples)
+	}
+	if _, err := g.Sample(42); !errors.Is(err, ErrTooFewStrings) {
+		t.Errorf("want ErrTooFewStrings, got %v", err)
+	}
+}
+
+func TestSampleAmbiguousUniform(t *testing.T) {
+	// "ab" is generated in two ways, but it is as likely as the other strings.
+	g := Must(New(`ab|[a-z]b`, syntax.Perl, rand.New(rand.NewSource(1))))
+	var ab int
+	for i := 0; i < 26000; i++ {
+		samples, err := g.Sample(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if samples[0] == "ab" {
+			ab++
+		}
+	}
+	if ab < 700 || ab > 1300 {
+		t.Errorf("want about 1000 of ab, got %d", ab)
+	}
+}
@@ -0,0 +1,89 @@
+package rerand
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp/syntax"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Schema generates records that consist of named fields.
+type Schema struct {
+	names []string
+	gens  []*Generator
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewSchema returns new Schema.
+// fields maps the name of each field to its pattern.
+func NewSchema(fields map[string]string, flags syntax.Flags, r *rand.Rand, opts ...Option) (*Schema, error) {
+	if r == nil {
+		r = rand.New(newPCGSource(time.Now().UnixNano()))
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// each Generator has its own source split from r,
+	// because Generator returns them, and they are used concurrently with GenerateRecord.
+	gens := make([]*Generator, len(names))
+	for i, name := range names {
+		src := newPCGSource(r.Int63())
+		g, err := newGenerator(fields[name], flags, rand.New(src), opts)
+		if err != nil {
+			return nil, fmt.Errorf("rerand: field %q: %w", name, err)
+		}
+		g.src = src
+		gens[i] = g
+	}
+	return &Schema{
+		names: names,
+		gens:  gens,
+		rand:  r,
+	}, nil
+}
+
+// Fields returns the names of the fields in sorted order.
+func (s *Schema) Fields() []string {
+	return append([]string(nil), s.names...)
+}
+
+// Generator returns the Generator of the field, or nil if the field does not exist.
+// It has its own random number generator, so it is safe to use concurrently with the methods of s.
+func (s *Schema) Generator(name string) *Generator {
+	i := sort.SearchStrings(s.names, name)
+	if i < len(s.names) && s.names[i] == name {
+		return s.gens[i]
+	}
+	return nil
+}
+
+// GenerateRecord generates a random record.
+// It is safe for concurrent use by multiple goroutines.
+func (s *Schema) GenerateRecord() map[string]string {
+	record := make(map[string]string, len(s.names))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, g := range s.gens {
+		record[s.names[i]] = g.generate(s.rand, nopLocker{})
+	}
+	return record
+}
+
+// AppendRecord appends a random record to dst as values ordered by Fields.
+// It is safe for concurrent use by multiple goroutines.
+func (s *Schema) AppendRecord(dst []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, g := range s.gens {
+		dst = append(dst, g.generate(s.rand, nopLocker{}))
+	}
+	return dst
+}
@@ -0,0 +1,73 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"sync"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	fields := map[string]string{
+		"id":    `\d{6}`,
+		"name":  `[A-Z][a-z]{2,8}`,
+		"email": `[a-z]{3,8}@example\.com`,
+	}
+	s, err := NewSchema(fields, syntax.Perl, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Fields(), []string{"email", "id", "name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	for i := 0; i < 100; i++ {
+		record := s.GenerateRecord()
+		if len(record) != len(fields) {
+			t.Errorf("want %d fields, got %d", len(fields), len(record))
+		}
+		for name, pattern := range fields {
+			re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+			if !re.MatchString(record[name]) {
+				t.Errorf("%s: %q does not match %s", name, record[name], pattern)
+			}
+		}
+	}
+}
+
+func TestSchemaConcurrent(t *testing.T) {
+	s, err := NewSchema(map[string]string{
+		"id":   `\d{6}`,
+		"name": `[A-Z][a-z]{2,8}`,
+	}, syntax.Perl, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the Generators of the fields do not share the random number generator with s.
+	var wg sync.WaitGroup
+	for _, name := range s.Fields() {
+		wg.Add(1)
+		go func(g *Generator) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				g.Generate()
+			}
+		}(s.Generator(name))
+	}
+	for i := 0; i < 100; i++ {
+		s.GenerateRecord()
+	}
+	wg.Wait()
+}
+
+func TestSchemaError(t *testing.T) {
+	_, err := NewSchema(map[string]string{"id": `\d*`}, syntax.Perl, nil)
+	if err == nil {
+		t.Fatal("want error, got nil")
+	}
+	if !errors.Is(err, ErrTooManyRepeat) {
+		t.Errorf("want ErrTooManyRepeat, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package rerand
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+	"regexp/syntax"
+)
+
+// NewSeeded returns new Generator that uses the random number generator seeded by seed.
+// The random number generator is PCG, whose algorithm is fixed,
+// so the generated strings are reproducible from the seed.
+// Use WithStreamVersion to make them reproducible across versions of this package.
+func NewSeeded(pattern string, flags syntax.Flags, seed int64, opts ...Option) (*Generator, error) {
+	src := newPCGSource(seed)
+	g, err := newGenerator(pattern, flags, rand.New(src), opts)
+	if err != nil {
+		return nil, err
+	}
+	g.src = src
+	return g, nil
+}
+
+// Reseed seeds the random number generator of g.
+// If g is created by New with user supplied *rand.Rand, the *rand.Rand is reseeded.
+func (g *Generator) Reseed(seed int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rand.Seed(seed)
+}
+
+// pcgStream is the second half of the PCG seed, which is fixed.
+const pcgStream = 0x5245524e44 // "RERND"
+
+// pcgSource is a rand.Source64 backed by PCG of math/rand/v2.
+type pcgSource struct {
+	pcg *randv2.PCG
+}
+
+func newPCGSource(seed int64) *pcgSource {
+	return &pcgSource{pcg: randv2.NewPCG(uint64(seed), pcgStream)}
+}
+
+func (s *pcgSource) Int63() int64 {
+	return int64(s.pcg.Uint64() >> 1)
+}
+
+func (s *pcgSource) Uint64() uint64 {
+	return s.pcg.Uint64()
+}
+
+func (s *pcgSource) Seed(seed int64) {
+	s.pcg.Seed(uint64(seed), pcgStream)
+}
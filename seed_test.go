@@ -0,0 +1,51 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"testing"
+)
+
+func TestNewSeeded(t *testing.T) {
+	g1 := Must(NewSeeded(`[a-z]{8}`, syntax.Perl, 42))
+	g2 := Must(NewSeeded(`[a-z]{8}`, syntax.Perl, 42))
+	for i := 0; i < 100; i++ {
+		if a, b := g1.Generate(), g2.Generate(); a != b {
+			t.Fatalf("want same strings, got %q and %q", a, b)
+		}
+	}
+}
+
+func TestReseed(t *testing.T) {
+	g := Must(NewSeeded(`[a-z]{8}`, syntax.Perl, 42))
+	want := []string{g.Generate(), g.Generate(), g.Generate()}
+	g.Reseed(42)
+	for i, w := range want {
+		if got := g.Generate(); got != w {
+			t.Errorf("%d: want %q, got %q", i, w, got)
+		}
+	}
+}
+
+func TestStreamV1(t *testing.T) {
+	// These strings must not change across versions of this package.
+	cases := []struct {
+		g    *Generator
+		want []string
+	}{
+		{
+			Must(NewSeeded(`\d{2,3}-\d{3,4}-\d{3,4}`, syntax.Perl, 1, WithStreamVersion(StreamV1))),
+			[]string{"54-1303-5590", "61-452-235", "71-5998-7759"},
+		},
+		{
+			Must(NewSeeded(`[a-zA-Z]{3}|x{1,3}`, syntax.Perl, 1, WithStreamVersion(StreamV1), WithDistinctRunes())),
+			[]string{"Nrb", "Qgm", "vfh"},
+		},
+	}
+	for _, c := range cases {
+		for i, w := range c.want {
+			if got := c.g.Generate(); got != w {
+				t.Errorf("%s: %d: want %q, got %q", c.g, i, w, got)
+			}
+		}
+	}
+}
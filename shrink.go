@@ -0,0 +1,116 @@
+package rerand
+
+import (
+	"iter"
+	"regexp/syntax"
+	"unicode/utf8"
+)
+
+// Shrink yields strings that are simpler than s and still generated by g.
+// A string is simpler if it is shorter, or it has the same length and takes earlier branches or smaller runes.
+// The most aggressive candidates are yielded first.
+// If g never generates s, Shrink yields nothing.
+//
+// Typical property-test loop is:
+//
+//	for shrunk := true; shrunk; {
+//		shrunk = false
+//		for c := range g.Shrink(s) {
+//			if fails(c) {
+//				s, shrunk = c, true
+//				break
+//			}
+//		}
+//	}
+func (g *Generator) Shrink(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		steps, ok := g.parse(s)
+		if !ok {
+			return
+		}
+		length := utf8.RuneCountInString(s)
+		seen := map[string]bool{s: true}
+		try := func(choices []step) bool {
+			c, cs := g.replay(choices)
+			if seen[c] {
+				return true
+			}
+			seen[c] = true
+			l := utf8.RuneCountInString(c)
+			if l > length || (l == length && !lessSteps(cs, steps)) {
+				return true
+			}
+			return yield(c)
+		}
+
+		// the simplest string
+		if !try(nil) {
+			return
+		}
+
+		// remove some runes
+		runes := []rune(s)
+		for size := len(runes) / 2; size > 0; size /= 2 {
+			for i := 0; i+size <= len(runes); i++ {
+				c := string(runes[:i]) + string(runes[i+size:])
+				if seen[c] {
+					continue
+				}
+				seen[c] = true
+				if _, ok := g.parse(c); !ok {
+					continue
+				}
+				if !yield(c) {
+					return
+				}
+			}
+		}
+
+		// take the other branch, and complete by the shortest string
+		for i, st := range steps {
+			if g.inst[st.pc].Op != syntax.InstAlt {
+				continue
+			}
+			choices := append(steps[:i:i], step{pc: st.pc, choice: 1 - st.choice})
+			if !try(choices) {
+				return
+			}
+		}
+
+		// make runes smaller
+		for i, st := range steps {
+			in := &g.inst[st.pc]
+			if in.Op != syntax.InstRune {
+				continue
+			}
+			min := in.minRune()
+			candidates := []rune{min}
+			if mid := min + (st.choice-min)/2; in.matchRune(mid) {
+				candidates = append(candidates, mid)
+			}
+			if in.matchRune(st.choice - 1) {
+				candidates = append(candidates, st.choice-1)
+			}
+			for _, r := range candidates {
+				if r >= st.choice {
+					continue
+				}
+				choices := append([]step(nil), steps...)
+				choices[i].choice = r
+				if !try(choices) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// lessSteps reports whether a is lexicographically less than b.
+func lessSteps(a, b []step) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i].choice != b[i].choice {
+			return a[i].choice < b[i].choice
+		}
+	}
+	return len(a) < len(b)
+}
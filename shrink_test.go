@@ -0,0 +1,73 @@
+package rerand
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestShrink(t *testing.T) {
+	cases := []struct {
+		pattern string
+		in      string
+		want    string
+	}{
+		{`a{1,5}`, `aaaa`, `a`},
+		{`abc|def`, `def`, `abc`},
+		{`[a-z]{3}`, `xyz`, `aaa`},
+		{`[0-9]{1,3}-[a-z]`, `123-x`, `0-a`},
+	}
+
+	for _, c := range cases {
+		g := Must(New(c.pattern, syntax.Perl, nil))
+		re := regexp.MustCompile(`^(?:` + c.pattern + `)$`)
+		var got []string
+		for s := range g.Shrink(c.in) {
+			if !re.MatchString(s) {
+				t.Errorf("%s: %q does not match", c.pattern, s)
+			}
+			if utf8.RuneCountInString(s) > utf8.RuneCountInString(c.in) {
+				t.Errorf("%s: %q is longer than %q", c.pattern, s, c.in)
+			}
+			got = append(got, s)
+		}
+		if len(got) == 0 || got[0] != c.want {
+			t.Errorf("%s: want %q first, got %q", c.pattern, c.want, got)
+		}
+	}
+}
+
+func TestShrinkLoop(t *testing.T) {
+	g := Must(New(`[a-z]{1,8}[0-9]{1,4}`, syntax.Perl, nil))
+	// fails if the string contains 'x' or digits greater than 5.
+	fails := func(s string) bool {
+		for _, r := range s {
+			if r == 'x' || r > '5' && r <= '9' {
+				return true
+			}
+		}
+		return false
+	}
+
+	s := "abxde789"
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for c := range g.Shrink(s) {
+			if fails(c) {
+				s, shrunk = c, true
+				break
+			}
+		}
+	}
+	if s != "x0" && s != "a6" {
+		t.Errorf("want minimal counterexample, got %q", s)
+	}
+}
+
+func TestShrinkNoMatch(t *testing.T) {
+	g := Must(New(`[a-z]{3}`, syntax.Perl, nil))
+	for s := range g.Shrink("123") {
+		t.Errorf("unexpected %q", s)
+	}
+}
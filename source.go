@@ -0,0 +1,41 @@
+package rerand
+
+import (
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"regexp/syntax"
+)
+
+// NewFromSource returns new Generator that uses src as the source of random numbers.
+// src may be rand.Source64, e.g. the source returned by NewReaderSource.
+func NewFromSource(pattern string, flags syntax.Flags, src rand.Source, opts ...Option) (*Generator, error) {
+	return newGenerator(pattern, flags, rand.New(src), opts)
+}
+
+// readerSource is a rand.Source64 that reads random numbers from io.Reader.
+type readerSource struct {
+	r   io.Reader
+	buf [8]byte
+}
+
+// NewReaderSource returns rand.Source64 that reads random numbers from r,
+// e.g. crypto/rand.Reader, hardware random number generators and recorded entropy for replay.
+// The source reads 8 bytes for each random number, and panics if it fails to read from r.
+// Seed of the source does nothing.
+func NewReaderSource(r io.Reader) rand.Source64 {
+	return &readerSource{r: r}
+}
+
+func (s *readerSource) Uint64() uint64 {
+	if _, err := io.ReadFull(s.r, s.buf[:]); err != nil {
+		panic("rerand: failed to read random numbers: " + err.Error())
+	}
+	return binary.LittleEndian.Uint64(s.buf[:])
+}
+
+func (s *readerSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *readerSource) Seed(seed int64) {}
@@ -0,0 +1,43 @@
+package rerand
+
+import (
+	"bytes"
+	"crypto/rand"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestNewFromSource(t *testing.T) {
+	re := regexp.MustCompile(`^[a-z]{8}$`)
+	g := Must(NewFromSource(`[a-z]{8}`, syntax.Perl, NewReaderSource(rand.Reader)))
+	for i := 0; i < 100; i++ {
+		if s := g.Generate(); !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+}
+
+func TestReaderSourceReplay(t *testing.T) {
+	entropy := make([]byte, 8*1024)
+	if _, err := rand.Read(entropy); err != nil {
+		t.Fatal(err)
+	}
+	g1 := Must(NewFromSource(`[a-z]{8}`, syntax.Perl, NewReaderSource(bytes.NewReader(entropy))))
+	g2 := Must(NewFromSource(`[a-z]{8}`, syntax.Perl, NewReaderSource(bytes.NewReader(entropy))))
+	for i := 0; i < 100; i++ {
+		if a, b := g1.Generate(), g2.Generate(); a != b {
+			t.Errorf("want same strings, got %q and %q", a, b)
+		}
+	}
+}
+
+func TestReaderSourceEOF(t *testing.T) {
+	g := Must(NewFromSource(`[a-z]{8}`, syntax.Perl, NewReaderSource(bytes.NewReader(nil))))
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic")
+		}
+	}()
+	g.Generate()
+}
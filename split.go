@@ -0,0 +1,38 @@
+package rerand
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// Split returns n child generators that share the compiled program with g, and have independent streams of random numbers.
+// The streams are derived from a random number of g by SplitMix64, and each child uses PCG with its own seed and stream,
+// so parallel workers generate non-overlapping sequences that are reproducible if g is seeded, e.g. by NewSeeded.
+// The children own their random number generators, so State and Checkpoint are available.
+// It advances the random number generator of g.
+// It panics if n is negative.
+func (g *Generator) Split(n int) []*Generator {
+	if n < 0 {
+		panic("rerand: Split with negative n")
+	}
+	g.mu.Lock()
+	x := g.rand.Uint64()
+	g.mu.Unlock()
+
+	children := make([]*Generator, n)
+	for i := range children {
+		seed := splitMix64(&x)
+		stream := splitMix64(&x)
+		src := &pcgSource{pcg: randv2.NewPCG(seed, stream)}
+		child := g.Clone(rand.New(src))
+		child.src = src
+		children[i] = child
+	}
+	return children
+}
+
+// splitMix64 advances the state x of SplitMix64, and returns the next random number.
+func splitMix64(x *uint64) uint64 {
+	*x += 0x9e3779b97f4a7c15
+	return mix64(*x)
+}
@@ -0,0 +1,72 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"slices"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	generate := func() [][]string {
+		g := Must(NewSeeded(`[a-z]{16}`, syntax.Perl, 42))
+		children := g.Split(4)
+		result := make([][]string, len(children))
+		for i, child := range children {
+			for j := 0; j < 100; j++ {
+				result[i] = append(result[i], child.Generate())
+			}
+		}
+		return result
+	}
+
+	a, b := generate(), generate()
+	for i := range a {
+		if !slices.Equal(a[i], b[i]) {
+			t.Errorf("child %d is not reproducible", i)
+		}
+	}
+
+	seen := map[string]int{}
+	for i, ss := range a {
+		for _, s := range ss {
+			if j, ok := seen[s]; ok {
+				t.Errorf("%q is generated by the children %d and %d", s, j, i)
+			}
+			seen[s] = i
+		}
+	}
+}
+
+func TestSplitState(t *testing.T) {
+	g := Must(NewSeeded(`[a-z]{16}`, syntax.Perl, 42))
+	child := g.Split(1)[0]
+	state, err := child.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := child.Generate()
+	if err := child.RestoreState(state); err != nil {
+		t.Fatal(err)
+	}
+	if got := child.Generate(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestSplitAdvances(t *testing.T) {
+	g := Must(NewSeeded(`[a-z]{16}`, syntax.Perl, 42))
+	a := g.Split(1)[0].Generate()
+	b := g.Split(1)[0].Generate()
+	if a == b {
+		t.Errorf("want different streams, got %q twice", a)
+	}
+}
+
+func TestSplitNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic, got nil")
+		}
+	}()
+	Must(New(`a`, syntax.Perl, nil)).Split(-1)
+}
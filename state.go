@@ -0,0 +1,31 @@
+package rerand
+
+import (
+	"errors"
+)
+
+// ErrStateUnavailable is returned if the state of the random number generator is not owned by the Generator.
+var ErrStateUnavailable = errors.New("rerand: the state of the random number generator is unavailable")
+
+// State returns the state of the random number generator.
+// The state is available if g is created by NewSeeded or with nil *rand.Rand,
+// otherwise ErrStateUnavailable is returned.
+func (g *Generator) State() ([]byte, error) {
+	if g.src == nil {
+		return nil, ErrStateUnavailable
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.src.pcg.MarshalBinary()
+}
+
+// RestoreState restores the state of the random number generator, saved by State.
+// After restoring, g generates the same strings as the Generator that saved the state.
+func (g *Generator) RestoreState(state []byte) error {
+	if g.src == nil {
+		return ErrStateUnavailable
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.src.pcg.UnmarshalBinary(state)
+}
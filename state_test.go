@@ -0,0 +1,43 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	g := Must(NewSeeded(`[a-z]{8}`, syntax.Perl, 42))
+	g.Generate()
+	state, err := g.State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{g.Generate(), g.Generate(), g.Generate()}
+
+	// resume in another generator
+	g2 := Must(NewSeeded(`[a-z]{8}`, syntax.Perl, 0))
+	if err := g2.RestoreState(state); err != nil {
+		t.Fatal(err)
+	}
+	for i, w := range want {
+		if got := g2.Generate(); got != w {
+			t.Errorf("%d: want %q, got %q", i, w, got)
+		}
+	}
+}
+
+func TestStateUnavailable(t *testing.T) {
+	g := Must(New(`[a-z]{8}`, syntax.Perl, rand.New(rand.NewSource(1))))
+	if _, err := g.State(); err != ErrStateUnavailable {
+		t.Errorf("want ErrStateUnavailable, got %v", err)
+	}
+	if err := g.RestoreState(nil); err != ErrStateUnavailable {
+		t.Errorf("want ErrStateUnavailable, got %v", err)
+	}
+
+	g = Must(New(`[a-z]{8}`, syntax.Perl, nil))
+	if _, err := g.State(); err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package rerand
+
+import (
+	"context"
+	"time"
+)
+
+// Stream returns a channel that receives random strings at rate strings per second,
+// until ctx is done. If rate is not positive, the strings are sent as fast as they are received.
+// The channel is closed when ctx is done or the generation fails.
+func (g *Generator) Stream(ctx context.Context, rate float64) <-chan string {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		var interval time.Duration
+		var timer *time.Timer
+		if rate > 0 {
+			interval = time.Duration(float64(time.Second) / rate)
+			timer = time.NewTimer(0)
+			defer timer.Stop()
+		}
+		start := time.Now()
+		for n := int64(1); ; n++ {
+			if timer != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case <-timer.C:
+				}
+				// schedule from the start, so that the delay of the receiver does not accumulate.
+				timer.Reset(time.Until(start.Add(time.Duration(n) * interval)))
+			}
+			s, err := g.TryGenerate()
+			if err != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- s:
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,48 @@
+package rerand
+
+import (
+	"context"
+	"regexp"
+	"regexp/syntax"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	pattern := `[a-z]{3}-\d{3}`
+	re := regexp.MustCompile(`\A(?:` + pattern + `)\z`)
+	g := Must(New(pattern, syntax.Perl, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := g.Stream(ctx, 0)
+	for i := 0; i < 100; i++ {
+		if s := <-ch; !re.MatchString(s) {
+			t.Errorf("%q does not match", s)
+		}
+	}
+	cancel()
+	for range ch {
+		// drain until closed
+	}
+}
+
+func TestStreamRate(t *testing.T) {
+	g := Must(New(`[a-z]{3}`, syntax.Perl, nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	var n int
+	for range g.Stream(ctx, 100) {
+		n++
+	}
+	// about 20 strings in 200ms
+	if n < 5 || n > 25 {
+		t.Errorf("want about 20 strings, got %d", n)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	g := Must(New(`[a-z]`, syntax.Perl, nil, WithFilter(func(string) bool { return false }, 1)))
+	for s := range g.Stream(context.Background(), 0) {
+		t.Errorf("unexpected string %q", s)
+	}
+}
@@ -0,0 +1,33 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"sync"
+	"text/template"
+)
+
+// TemplateFuncs returns the functions for text/template and html/template.
+// The function "rerand" generates a random string that matches the pattern in the Perl syntax, e.g. {{rerand "[a-z]{8}"}}.
+// The generators are compiled once and cached in the returned FuncMap,
+// so use one FuncMap for each template.
+func TemplateFuncs() template.FuncMap {
+	var mu sync.Mutex
+	cache := map[string]*Generator{}
+	return template.FuncMap{
+		"rerand": func(pattern string) (string, error) {
+			mu.Lock()
+			g, ok := cache[pattern]
+			if !ok {
+				var err error
+				g, err = New(pattern, syntax.Perl, nil)
+				if err != nil {
+					mu.Unlock()
+					return "", err
+				}
+				cache[pattern] = g
+			}
+			mu.Unlock()
+			return g.TryGenerate()
+		},
+	}
+}
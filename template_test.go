@@ -0,0 +1,45 @@
+package rerand
+
+import (
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("config").Funcs(TemplateFuncs()).Parse(
+		`user={{rerand "[a-z]{8}"}} port={{rerand "[1-9][0-9]{3}"}}`,
+	))
+	re := regexp.MustCompile(`\Auser=[a-z]{8} port=[1-9][0-9]{3}\z`)
+	for i := 0; i < 10; i++ {
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, nil); err != nil {
+			t.Fatal(err)
+		}
+		if !re.MatchString(buf.String()) {
+			t.Errorf("%q does not match", buf.String())
+		}
+	}
+}
+
+func TestTemplateFuncsHTML(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("html").Funcs(htmltemplate.FuncMap(TemplateFuncs())).Parse(
+		`<p>{{rerand "<[a-z]{3}>"}}</p>`,
+	))
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`\A<p>&lt;[a-z]{3}&gt;</p>\z`).MatchString(buf.String()) {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+}
+
+func TestTemplateFuncsError(t *testing.T) {
+	tmpl := template.Must(template.New("error").Funcs(TemplateFuncs()).Parse(`{{rerand "[a-z"}}`))
+	if err := tmpl.Execute(&strings.Builder{}, nil); err == nil {
+		t.Error("want error, got nil")
+	}
+}
@@ -0,0 +1,73 @@
+package rerand
+
+import (
+	"math/big"
+	"math/rand"
+	"regexp/syntax"
+	"sync"
+)
+
+// TraceStep is a step of the program executed by GenerateTrace.
+type TraceStep struct {
+	// PC is the program counter of the instruction.
+	PC uint32
+
+	// Op is the operation of the instruction.
+	// InstRuneAny and InstRuneAnyNotNL are reported as InstRune.
+	Op syntax.InstOp
+
+	// Branch is the branch taken by InstAlt, 0 for Out and 1 for Arg.
+	Branch int
+
+	// Rune is the rune generated by InstRune and InstRune1.
+	Rune rune
+}
+
+// GenerateTrace generates a random string, and returns the executed instructions.
+// The string is not transformed nor filtered by WithTransform and WithFilter.
+// It is safe for concurrent use by multiple goroutines.
+func (g *Generator) GenerateTrace() (string, []TraceStep) {
+	var result []rune
+	var trace []TraceStep
+	pc := uint32(g.prog.Start)
+	for {
+		in := &g.inst[pc]
+		step := TraceStep{PC: pc, Op: in.Op}
+		switch in.Op {
+		case syntax.InstMatch:
+			trace = append(trace, step)
+			return string(result), trace
+		case syntax.InstRune:
+			step.Rune = in.generateRune(g.rand, &g.mu)
+			result = append(result, step.Rune)
+			pc = in.Out
+		case syntax.InstRune1:
+			step.Rune = in.Rune[0]
+			result = append(result, step.Rune)
+			pc = in.Out
+		case syntax.InstAlt:
+			if in.alt(g.rand, &g.mu) {
+				pc = in.Out
+			} else {
+				step.Branch = 1
+				pc = in.Arg
+			}
+		default:
+			pc = in.Out
+		}
+		trace = append(trace, step)
+	}
+}
+
+// alt reports whether InstAlt takes Out.
+// mu guards r.
+func (in *myinst) alt(r *rand.Rand, mu sync.Locker) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	if in.y > 0 {
+		return r.Int63n(in.y) < in.x
+	}
+	var a big.Int
+	a.Rand(r, in.bigY)
+	return a.Cmp(in.bigX) < 0
+}
@@ -0,0 +1,37 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestGenerateTrace(t *testing.T) {
+	g := Must(New(`abc|d[ef]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	g2 := Must(New(`abc|d[ef]`, syntax.Perl, rand.New(rand.NewSource(1))))
+	for i := 0; i < 100; i++ {
+		s, trace := g.GenerateTrace()
+		if want := g2.Generate(); s != want {
+			t.Errorf("want %q, got %q", want, s)
+		}
+		if trace[0].Op != syntax.InstAlt {
+			t.Errorf("want alt, got %v", trace[0].Op)
+		}
+		if last := trace[len(trace)-1]; last.Op != syntax.InstMatch {
+			t.Errorf("want match, got %v", last.Op)
+		}
+
+		var runes []rune
+		for _, step := range trace {
+			if step.Op == syntax.InstRune || step.Op == syntax.InstRune1 {
+				runes = append(runes, step.Rune)
+			}
+		}
+		if string(runes) != s {
+			t.Errorf("want %q, got %q", s, string(runes))
+		}
+		if branch := trace[0].Branch; (s == "abc") != (branch == 0) {
+			t.Errorf("unexpected branch %d for %q", branch, s)
+		}
+	}
+}
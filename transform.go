@@ -0,0 +1,9 @@
+package rerand
+
+// transform applies the transforms of WithTransform.
+func (g *Generator) transform(s string) string {
+	for _, f := range g.opts.transforms {
+		s = f(s)
+	}
+	return s
+}
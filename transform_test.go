@@ -0,0 +1,27 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+func TestWithTransform(t *testing.T) {
+	re := regexp.MustCompile(`^[A-Z]{4}-X$`)
+	g := Must(New(`[a-z]{4}`, syntax.Perl, rand.New(rand.NewSource(1)),
+		WithTransform(strings.ToUpper),
+		WithTransform(func(s string) string { return s + "-X" }),
+		WithFilter(func(s string) bool { return re.MatchString(s) }, 1),
+	))
+	for i := 0; i < 100; i++ {
+		s, err := g.TryGenerate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !re.MatchString(s) {
+			t.Errorf("unexpected %q", s)
+		}
+	}
+}
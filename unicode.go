@@ -0,0 +1,51 @@
+package rerand
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// limitRunes returns the ranges of runes limited to ceiling, in the same way as `.`.
+// By default, supplementary private use areas beyond maxRune are excluded, e.g. from `[^a]` and `\PL`.
+// If all of the runes are beyond ceiling, e.g. `\x{F0000}`, runes is returned as is.
+func limitRunes(runes []rune, ceiling rune) []rune {
+	if len(runes) < 2 || len(runes)%2 != 0 || runes[len(runes)-1] <= ceiling {
+		return runes
+	}
+	var ret []rune
+	for i := 0; i < len(runes); i += 2 {
+		lo, hi := runes[i], runes[i+1]
+		if lo > ceiling {
+			break
+		}
+		ret = append(ret, lo, min(hi, ceiling))
+	}
+	if len(ret) == 0 {
+		return runes
+	}
+	return ret
+}
+
+// UnicodeClass returns the pattern of the character class that consists of the Unicode scripts and categories,
+// e.g. UnicodeClass("Hiragana", "Katakana") returns `[\p{Hiragana}\p{Katakana}]`.
+// It returns an error if the name is not supported by regexp/syntax.
+func UnicodeClass(names ...string) (string, error) {
+	if len(names) == 0 {
+		return "", fmt.Errorf("rerand: no unicode class")
+	}
+	var buf strings.Builder
+	buf.WriteByte('[')
+	for _, name := range names {
+		if _, ok := unicode.Scripts[name]; !ok && name != "Any" {
+			if _, ok := unicode.Categories[name]; !ok {
+				return "", fmt.Errorf("rerand: unknown unicode class %q", name)
+			}
+		}
+		buf.WriteString(`\p{`)
+		buf.WriteString(name)
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+	return buf.String(), nil
+}
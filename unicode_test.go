@@ -0,0 +1,106 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"slices"
+	"testing"
+	"unicode"
+)
+
+func TestUnicodeClasses(t *testing.T) {
+	tests := []struct {
+		pattern string
+		in      func(r rune) bool
+	}{
+		{`\p{Hiragana}`, func(r rune) bool { return unicode.Is(unicode.Hiragana, r) }},
+		{`\p{Katakana}`, func(r rune) bool { return unicode.Is(unicode.Katakana, r) }},
+		{`\p{Han}`, func(r rune) bool { return unicode.Is(unicode.Han, r) }},
+		{`\p{Greek}`, func(r rune) bool { return unicode.Is(unicode.Greek, r) }},
+		{`\p{Cyrillic}`, func(r rune) bool { return unicode.Is(unicode.Cyrillic, r) }},
+		{`\p{Arabic}`, func(r rune) bool { return unicode.Is(unicode.Arabic, r) }},
+		{`\p{Lu}`, unicode.IsUpper},
+		{`\p{Ll}`, unicode.IsLower},
+		{`\p{Nd}`, unicode.IsDigit},
+		{`\pL`, unicode.IsLetter},
+		{`\PL`, func(r rune) bool { return !unicode.IsLetter(r) && r <= maxRune }},
+		{`[^a]`, func(r rune) bool { return r != 'a' && r <= maxRune }},
+		{`(?i)\p{Lu}`, isFoldUpper},
+		{`[\p{Hiragana}\p{Katakana}]`, func(r rune) bool { return unicode.In(r, unicode.Hiragana, unicode.Katakana) }},
+	}
+	for _, tt := range tests {
+		g := Must(New(tt.pattern, syntax.Perl, nil))
+		for i := 0; i < 1000; i++ {
+			s := []rune(g.Generate())
+			if len(s) != 1 || !tt.in(s[0]) {
+				t.Errorf("%s: unexpected %U", tt.pattern, s)
+			}
+		}
+	}
+}
+
+// isFoldUpper reports whether r is an upper case letter or its case folding.
+// Some of them are not letters, e.g. U+0345 is a combining mark that folds to U+0399.
+func isFoldUpper(r rune) bool {
+	for f := unicode.SimpleFold(r); ; f = unicode.SimpleFold(f) {
+		if unicode.IsUpper(f) {
+			return true
+		}
+		if f == r {
+			return false
+		}
+	}
+}
+
+func TestLimitRunes(t *testing.T) {
+	tests := []struct {
+		in, want []rune
+	}{
+		{[]rune{'a', 'z'}, []rune{'a', 'z'}},
+		{[]rune{0, 'a' - 1, 'a' + 1, unicode.MaxRune}, []rune{0, 'a' - 1, 'a' + 1, maxRune}},
+		{[]rune{'a', 'z', 0xF0000, 0xFFFFD}, []rune{'a', 'z'}},
+		{[]rune{0xF0000, 0xFFFFD}, []rune{0xF0000, 0xFFFFD}},
+		{[]rune{'a'}, []rune{'a'}},
+	}
+	for _, tt := range tests {
+		if got := limitRunes(tt.in, maxRune); !slices.Equal(got, tt.want) {
+			t.Errorf("limitRunes(%U): want %U, got %U", tt.in, tt.want, got)
+		}
+	}
+
+	g := Must(New(`[^\x00-\x{EFFFE}]`, syntax.Perl, nil))
+	for i := 0; i < 100; i++ {
+		if r := []rune(g.Generate()); r[0] != maxRune {
+			t.Errorf("unexpected %U", r)
+		}
+	}
+
+	// StreamV1 and StreamV2 keep the runes beyond maxRune.
+	for _, v := range []StreamVersion{StreamV1, StreamV2} {
+		g = Must(New(`[^\x00-\x{EFFFE}]`, syntax.Perl, nil, WithStreamVersion(v)))
+		var beyond bool
+		for i := 0; i < 100; i++ {
+			beyond = beyond || []rune(g.Generate())[0] > maxRune
+		}
+		if !beyond {
+			t.Errorf("stream version %d: want runes beyond maxRune", v)
+		}
+	}
+}
+
+func TestUnicodeClass(t *testing.T) {
+	got, err := UnicodeClass("Hiragana", "Katakana", "Lu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[\p{Hiragana}\p{Katakana}\p{Lu}]`; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if _, err := New(got, syntax.Perl, nil); err != nil {
+		t.Error(err)
+	}
+	for _, names := range [][]string{{}, {"Klingon"}} {
+		if _, err := UnicodeClass(names...); err == nil {
+			t.Errorf("%v: want error, got nil", names)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package rerand
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+// alternationMarker finds the alternations of the pattern in the compiled program.
+// It wraps each branch of alternations in a capture group, which is not used by the pattern.
+type alternationMarker struct {
+	base    int           // the first index of marker groups
+	next    int           // the next index of marker groups
+	weights map[int]int64 // the number of alternatives that each marker group represents
+}
+
+func newAlternationMarker(re *syntax.Regexp) *alternationMarker {
+	base := re.MaxCap() + 1
+	return &alternationMarker{
+		base:    base,
+		next:    base,
+		weights: map[int]int64{},
+	}
+}
+
+// mark returns the copy of re whose branches of alternations are marked.
+func (m *alternationMarker) mark(re *syntax.Regexp) *syntax.Regexp {
+	if len(re.Sub) == 0 {
+		return re
+	}
+	re2 := *re
+	re2.Sub = make([]*syntax.Regexp, len(re.Sub))
+	for i, sub := range re.Sub {
+		sub = m.mark(sub)
+		if re.Op == syntax.OpAlternate {
+			cap := m.next
+			m.next++
+			m.weights[cap] = alternatives(sub)
+			sub = &syntax.Regexp{
+				Op:    syntax.OpCapture,
+				Flags: sub.Flags,
+				Sub:   []*syntax.Regexp{sub},
+				Cap:   cap,
+			}
+		}
+		re2.Sub[i] = sub
+	}
+	return &re2
+}
+
+// markBranches returns the copy of the alternation re whose branches are marked with weights.
+func (m *alternationMarker) markBranches(re *syntax.Regexp, weights []int64) *syntax.Regexp {
+	re2 := *re
+	re2.Sub = make([]*syntax.Regexp, len(re.Sub))
+	for i, sub := range re.Sub {
+		cap := m.next
+		m.next++
+		m.weights[cap] = weights[i]
+		re2.Sub[i] = &syntax.Regexp{
+			Op:    syntax.OpCapture,
+			Flags: sub.Flags,
+			Sub:   []*syntax.Regexp{sub},
+			Cap:   cap,
+		}
+	}
+	return &re2
+}
+
+// alternatives returns the number of alternatives that the branch represents.
+// The parser factors common prefixes out, e.g. `apple|apricot|banana` into `ap(?:ple|ricot)|banana`,
+// so ap(?:ple|ricot) represents two alternatives.
+func alternatives(re *syntax.Regexp) int64 {
+	if re.Op == syntax.OpCapture && len(re.Sub) == 1 {
+		re = re.Sub[0]
+	}
+	if re.Op == syntax.OpConcat && len(re.Sub) > 0 {
+		re = re.Sub[len(re.Sub)-1]
+		if re.Op == syntax.OpCapture && len(re.Sub) == 1 {
+			re = re.Sub[0]
+		}
+	}
+	if re.Op != syntax.OpAlternate {
+		return 1
+	}
+	var n int64
+	for _, sub := range re.Sub {
+		n += alternatives(sub)
+	}
+	return n
+}
+
+// isMarker reports whether the instruction is the beginning of a marker group.
+func (m *alternationMarker) isMarker(in *syntax.Inst) bool {
+	return in.Op == syntax.InstCapture && in.Arg%2 == 0 && int(in.Arg/2) >= m.base
+}
+
+// probabilities returns x and y of alternations, where x/y is the probability of taking Out,
+// so that each alternative is chosen with equal probability.
+func (m *alternationMarker) probabilities(prog *syntax.Prog) map[uint32][2]int64 {
+	isAlternation := func(pc uint32) bool {
+		in := &prog.Inst[pc]
+		return in.Op == syntax.InstAlt && m.isMarker(&prog.Inst[in.Arg])
+	}
+	var weight func(pc uint32) int64
+	weight = func(pc uint32) int64 {
+		in := &prog.Inst[pc]
+		if isAlternation(pc) {
+			return weight(in.Out) + weight(in.Arg)
+		}
+		if m.isMarker(in) {
+			return m.weights[int(in.Arg/2)]
+		}
+		return 1
+	}
+
+	ret := map[uint32][2]int64{}
+	for pc := range prog.Inst {
+		if !isAlternation(uint32(pc)) {
+			continue
+		}
+		in := &prog.Inst[pc]
+		x := weight(in.Out)
+		ret[uint32(pc)] = [2]int64{x, x + weight(in.Arg)}
+	}
+	return ret
+}
+
+// uniformTree returns the simplified tree of pattern whose alternations are not merged by the parser,
+// e.g. `x|y|zz` keeps three branches, instead of `[xy]|zz`, so that mark sees every branch.
+// It returns re, which is the simplified tree of pattern, if the pattern cannot be rewritten.
+func uniformTree(re *syntax.Regexp, pattern string, flags syntax.Flags, o *options) *syntax.Regexp {
+	src, ok := uniformSource(pattern, flags)
+	if !ok {
+		return re
+	}
+	u, err := syntax.Parse(src, flags)
+	if err != nil || u.MaxCap() != re.MaxCap() {
+		return re
+	}
+	return applyCase(greedy(u), o.caseMode).Simplify()
+}
+
+// uniformSource returns the pattern whose branches of alternations are wrapped in `(?:...){1}`,
+// which keeps the parser from merging single runes into character classes and factoring common prefixes out.
+// The capture groups are not changed, and the wrappers are removed by Simplify.
+// The flags set in a branch, e.g. `(?i)`, are copied to the following branches, because they last until the end of the group.
+// It returns false if the pattern cannot be rewritten.
+func uniformSource(pattern string, flags syntax.Flags) (string, bool) {
+	if flags&syntax.Literal != 0 || flags&syntax.PerlX == 0 {
+		return "", false
+	}
+	type group struct {
+		open     string // the beginning of the group, e.g. "(?:"
+		branches []string
+		cur      []byte // the current branch
+		flags    string // the flags set in the group so far
+	}
+	render := func(g *group) string {
+		if len(g.branches) == 0 {
+			return string(g.cur)
+		}
+		var b strings.Builder
+		for _, branch := range append(g.branches, string(g.cur)) {
+			if b.Len() > 0 {
+				b.WriteByte('|')
+			}
+			b.WriteString("(?:" + branch + "){1}")
+		}
+		return b.String()
+	}
+
+	stack := []*group{{}}
+	for i := 0; i < len(pattern); {
+		g := stack[len(stack)-1]
+		switch pattern[i] {
+		case '\\':
+			j := skipEscape(pattern, i)
+			g.cur = append(g.cur, pattern[i:j]...)
+			i = j
+		case '[':
+			j := skipClass(pattern, i)
+			g.cur = append(g.cur, pattern[i:j]...)
+			i = j
+		case '(':
+			j := i + 1
+			switch {
+			case strings.HasPrefix(pattern[i:], "(?P<") || strings.HasPrefix(pattern[i:], "(?<"):
+				k := strings.IndexByte(pattern[i:], '>')
+				if k < 0 {
+					return "", false
+				}
+				j = i + k + 1
+			case strings.HasPrefix(pattern[i:], "(?"):
+				j = i + 2
+				for j < len(pattern) && strings.IndexByte("imsU-", pattern[j]) >= 0 {
+					j++
+				}
+				if j >= len(pattern) {
+					return "", false
+				}
+				if pattern[j] == ')' {
+					// the flags last until the end of the group.
+					g.cur = append(g.cur, pattern[i:j+1]...)
+					g.flags += pattern[i : j+1]
+					i = j + 1
+					continue
+				}
+				if pattern[j] != ':' {
+					return "", false
+				}
+				j++
+			}
+			stack = append(stack, &group{open: pattern[i:j]})
+			i = j
+		case ')':
+			if len(stack) == 1 {
+				return "", false
+			}
+			stack = stack[:len(stack)-1]
+			parent := stack[len(stack)-1]
+			parent.cur = append(parent.cur, g.open+render(g)+")"...)
+			i++
+		case '|':
+			g.branches = append(g.branches, string(g.cur))
+			g.cur = []byte(g.flags)
+			i++
+		default:
+			g.cur = append(g.cur, pattern[i])
+			i++
+		}
+	}
+	if len(stack) != 1 {
+		return "", false
+	}
+	return render(stack[0]), true
+}
+
+// skipEscape returns the end of the escape sequence beginning at pattern[i], e.g. `\d`, `\p{Greek}` and `\Q...\E`.
+func skipEscape(pattern string, i int) int {
+	if i+1 >= len(pattern) {
+		return len(pattern)
+	}
+	switch pattern[i+1] {
+	case 'Q':
+		if k := strings.Index(pattern[i+2:], `\E`); k >= 0 {
+			return i + 2 + k + 2
+		}
+		return len(pattern)
+	case 'p', 'P', 'x':
+		if i+2 < len(pattern) && pattern[i+2] == '{' {
+			if k := strings.IndexByte(pattern[i+2:], '}'); k >= 0 {
+				return i + 2 + k + 1
+			}
+			return len(pattern)
+		}
+	}
+	_, size := utf8.DecodeRuneInString(pattern[i+1:])
+	return i + 1 + size
+}
+
+// skipClass returns the end of the character class beginning at pattern[i], e.g. `[^]a-z[:digit:]]`.
+func skipClass(pattern string, i int) int {
+	j := i + 1
+	if j < len(pattern) && pattern[j] == '^' {
+		j++
+	}
+	if j < len(pattern) && pattern[j] == ']' {
+		j++
+	}
+	for j < len(pattern) {
+		switch {
+		case pattern[j] == '\\':
+			j = skipEscape(pattern, j)
+		case strings.HasPrefix(pattern[j:], "[:"):
+			if k := strings.Index(pattern[j+2:], ":]"); k >= 0 {
+				j += 2 + k + 2
+			} else {
+				j++
+			}
+		case pattern[j] == ']':
+			return j + 1
+		default:
+			j++
+		}
+	}
+	return len(pattern)
+}
@@ -0,0 +1,95 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestWithUniformAlternation(t *testing.T) {
+	const N = 100000
+	const AllowError = 2000
+	cases := []struct {
+		pattern string
+		want    []string
+	}{
+		{`admin|user|guest`, []string{"admin", "user", "guest"}},
+		{`apple|apricot|banana`, []string{"apple", "apricot", "banana"}},
+		{`a|bc|[0-9]{3}`, nil},
+		{`x|y|zz`, []string{"x", "y", "zz"}},
+		{`ab|ac|d`, []string{"ab", "ac", "d"}},
+	}
+	for _, c := range cases {
+		g := Must(New(c.pattern, syntax.Perl, rand.New(rand.NewSource(1)), WithUniformAlternation()))
+		count := map[string]int{}
+		for i := 0; i < 3*N; i++ {
+			s := g.Generate()
+			if len(s) == 3 && s[0] >= '0' && s[0] <= '9' {
+				s = "digits"
+			}
+			count[s]++
+		}
+		if len(count) != 3 {
+			t.Errorf("%s: want 3 kinds, got %v", c.pattern, count)
+		}
+		for s, n := range count {
+			if n < N-AllowError || n > N+AllowError {
+				t.Errorf("%s: incorrect count of %q(%d)", c.pattern, s, n)
+			}
+		}
+		for _, w := range c.want {
+			if _, ok := count[w]; !ok {
+				t.Errorf("%s: %q is never generated", c.pattern, w)
+			}
+		}
+	}
+}
+
+func TestWithUniformAlternationCapture(t *testing.T) {
+	g := Must(New(`(foo|bar)-(x|yz)`, syntax.Perl, nil, WithUniformAlternation(), WithVerify()))
+	for i := 0; i < 100; i++ {
+		if _, err := g.TryGenerate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUniformSource(t *testing.T) {
+	cases := []struct {
+		pattern, want string
+	}{
+		{`x|y|zz`, `(?:x){1}|(?:y){1}|(?:zz){1}`},
+		{`a(b|c)d`, `a((?:b){1}|(?:c){1})d`},
+		{`(?P<n>a|b)`, `(?P<n>(?:a){1}|(?:b){1})`},
+		{`(?i)a|b`, `(?:(?i)a){1}|(?:(?i)b){1}`},
+		{`[|(]|\||\Q|(\E|\p{Greek}`, `(?:[|(]){1}|(?:\|){1}|(?:\Q|(\E){1}|(?:\p{Greek}){1}`},
+		{`[]|]|a`, `(?:[]|]){1}|(?:a){1}`},
+		{`a|`, `(?:a){1}|(?:){1}`},
+	}
+	for _, c := range cases {
+		got, ok := uniformSource(c.pattern, syntax.Perl)
+		if !ok || got != c.want {
+			t.Errorf("%s: want %s, got %s (%t)", c.pattern, c.want, got, ok)
+		}
+		if _, err := syntax.Parse(got, syntax.Perl); err != nil {
+			t.Errorf("%s: %v", c.pattern, err)
+		}
+	}
+	if _, ok := uniformSource(`a|b`, syntax.POSIX); ok {
+		t.Error("want false for POSIX")
+	}
+}
+
+func TestWithUniformAlternationStreamV3(t *testing.T) {
+	// StreamV3 keeps the branches merged by the parser, so zz is chosen with the probability 1/2.
+	g := Must(New(`x|y|zz`, syntax.Perl, rand.New(rand.NewSource(1)), WithUniformAlternation(), WithStreamVersion(StreamV3)))
+	var zz int
+	for i := 0; i < 10000; i++ {
+		if g.Generate() == "zz" {
+			zz++
+		}
+	}
+	if zz < 4500 || zz > 5500 {
+		t.Errorf("want about 5000 of zz, got %d", zz)
+	}
+}
@@ -0,0 +1,77 @@
+package rerand
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// WithProbabilisticUnique rejects the strings that are likely generated before, and generates again.
+// It remembers the generated strings by a Bloom filter sized for expectedN strings with the false positive rate fpRate,
+// so the memory is about -expectedN*ln(fpRate)/ln(2)^2 bits regardless of the length of the strings.
+// Repeats are never generated, but a new string is rejected with the probability about fpRate, and more if more than expectedN strings are generated.
+// If no new string is found after the attempts of WithFilter, the generation fails with *FilterError.
+// The filter is shared by the clones of the Generator.
+func WithProbabilisticUnique(expectedN int, fpRate float64) Option {
+	return func(o *options) {
+		o.uniqueN = expectedN
+		o.uniqueFPRate = fpRate
+		if expectedN > 0 && fpRate > 0 && fpRate < 1 {
+			o.unique = newBloomFilter(expectedN, fpRate)
+			o.filters = append(o.filters, o.unique.add)
+		}
+	}
+}
+
+// bloomFilter is a Bloom filter of strings.
+// It is safe for concurrent use by multiple goroutines.
+type bloomFilter struct {
+	bits []atomic.Uint64
+	m    uint64 // the number of the bits
+	k    int    // the number of the hash functions
+}
+
+func newBloomFilter(n int, fpRate float64) *bloomFilter {
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	words := (uint64(m) + 63) / 64
+	return &bloomFilter{
+		bits: make([]atomic.Uint64, words),
+		m:    words * 64,
+		k:    k,
+	}
+}
+
+// add adds s to the filter, and reports whether s is possibly new.
+func (f *bloomFilter) add(s string) bool {
+	// FNV-1a, which is stable across processes for Checkpoint
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+
+	// double hashing by Kirsch and Mitzenmacher
+	h1, h2 := mix64(h), mix64(h^0x9e3779b97f4a7c15)|1
+	added := false
+	for i := 0; i < f.k; i++ {
+		bit := (h1 + uint64(i)*h2) % f.m
+		mask := uint64(1) << (bit % 64)
+		if f.bits[bit/64].Or(mask)&mask == 0 {
+			added = true
+		}
+	}
+	return added
+}
+
+// mix64 is the finalizer of SplitMix64.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,71 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestWithProbabilisticUnique(t *testing.T) {
+	g := Must(New(`[a-z]{3}`, syntax.Perl, nil, WithProbabilisticUnique(10000, 0.001)))
+	seen := map[string]bool{}
+	for i := 0; i < 5000; i++ {
+		s := g.Generate()
+		if seen[s] {
+			t.Fatalf("%q is generated twice", s)
+		}
+		seen[s] = true
+	}
+}
+
+func TestWithProbabilisticUniqueExhausted(t *testing.T) {
+	g := Must(New(`[ab]`, syntax.Perl, nil, WithProbabilisticUnique(100, 0.01)))
+	for i := 0; i < 2; i++ {
+		if _, err := g.TryGenerate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := g.TryGenerate(); !errors.Is(err, ErrFilterExhausted) {
+		t.Errorf("want ErrFilterExhausted, got %v", err)
+	}
+}
+
+func TestWithProbabilisticUniqueInvalid(t *testing.T) {
+	tests := []struct {
+		n      int
+		fpRate float64
+	}{
+		{-1, 0.01},
+		{100, 0},
+		{100, 1},
+		{0, 0.01},
+	}
+	for _, tt := range tests {
+		if _, err := New(`a`, syntax.Perl, nil, WithProbabilisticUnique(tt.n, tt.fpRate)); err == nil {
+			t.Errorf("%d, %g: want error, got nil", tt.n, tt.fpRate)
+		}
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	f := newBloomFilter(10000, 0.01)
+	for i := 0; i < 10000; i++ {
+		f.add(fmt.Sprint(i))
+	}
+	for i := 0; i < 10000; i++ {
+		if f.add(fmt.Sprint(i)) {
+			t.Fatalf("%d is not remembered", i)
+		}
+	}
+	var fp int
+	for i := 10000; i < 11000; i++ {
+		if !f.add(fmt.Sprint(i)) {
+			fp++
+		}
+	}
+	// about 10 false positives are expected, and the rate grows as the new strings are added.
+	if fp > 50 {
+		t.Errorf("too many false positives: %d", fp)
+	}
+}
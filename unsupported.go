@@ -0,0 +1,101 @@
+package rerand
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+)
+
+// ErrUnsupported is the error wrapped by *UnsupportedError.
+var ErrUnsupported = errors.New("rerand: unsupported operation")
+
+// UnsupportedError is returned by New if the pattern has an operation that the generator does not support,
+// e.g. empty-width assertions such as `^` and `\b`, and character classes that match nothing.
+type UnsupportedError struct {
+	// Pattern is the pattern of the generator.
+	Pattern string
+
+	// Op is the unsupported operation.
+	Op syntax.InstOp
+
+	// Pos is the byte offset of the operation in Pattern, or -1 if it is unknown.
+	Pos int
+
+	// Inst is the index of the instruction in the compiled program.
+	Inst int
+}
+
+func (e *UnsupportedError) Error() string {
+	if e.Pos < 0 {
+		return fmt.Sprintf("rerand: unsupported operation %v in %q", e.Op, e.Pattern)
+	}
+	return fmt.Sprintf("rerand: unsupported operation %v at offset %d in %q", e.Op, e.Pos, e.Pattern)
+}
+
+// Unwrap returns ErrUnsupported.
+func (e *UnsupportedError) Unwrap() error {
+	return ErrUnsupported
+}
+
+// checkInsts returns *UnsupportedError if the program can reach an instruction that the generator does not support.
+func checkInsts(prog *syntax.Prog, pattern string, flags syntax.Flags) error {
+	visited := make([]bool, len(prog.Inst))
+	stack := []uint32{uint32(prog.Start)}
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[pc] {
+			continue
+		}
+		visited[pc] = true
+		in := &prog.Inst[pc]
+		switch in.Op {
+		case syntax.InstMatch:
+		case syntax.InstAlt:
+			stack = append(stack, in.Out, in.Arg)
+		case syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL, syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, in.Out)
+		case syntax.InstRune:
+			if len(in.Rune) > 0 {
+				stack = append(stack, in.Out)
+				break
+			}
+			fallthrough // the character class that matches nothing
+		default:
+			return &UnsupportedError{
+				Pattern: pattern,
+				Op:      in.Op,
+				Pos:     unsupportedPos(in, pattern, flags),
+				Inst:    int(pc),
+			}
+		}
+	}
+	return nil
+}
+
+// unsupportedPos returns the byte offset of the construct that compiles to the instruction, or -1 if it is unknown.
+func unsupportedPos(in *syntax.Inst, pattern string, flags syntax.Flags) int {
+	for _, d := range Check(pattern, flags) {
+		var ok bool
+		switch in.Op {
+		case syntax.InstEmptyWidth:
+			op := syntax.EmptyOp(in.Arg)
+			switch d.Expr {
+			case "^", `\A`:
+				ok = op&(syntax.EmptyBeginLine|syntax.EmptyBeginText) != 0
+			case "$", `\z`:
+				ok = op&(syntax.EmptyEndLine|syntax.EmptyEndText) != 0
+			case `\b`:
+				ok = op&syntax.EmptyWordBoundary != 0
+			case `\B`:
+				ok = op&syntax.EmptyNoWordBoundary != 0
+			}
+		case syntax.InstRune, syntax.InstFail:
+			ok = len(d.Expr) > 0 && d.Expr[0] == '['
+		}
+		if ok {
+			return d.Offset
+		}
+	}
+	return -1
+}
@@ -0,0 +1,36 @@
+package rerand
+
+import (
+	"errors"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestUnsupportedError(t *testing.T) {
+	tests := []struct {
+		pattern string
+		op      syntax.InstOp
+		pos     int
+	}{
+		{`^abc`, syntax.InstEmptyWidth, 0},
+		{`abc$`, syntax.InstEmptyWidth, 3},
+		{`(?m)a{2}^b`, syntax.InstEmptyWidth, 8},
+		{`\d{3}\bx`, syntax.InstEmptyWidth, 5},
+		{`x[^\x00-\x{10FFFF}]`, syntax.InstRune, 1},
+		{`[^\x00-\x{10FFFF}]|y{0}`, syntax.InstRune, 0},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.pattern, syntax.Perl, nil)
+		var uerr *UnsupportedError
+		if !errors.As(err, &uerr) {
+			t.Errorf("%s: want *UnsupportedError, got %v", tt.pattern, err)
+			continue
+		}
+		if !errors.Is(err, ErrUnsupported) {
+			t.Errorf("%s: want ErrUnsupported, got %v", tt.pattern, err)
+		}
+		if uerr.Op != tt.op || uerr.Pos != tt.pos {
+			t.Errorf("%s: want %v at %d, got %v at %d", tt.pattern, tt.op, tt.pos, uerr.Op, uerr.Pos)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package rerand
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// VerifyError is returned if a generated string does not match the pattern with WithVerify.
+type VerifyError struct {
+	Pattern string
+	Output  string
+}
+
+func (e *VerifyError) Error() string {
+	return fmt.Sprintf("rerand: generated string %q does not match %q", e.Output, e.Pattern)
+}
+
+// verify checks s matches the pattern if WithVerify is given.
+func (g *Generator) verify(s string) error {
+	if g.verifier == nil || g.verifier.MatchString(s) {
+		return nil
+	}
+	g.log(slog.LevelError, "rerand: generated string does not match", slog.String("output", s))
+	return &VerifyError{
+		Pattern: g.pattern,
+		Output:  s,
+	}
+}
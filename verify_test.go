@@ -0,0 +1,44 @@
+package rerand
+
+import (
+	"errors"
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestWithVerify(t *testing.T) {
+	patterns := []string{
+		`[a-z]{1,8}`,
+		`(?i)abc`,
+		`(?s).{3}`,
+		`\d{2,3}-\d{3,4}-\d{3,4}`,
+		`\p{Greek}+`,
+	}
+	for _, p := range patterns {
+		g, err := New(p, syntax.Perl, rand.New(rand.NewSource(1)), WithVerify(), WithTargetLength(5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 1000; i++ {
+			if _, err := g.TryGenerate(); err != nil {
+				t.Errorf("%s: unexpected error: %v", p, err)
+				break
+			}
+		}
+	}
+}
+
+func TestVerifyError(t *testing.T) {
+	g := Must(New(`abc`, syntax.Perl, nil, WithVerify()))
+	// break the generator on purpose
+	g.code[0].literal = []rune("xbc")
+	_, err := g.TryGenerate()
+	var verr *VerifyError
+	if !errors.As(err, &verr) {
+		t.Fatalf("want *VerifyError, got %v", err)
+	}
+	if verr.Output != "xbc" {
+		t.Errorf("want xbc, got %q", verr.Output)
+	}
+}
@@ -0,0 +1,29 @@
+package rerand
+
+// WeightMode is the semantics of the probabilities of the branches of alternations and repetitions.
+type WeightMode int
+
+const (
+	// WeightBranchCount weights each branch by the number of the ways to choose the branches in it,
+	// and counts a character class as one way. It is the default.
+	// For example, `(?:xx|yy)z|[0-9]` generates xxz, yyz and a digit with the probability 1/3 each.
+	WeightBranchCount WeightMode = iota
+
+	// WeightStringCount weights each branch by the number of the strings in it, same as WithDistinctRunes.
+	// For example, `(?:xx|yy)z|[0-9]` generates xxz and yyz with the probability 1/12 each, and a digit with 10/12.
+	WeightStringCount
+
+	// WeightUniform chooses every branch of alternations with equal probability, same as WithUniformAlternation.
+	// For example, `(?:xx|yy)z|[0-9]` generates a digit with the probability 1/2, and xxz and yyz with 1/4 each.
+	WeightUniform
+)
+
+// WithWeightMode sets the semantics of the probabilities.
+// It overrides WithDistinctRunes and WithUniformAlternation given before it.
+func WithWeightMode(mode WeightMode) Option {
+	return func(o *options) {
+		o.weightMode = mode
+		o.distinctRunes = mode == WeightStringCount
+		o.uniformAlternation = mode == WeightUniform
+	}
+}
@@ -0,0 +1,53 @@
+package rerand
+
+import (
+	"math/rand"
+	"regexp/syntax"
+	"testing"
+)
+
+func TestWithWeightMode(t *testing.T) {
+	tests := []struct {
+		mode WeightMode
+		want map[string]float64 // the probability of xxz, yyz and a digit
+	}{
+		{WeightBranchCount, map[string]float64{"xxz": 1.0 / 3, "yyz": 1.0 / 3, "digit": 1.0 / 3}},
+		{WeightStringCount, map[string]float64{"xxz": 1.0 / 12, "yyz": 1.0 / 12, "digit": 10.0 / 12}},
+		{WeightUniform, map[string]float64{"xxz": 1.0 / 4, "yyz": 1.0 / 4, "digit": 1.0 / 2}},
+	}
+	const n = 12000
+	for _, tt := range tests {
+		g := Must(New(`(?:xx|yy)z|[0-9]`, syntax.Perl, rand.New(rand.NewSource(1)), WithWeightMode(tt.mode)))
+		counts := map[string]int{}
+		for i := 0; i < n; i++ {
+			s := g.Generate()
+			if len(s) == 1 {
+				s = "digit"
+			}
+			counts[s]++
+		}
+		for s, p := range tt.want {
+			want := p * n
+			if got := float64(counts[s]); got < want*0.9 || got > want*1.1 {
+				t.Errorf("mode %d: %s is generated %.0f times, want about %.0f", tt.mode, s, got, want)
+			}
+		}
+	}
+}
+
+func TestWithWeightModeOverrides(t *testing.T) {
+	g := Must(New(`a`, syntax.Perl, nil, WithDistinctRunes(), WithUniformAlternation(), WithWeightMode(WeightBranchCount)))
+	if g.opts.distinctRunes || g.opts.uniformAlternation {
+		t.Error("want the options overridden")
+	}
+	g = Must(New(`a`, syntax.Perl, nil, WithWeightMode(WeightUniform)))
+	if !g.opts.uniformAlternation {
+		t.Error("want uniform alternation")
+	}
+}
+
+func TestWithWeightModeInvalid(t *testing.T) {
+	if _, err := New(`a`, syntax.Perl, nil, WithWeightMode(WeightMode(100))); err == nil {
+		t.Error("want error, got nil")
+	}
+}